@@ -130,6 +130,15 @@ func createPrecompileHook(abifilename string, template string) bind.BindHook {
 			for _, key := range allowlist.AllowListFuncKeys {
 				delete(funcs, key)
 			}
+			for _, key := range allowlist.AllowListExpiryFuncKeys {
+				delete(funcs, key)
+			}
+			for _, key := range allowlist.AllowListEnumerationFuncKeys {
+				delete(funcs, key)
+			}
+			for _, key := range allowlist.AdminThresholdFuncKeys {
+				delete(funcs, key)
+			}
 		}
 
 		precompileContract := &tmplPrecompileContract{
@@ -469,7 +469,7 @@ var bindTests = []struct {
 			stateDB := state.NewTestStateDB(t)
 			address := common.BigToAddress(big.NewInt(1))
 			SetHelloWorldAllowListStatus(stateDB, address, allowlist.EnabledRole)
-			role := GetHelloWorldAllowListStatus(stateDB, address)
+			role := GetHelloWorldAllowListStatus(stateDB, address, 0)
 			require.Equal(t, role, allowlist.EnabledRole)
 		`,
 		"",
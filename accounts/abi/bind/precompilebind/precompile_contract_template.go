@@ -121,9 +121,9 @@ type {{capitalise .Normalized.Name}}Output struct{
 {{- end}}
 
 {{if .Contract.AllowList}}
-// Get{{.Contract.Type}}AllowListStatus returns the role of [address] for the {{.Contract.Type}} list.
-func Get{{.Contract.Type}}AllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// Get{{.Contract.Type}}AllowListStatus returns the role of [address] as of [timestamp] for the {{.Contract.Type}} list.
+func Get{{.Contract.Type}}AllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // Set{{.Contract.Type}}AllowListStatus sets the permissions of [address] to [role] for the
@@ -252,7 +252,7 @@ func {{decapitalise .Normalized.Name}}(accessibleState contract.AccessibleState,
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannot{{.Normalized.Name}}, caller)
 	}
@@ -303,7 +303,7 @@ func {{decapitalise $contract.Type}}Fallback (accessibleState contract.Accessibl
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", Err{{$contract.Type}}CannotFallback, caller)
 	}
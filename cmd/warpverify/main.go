@@ -0,0 +1,186 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/internal/flags"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	messageFlag = &cli.StringFlag{
+		Name:  "message",
+		Usage: "Hex encoded signed warp message, - for STDIN",
+	}
+	predicateFlag = &cli.BoolFlag{
+		Name:  "predicate",
+		Usage: "Whether the message is predicate encoded, as in the storage keys of a transaction access list",
+	}
+	validatorsFlag = &cli.StringFlag{
+		Name:  "validators",
+		Usage: "Path to the JSON validator set snapshot of the source subnet",
+	}
+	quorumNumeratorFlag = &cli.Uint64Flag{
+		Name:  "quorum-numerator",
+		Usage: fmt.Sprintf("Quorum numerator out of %d required for the message to verify", params.WarpQuorumDenominator),
+		Value: params.WarpDefaultQuorumNumerator,
+	}
+	networkIDFlag = &cli.UintFlag{
+		Name:  "network-id",
+		Usage: "Network ID the message must be sent on (default = network ID of the message)",
+	}
+)
+
+var (
+	errNoMessage    = errors.New("no message is specified (--message)")
+	errNoValidators = errors.New("no validator snapshot is specified (--validators)")
+	errNotBitSet    = errors.New("warp message signature is not a bit set signature")
+)
+
+var app = flags.NewApp("subnet-evm offline warp message verification tool")
+
+func init() {
+	app.Name = "warpverify"
+	app.Flags = []cli.Flag{
+		messageFlag,
+		predicateFlag,
+		validatorsFlag,
+		quorumNumeratorFlag,
+		networkIDFlag,
+	}
+	app.Action = warpVerify
+}
+
+// signer is a validator of the source subnet that signed a warp message.
+type signer struct {
+	index   int
+	nodeIDs []ids.NodeID
+	weight  uint64
+}
+
+// verification is the result of verifying a warp message against a validator set snapshot.
+type verification struct {
+	signers      []signer
+	signedWeight uint64
+	totalWeight  uint64
+	// err is nil if the signature of the message verifies
+	err error
+}
+
+func warpVerify(c *cli.Context) error {
+	if !c.IsSet(messageFlag.Name) {
+		return errNoMessage
+	}
+	if !c.IsSet(validatorsFlag.Name) {
+		return errNoValidators
+	}
+	quorumNumerator := c.Uint64(quorumNumeratorFlag.Name)
+	if quorumNumerator < params.WarpQuorumNumeratorMinimum || quorumNumerator > params.WarpQuorumDenominator {
+		return fmt.Errorf("quorum numerator %d outside of [%d, %d]", quorumNumerator, params.WarpQuorumNumeratorMinimum, params.WarpQuorumDenominator)
+	}
+
+	warpMsg, err := readMessage(c.String(messageFlag.Name), c.Bool(predicateFlag.Name))
+	if err != nil {
+		return err
+	}
+	snapshot, err := readValidatorSnapshot(c.String(validatorsFlag.Name))
+	if err != nil {
+		return err
+	}
+	networkID := warpMsg.NetworkID
+	if c.IsSet(networkIDFlag.Name) {
+		networkID = uint32(c.Uint(networkIDFlag.Name))
+	}
+
+	fmt.Printf("Message ID:      %s\n", warpMsg.ID())
+	fmt.Printf("Network ID:      %d\n", warpMsg.NetworkID)
+	fmt.Printf("Source Chain ID: %s\n", warpMsg.SourceChainID)
+	fmt.Printf("Payload:         0x%x\n", warpMsg.Payload)
+
+	result, err := verify(context.Background(), warpMsg, networkID, snapshot, quorumNumerator)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Signers (%d):\n", len(result.signers))
+	for _, signer := range result.signers {
+		fmt.Printf("  %d: %v weight %d\n", signer.index, signer.nodeIDs, signer.weight)
+	}
+	fmt.Printf("Signed weight:   %d / %d (quorum %d / %d)\n", result.signedWeight, result.totalWeight, quorumNumerator, params.WarpQuorumDenominator)
+	if result.err != nil {
+		return fmt.Errorf("warp message signature does not verify: %w", result.err)
+	}
+	fmt.Println("Warp message signature verifies")
+	return nil
+}
+
+// readMessage parses the hex encoded warp message [input], read from STDIN if [input] is "-".
+func readMessage(input string, isPredicate bool) (*avalancheWarp.Message, error) {
+	if input == "-" {
+		inputBytes, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+		input = string(inputBytes)
+	}
+	messageBytes := common.FromHex(strings.TrimSpace(input))
+	if isPredicate {
+		var err error
+		messageBytes, err = predicate.UnpackPredicate(messageBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack predicate: %w", err)
+		}
+	}
+	warpMsg, err := avalancheWarp.ParseMessage(messageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse warp message: %w", err)
+	}
+	return warpMsg, nil
+}
+
+// verify verifies [warpMsg] against [snapshot] the same way the warp precompile verifies predicates,
+// and returns the signers of the message. A non-nil error is only returned if the signers cannot be
+// determined; a signature that does not verify is reported in the returned verification.
+func verify(ctx context.Context, warpMsg *avalancheWarp.Message, networkID uint32, snapshot *validatorSnapshot, quorumNumerator uint64) (*verification, error) {
+	signature, ok := warpMsg.Signature.(*avalancheWarp.BitSetSignature)
+	if !ok {
+		return nil, errNotBitSet
+	}
+	vdrs, totalWeight, err := avalancheWarp.GetCanonicalValidatorSet(ctx, snapshot, 0, snapshot.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &verification{totalWeight: totalWeight}
+	signerIndices := set.BitsFromBytes(signature.Signers)
+	for i, vdr := range vdrs {
+		if !signerIndices.Contains(i) {
+			continue
+		}
+		result.signers = append(result.signers, signer{index: i, nodeIDs: vdr.NodeIDs, weight: vdr.Weight})
+		result.signedWeight += vdr.Weight
+	}
+	result.err = warp.VerifyMessage(ctx, warpMsg, networkID, snapshot, 0, quorumNumerator)
+	return result, nil
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
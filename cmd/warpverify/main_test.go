@@ -0,0 +1,94 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+// newSignedMessage returns a message signed by the validators at [signerIndices] of the canonical
+// validator set of [snapshot], given the secret keys of the snapshot validators in [secretKeys].
+func newSignedMessage(t *testing.T, snapshot *validatorSnapshot, secretKeys []*bls.SecretKey, signerIndices ...int) *avalancheWarp.Message {
+	require := require.New(t)
+
+	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	secretKeysByPublicKey := make(map[string]*bls.SecretKey, len(secretKeys))
+	for _, sk := range secretKeys {
+		secretKeysByPublicKey[string(bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)))] = sk
+	}
+	vdrs, _, err := avalancheWarp.GetCanonicalValidatorSet(context.Background(), snapshot, 0, snapshot.SubnetID)
+	require.NoError(err)
+
+	signers := set.NewBits()
+	signatures := make([]*bls.Signature, 0, len(signerIndices))
+	for _, i := range signerIndices {
+		signers.Add(i)
+		signatures = append(signatures, bls.Sign(secretKeysByPublicKey[string(bls.PublicKeyToBytes(vdrs[i].PublicKey))], unsignedMsg.Bytes()))
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	require.NoError(err)
+	signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+
+	warpMsg, err := avalancheWarp.NewMessage(unsignedMsg, signature)
+	require.NoError(err)
+	return warpMsg
+}
+
+func TestVerify(t *testing.T) {
+	require := require.New(t)
+
+	snapshot := &validatorSnapshot{SubnetID: ids.GenerateTestID()}
+	secretKeys := make([]*bls.SecretKey, 0, 3)
+	for i := 0; i < 3; i++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		secretKeys = append(secretKeys, sk)
+		snapshot.Validators = append(snapshot.Validators, snapshotValidator{
+			NodeID:    ids.GenerateTestNodeID(),
+			PublicKey: bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)),
+			Weight:    10,
+		})
+	}
+
+	// Round trip the snapshot through its JSON file format
+	snapshotBytes, err := json.Marshal(snapshot)
+	require.NoError(err)
+	snapshotPath := filepath.Join(t.TempDir(), "validators.json")
+	require.NoError(os.WriteFile(snapshotPath, snapshotBytes, 0o600))
+	snapshot, err = readValidatorSnapshot(snapshotPath)
+	require.NoError(err)
+
+	warpMsg := newSignedMessage(t, snapshot, secretKeys, 0, 2)
+	result, err := verify(context.Background(), warpMsg, warpMsg.NetworkID, snapshot, 50)
+	require.NoError(err)
+	require.NoError(result.err)
+	require.Len(result.signers, 2)
+	require.Equal(0, result.signers[0].index)
+	require.Equal(2, result.signers[1].index)
+	require.Equal(uint64(20), result.signedWeight)
+	require.Equal(uint64(30), result.totalWeight)
+
+	// The same signers do not meet a higher quorum
+	result, err = verify(context.Background(), warpMsg, warpMsg.NetworkID, snapshot, 67)
+	require.NoError(err)
+	require.ErrorIs(result.err, avalancheWarp.ErrInsufficientWeight)
+
+	// Messages for another network do not verify
+	result, err = verify(context.Background(), warpMsg, warpMsg.NetworkID+1, snapshot, 50)
+	require.NoError(err)
+	require.ErrorIs(result.err, avalancheWarp.ErrWrongNetworkID)
+}
@@ -0,0 +1,83 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ validators.State = (*validatorSnapshot)(nil)
+
+// validatorSnapshot is the validator set of the source subnet of a warp message, as read from a JSON file:
+//
+//	{
+//	  "subnetID": "2bRCr6B4MiEfSjidDwxDpdCyviwnfUVqB2HGwhm947w9YYqb7r",
+//	  "validators": [
+//	    {"nodeID": "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg", "publicKey": "0x8f95...", "weight": 2000}
+//	  ]
+//	}
+//
+// It implements validators.State by returning its validators for any subnet and height, so that
+// warp messages can be verified exactly as on a node without access to the P-Chain.
+type validatorSnapshot struct {
+	SubnetID   ids.ID              `json:"subnetID"`
+	Validators []snapshotValidator `json:"validators"`
+}
+
+type snapshotValidator struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Compressed BLS public key, empty if the validator has not registered one
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	Weight    uint64        `json:"weight"`
+}
+
+func readValidatorSnapshot(path string) (*validatorSnapshot, error) {
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &validatorSnapshot{}
+	if err := json.Unmarshal(snapshotBytes, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse validator snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (*validatorSnapshot) GetMinimumHeight(context.Context) (uint64, error) { return 0, nil }
+
+func (*validatorSnapshot) GetCurrentHeight(context.Context) (uint64, error) { return 0, nil }
+
+func (s *validatorSnapshot) GetSubnetID(context.Context, ids.ID) (ids.ID, error) {
+	return s.SubnetID, nil
+}
+
+func (s *validatorSnapshot) GetValidatorSet(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	validatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(s.Validators))
+	for _, validator := range s.Validators {
+		if _, ok := validatorSet[validator.NodeID]; ok {
+			return nil, fmt.Errorf("duplicate validator %s in snapshot", validator.NodeID)
+		}
+		output := &validators.GetValidatorOutput{
+			NodeID: validator.NodeID,
+			Weight: validator.Weight,
+		}
+		if len(validator.PublicKey) != 0 {
+			publicKey, err := bls.PublicKeyFromBytes(validator.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key of validator %s: %w", validator.NodeID, err)
+			}
+			output.PublicKey = publicKey
+		}
+		validatorSet[validator.NodeID] = output
+	}
+	return validatorSet, nil
+}
@@ -0,0 +1,68 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package commontype
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// FeeSplitTotalBasisPoints is the number of basis points that make up all of the fees of a block.
+	FeeSplitTotalBasisPoints = 10_000
+	// MaxFeeSplitRecipients is the maximum number of recipients fees can be split across.
+	MaxFeeSplitRecipients = 8
+)
+
+var (
+	ErrEmptyFeeSplit              = errors.New("fee split must have at least one recipient")
+	ErrTooManyFeeSplitRecipients  = fmt.Errorf("fee split cannot have more than %d recipients", MaxFeeSplitRecipients)
+	ErrEmptyFeeSplitAddress       = errors.New("fee split recipient address cannot be empty")
+	ErrDuplicateFeeSplitRecipient = errors.New("duplicate fee split recipient")
+	ErrZeroFeeSplitShare          = errors.New("fee split recipient share cannot be zero")
+	ErrFeeSplitSharesExceedTotal  = fmt.Errorf("fee split shares cannot exceed %d basis points", FeeSplitTotalBasisPoints)
+)
+
+// FeeSplitRecipient specifies the share of the fees of a block paid to [Address] in basis points.
+// The share of the fees not assigned to any recipient is paid to the coinbase of the block.
+// This struct is used by the Reward Manager precompile and the consensus engine.
+type FeeSplitRecipient struct {
+	Address     common.Address `json:"address"`
+	BasisPoints uint16         `json:"basisPoints"`
+}
+
+// VerifyFeeSplit checks that [recipients] is a valid fee split: a non-empty list of at most
+// [MaxFeeSplitRecipients] distinct, non-empty addresses with non-zero shares that sum up to
+// at most [FeeSplitTotalBasisPoints].
+func VerifyFeeSplit(recipients []FeeSplitRecipient) error {
+	if len(recipients) == 0 {
+		return ErrEmptyFeeSplit
+	}
+	if len(recipients) > MaxFeeSplitRecipients {
+		return ErrTooManyFeeSplitRecipients
+	}
+	var (
+		seen  = make(map[common.Address]struct{}, len(recipients))
+		total uint64
+	)
+	for _, recipient := range recipients {
+		if recipient.Address == (common.Address{}) {
+			return ErrEmptyFeeSplitAddress
+		}
+		if _, ok := seen[recipient.Address]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateFeeSplitRecipient, recipient.Address)
+		}
+		seen[recipient.Address] = struct{}{}
+		if recipient.BasisPoints == 0 {
+			return fmt.Errorf("%w: %s", ErrZeroFeeSplitShare, recipient.Address)
+		}
+		total += uint64(recipient.BasisPoints)
+	}
+	if total > FeeSplitTotalBasisPoints {
+		return fmt.Errorf("%w: %d", ErrFeeSplitSharesExceedTotal, total)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package commontype
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFeeSplit(t *testing.T) {
+	var (
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+	)
+	tests := []struct {
+		name        string
+		recipients  []FeeSplitRecipient
+		expectedErr error
+	}{
+		{
+			name:        "empty fee split",
+			recipients:  nil,
+			expectedErr: ErrEmptyFeeSplit,
+		},
+		{
+			name: "too many recipients",
+			recipients: func() []FeeSplitRecipient {
+				recipients := make([]FeeSplitRecipient, MaxFeeSplitRecipients+1)
+				for i := range recipients {
+					recipients[i] = FeeSplitRecipient{Address: common.BigToAddress(common.Big1), BasisPoints: 1}
+				}
+				return recipients
+			}(),
+			expectedErr: ErrTooManyFeeSplitRecipients,
+		},
+		{
+			name:        "empty address",
+			recipients:  []FeeSplitRecipient{{BasisPoints: 100}},
+			expectedErr: ErrEmptyFeeSplitAddress,
+		},
+		{
+			name:        "duplicate recipient",
+			recipients:  []FeeSplitRecipient{{Address: addr1, BasisPoints: 100}, {Address: addr1, BasisPoints: 100}},
+			expectedErr: ErrDuplicateFeeSplitRecipient,
+		},
+		{
+			name:        "zero share",
+			recipients:  []FeeSplitRecipient{{Address: addr1, BasisPoints: 0}},
+			expectedErr: ErrZeroFeeSplitShare,
+		},
+		{
+			name:        "shares exceed total",
+			recipients:  []FeeSplitRecipient{{Address: addr1, BasisPoints: 6_000}, {Address: addr2, BasisPoints: 4_001}},
+			expectedErr: ErrFeeSplitSharesExceedTotal,
+		},
+		{
+			name:        "shares sum up to total",
+			recipients:  []FeeSplitRecipient{{Address: addr1, BasisPoints: 6_000}, {Address: addr2, BasisPoints: 4_000}},
+			expectedErr: nil,
+		},
+		{
+			name:        "shares below total",
+			recipients:  []FeeSplitRecipient{{Address: addr1, BasisPoints: 1}},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, VerifyFeeSplit(test.recipients), test.expectedErr)
+		})
+	}
+}
@@ -58,9 +58,17 @@ type ChainHeaderReader interface {
 	// GetFeeConfigAt retrieves the fee config and last changed block number at block header.
 	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
 
+	// GetPendingFeeConfigAt retrieves the fee config scheduled at [parent] and the timestamp it
+	// takes effect at. The returned timestamp is 0 if no fee config change is scheduled.
+	GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error)
+
 	// GetCoinbaseAt retrieves the configured coinbase address at [parent].
 	// If fee recipients are allowed, returns true in the second return value and a predefined address in the first value.
 	GetCoinbaseAt(parent *types.Header) (common.Address, bool, error)
+
+	// GetFeeSplitAt retrieves the fee split configured at [parent].
+	// Returns nil if the fees of a child of [parent] are not split.
+	GetFeeSplitAt(parent *types.Header) ([]commontype.FeeSplitRecipient, error)
 }
 
 // ChainReader defines a small collection of methods needed to access the local
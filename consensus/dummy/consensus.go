@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
@@ -120,7 +122,7 @@ func (self *DummyEngine) verifyHeaderGasFields(config *params.ChainConfig, heade
 	// Fee config might depend on the state when precompile is activated
 	// but we don't know the final state while forming the block.
 	// See worker package for more details.
-	feeConfig, _, err := chain.GetFeeConfigAt(parent)
+	feeConfig, err := GetFeeConfigAt(chain, parent, header.Time)
 	if err != nil {
 		return err
 	}
@@ -337,11 +339,47 @@ func (self *DummyEngine) verifyBlockFee(
 	return nil
 }
 
+// applyFeeSplit transfers the share of each recipient of the fee split configured at [parent] from the
+// fees collected by [coinbase] in a block with [baseFee], [txs] and [receipts]. The share of the fees not
+// assigned to any recipient stays with [coinbase].
+// Since [coinbase] may spend the fees it collects within the block before the split is applied at
+// finalization, each share is capped at the remaining balance of [coinbase], paying the recipients
+// in order.
+func applyFeeSplit(chain consensus.ChainHeaderReader, parent *types.Header, coinbase common.Address, baseFee *big.Int, txs []*types.Transaction, receipts []*types.Receipt, state *state.StateDB) error {
+	recipients, err := chain.GetFeeSplitAt(parent)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+	if len(txs) != len(receipts) {
+		return fmt.Errorf("mismatch of transactions (length %d) and receipts (length %d)", len(txs), len(receipts))
+	}
+	// Each transaction pays gasUsed * (baseFee + effectiveGasTip) to the coinbase.
+	totalFees := new(big.Int)
+	for i, tx := range txs {
+		gasPrice := new(big.Int).Add(baseFee, tx.EffectiveGasTipValue(baseFee))
+		totalFees.Add(totalFees, gasPrice.Mul(gasPrice, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	for _, recipient := range recipients {
+		share := new(big.Int).Mul(totalFees, new(big.Int).SetUint64(uint64(recipient.BasisPoints)))
+		share.Div(share, big.NewInt(commontype.FeeSplitTotalBasisPoints))
+		if balance := state.GetBalance(coinbase); share.Cmp(balance) > 0 {
+			log.Warn("Capping fee split share at the remaining coinbase balance", "parent", parent.Hash(), "coinbase", coinbase, "recipient", recipient.Address, "share", share, "balance", balance)
+			share.Set(balance)
+		}
+		state.SubBalance(coinbase, share)
+		state.AddBalance(recipient.Address, share)
+	}
+	return nil
+}
+
 func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *types.Block, parent *types.Header, state *state.StateDB, receipts []*types.Receipt) error {
 	if chain.Config().IsSubnetEVM(block.Time()) {
 		// we use the parent to determine the fee config
 		// since the current block has not been finalized yet.
-		feeConfig, _, err := chain.GetFeeConfigAt(parent)
+		feeConfig, err := GetFeeConfigAt(chain, parent, block.Time())
 		if err != nil {
 			return err
 		}
@@ -369,6 +407,10 @@ func (self *DummyEngine) Finalize(chain consensus.ChainHeaderReader, block *type
 		); err != nil {
 			return err
 		}
+		// Pay the shares of the fee split out of the fees collected by the coinbase.
+		if err := applyFeeSplit(chain, parent, block.Coinbase(), block.BaseFee(), block.Transactions(), receipts, state); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -380,7 +422,7 @@ func (self *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader,
 	if chain.Config().IsSubnetEVM(header.Time) {
 		// we use the parent to determine the fee config
 		// since the current block has not been finalized yet.
-		feeConfig, _, err := chain.GetFeeConfigAt(parent)
+		feeConfig, err := GetFeeConfigAt(chain, parent, header.Time)
 		if err != nil {
 			return nil, err
 		}
@@ -402,6 +444,10 @@ func (self *DummyEngine) FinalizeAndAssemble(chain consensus.ChainHeaderReader,
 		); err != nil {
 			return nil, err
 		}
+		// Pay the shares of the fee split out of the fees collected by the coinbase.
+		if err := applyFeeSplit(chain, parent, header.Coinbase, header.BaseFee, txs, receipts, state); err != nil {
+			return nil, err
+		}
 	}
 	// commit the final state root
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
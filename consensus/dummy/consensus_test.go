@@ -8,9 +8,14 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var testBlockGasCostStep = big.NewInt(50_000)
@@ -140,3 +145,102 @@ func TestVerifyBlockFee(t *testing.T) {
 		})
 	}
 }
+
+// testFeeSplitReader serves a fixed fee split at every parent.
+type testFeeSplitReader struct {
+	consensus.ChainHeaderReader
+
+	feeSplit []commontype.FeeSplitRecipient
+}
+
+func (r *testFeeSplitReader) GetFeeSplitAt(parent *types.Header) ([]commontype.FeeSplitRecipient, error) {
+	return r.feeSplit, nil
+}
+
+func TestApplyFeeSplit(t *testing.T) {
+	var (
+		coinbase   = common.HexToAddress("0x0100")
+		recipient1 = common.HexToAddress("0x0101")
+		recipient2 = common.HexToAddress("0x0102")
+		to         = common.HexToAddress("7ef5a6135f1fd6a02593eedc869c6d41d934aef8")
+		baseFee    = big.NewInt(100)
+		txs        = []*types.Transaction{
+			types.NewTransaction(0, to, big.NewInt(0), 100_000, big.NewInt(200), nil),
+			types.NewTransaction(1, to, big.NewInt(0), 100_000, big.NewInt(100), nil),
+		}
+		receipts = []*types.Receipt{
+			{GasUsed: 100_000},
+			{GasUsed: 100_000},
+		}
+		// The coinbase collected 100_000 * 200 + 100_000 * 100 while processing [txs].
+		totalFees = big.NewInt(30_000_000)
+	)
+
+	tests := map[string]struct {
+		feeSplit         []commontype.FeeSplitRecipient
+		coinbaseBalance  *big.Int // Defaults to [totalFees]
+		expectedBalances map[common.Address]*big.Int
+	}{
+		"no fee split": {
+			feeSplit: nil,
+			expectedBalances: map[common.Address]*big.Int{
+				coinbase:   totalFees,
+				recipient1: big.NewInt(0),
+				recipient2: big.NewInt(0),
+			},
+		},
+		"remainder stays with coinbase": {
+			feeSplit: []commontype.FeeSplitRecipient{
+				{Address: recipient1, BasisPoints: 2_500},
+				{Address: recipient2, BasisPoints: 1_000},
+			},
+			expectedBalances: map[common.Address]*big.Int{
+				coinbase:   big.NewInt(19_500_000),
+				recipient1: big.NewInt(7_500_000),
+				recipient2: big.NewInt(3_000_000),
+			},
+		},
+		"full split": {
+			feeSplit: []commontype.FeeSplitRecipient{
+				{Address: recipient1, BasisPoints: 5_000},
+				{Address: recipient2, BasisPoints: 5_000},
+			},
+			expectedBalances: map[common.Address]*big.Int{
+				coinbase:   big.NewInt(0),
+				recipient1: big.NewInt(15_000_000),
+				recipient2: big.NewInt(15_000_000),
+			},
+		},
+		"coinbase spent fees in the block": {
+			feeSplit: []commontype.FeeSplitRecipient{
+				{Address: recipient1, BasisPoints: 5_000},
+				{Address: recipient2, BasisPoints: 5_000},
+			},
+			// The coinbase sent a transaction in the same block, spending 20_000_000 of its fees.
+			coinbaseBalance: big.NewInt(10_000_000),
+			expectedBalances: map[common.Address]*big.Int{
+				coinbase:   big.NewInt(0),
+				recipient1: big.NewInt(10_000_000),
+				recipient2: big.NewInt(0),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			coinbaseBalance := totalFees
+			if test.coinbaseBalance != nil {
+				coinbaseBalance = test.coinbaseBalance
+			}
+			statedb.AddBalance(coinbase, coinbaseBalance)
+
+			chain := &testFeeSplitReader{feeSplit: test.feeSplit}
+			require.NoError(t, applyFeeSplit(chain, &types.Header{}, coinbase, baseFee, txs, receipts, statedb))
+			for addr, expected := range test.expectedBalances {
+				require.Zero(t, expected.Cmp(statedb.GetBalance(addr)), addr)
+			}
+		})
+	}
+}
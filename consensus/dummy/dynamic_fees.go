@@ -10,12 +10,37 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 )
 
+// FeeConfigReader retrieves the fee config and the scheduled fee config stored at a block.
+type FeeConfigReader interface {
+	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error)
+}
+
+// GetFeeConfigAt returns the fee config in effect for a child of [parent] with [timestamp].
+// This is the fee config at [parent], unless a fee config change scheduled at [parent] takes
+// effect at or before [timestamp], in which case the scheduled fee config is returned.
+func GetFeeConfigAt(chain FeeConfigReader, parent *types.Header, timestamp uint64) (commontype.FeeConfig, error) {
+	feeConfig, _, err := chain.GetFeeConfigAt(parent)
+	if err != nil {
+		return commontype.EmptyFeeConfig, err
+	}
+	pendingFeeConfig, activationTimestamp, err := chain.GetPendingFeeConfigAt(parent)
+	if err != nil {
+		return commontype.EmptyFeeConfig, err
+	}
+	if activationTimestamp != 0 && timestamp >= activationTimestamp {
+		return pendingFeeConfig, nil
+	}
+	return feeConfig, nil
+}
+
 // CalcBaseFee takes the previous header and the timestamp of its child block
 // and calculates the expected base fee as well as the encoding of the past
 // pricing information for the child block.
@@ -240,6 +265,33 @@ func calcBlockGasCost(
 	return blockGasCost
 }
 
+// EstimateNextBlockFee returns the base fee and the block gas cost required of a child of [parent]
+// with [timestamp]. The transactions of the child must pay tips covering the block gas cost at the
+// base fee. Both are nil prior to Subnet EVM, when no block fee is required.
+func EstimateNextBlockFee(chain consensus.ChainHeaderReader, parent *types.Header, timestamp uint64) (*big.Int, *big.Int, error) {
+	config := chain.Config()
+	if !config.IsSubnetEVM(timestamp) {
+		return nil, nil, nil
+	}
+	feeConfig, err := GetFeeConfigAt(chain, parent, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, baseFee, err := CalcBaseFee(config, feeConfig, parent, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockGasCost := calcBlockGasCost(
+		feeConfig.TargetBlockRate,
+		feeConfig.MinBlockGasCost,
+		feeConfig.MaxBlockGasCost,
+		feeConfig.BlockGasCostStep,
+		parent.BlockGasCost,
+		parent.Time, timestamp,
+	)
+	return baseFee, blockGasCost, nil
+}
+
 // MinRequiredTip is the estimated minimum tip a transaction would have
 // needed to pay to be included in a given block (assuming it paid a tip
 // proportional to its gas usage). In reality, there is no minimum tip that
@@ -9,8 +9,10 @@ import (
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
@@ -440,3 +442,65 @@ func TestCalcBlockGasCost(t *testing.T) {
 		})
 	}
 }
+
+// testFeeConfigReader serves a fixed fee config and pending fee config at every parent.
+type testFeeConfigReader struct {
+	consensus.ChainHeaderReader
+
+	feeConfig           commontype.FeeConfig
+	pendingFeeConfig    commontype.FeeConfig
+	activationTimestamp uint64
+}
+
+func (r *testFeeConfigReader) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
+	return r.feeConfig, common.Big0, nil
+}
+
+func (r *testFeeConfigReader) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	return r.pendingFeeConfig, r.activationTimestamp, nil
+}
+
+func TestGetFeeConfigAt(t *testing.T) {
+	pendingFeeConfig := params.DefaultFeeConfig
+	pendingFeeConfig.GasLimit = big.NewInt(10_000_000)
+	parent := &types.Header{Time: 10}
+
+	tests := map[string]struct {
+		activationTimestamp uint64
+		timestamp           uint64
+		expected            commontype.FeeConfig
+	}{
+		"no pending fee config": {
+			activationTimestamp: 0,
+			timestamp:           20,
+			expected:            params.DefaultFeeConfig,
+		},
+		"before activation": {
+			activationTimestamp: 20,
+			timestamp:           19,
+			expected:            params.DefaultFeeConfig,
+		},
+		"at activation": {
+			activationTimestamp: 20,
+			timestamp:           20,
+			expected:            pendingFeeConfig,
+		},
+		"after activation": {
+			activationTimestamp: 20,
+			timestamp:           21,
+			expected:            pendingFeeConfig,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain := &testFeeConfigReader{
+				feeConfig:           params.DefaultFeeConfig,
+				pendingFeeConfig:    pendingFeeConfig,
+				activationTimestamp: test.activationTimestamp,
+			}
+			feeConfig, err := GetFeeConfigAt(chain, parent, test.timestamp)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, feeConfig)
+		})
+	}
+}
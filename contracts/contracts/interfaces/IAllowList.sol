@@ -2,6 +2,12 @@
 pragma solidity ^0.8.0;
 
 interface IAllowList {
+  // Emitted when [sender] sets the role of [account] from [oldRole] to [role].
+  event RoleSet(uint256 indexed role, address indexed account, address indexed sender, uint256 oldRole);
+
+  // Emitted when [admin] approves [proposalID], bringing it to [approvals] approvals.
+  event ProposalApproved(bytes32 indexed proposalID, address indexed admin, uint256 approvals);
+
   // Set [addr] to have the admin role over the precompile contract.
   function setAdmin(address addr) external;
 
@@ -16,4 +22,31 @@ interface IAllowList {
 
   // Read the status of [addr].
   function readAllowList(address addr) external view returns (uint256 role);
+
+  // Set [addr] to be enabled on the precompile contract until the [expiry] timestamp.
+  function setEnabledWithExpiry(address addr, uint256 expiry) external;
+
+  // Set [addr] to have the manager role over the precompile contract until the [expiry] timestamp.
+  function setManagerWithExpiry(address addr, uint256 expiry) external;
+
+  // Read the timestamp at which the role of [addr] expires, or 0 if it does not expire.
+  function readAllowListExpiry(address addr) external view returns (uint256 expiry);
+
+  // Read the number of addresses holding a role.
+  function readAllowListCount() external view returns (uint256 count);
+
+  // Read the address holding a role at [index]. Removing a role moves the last address into its place.
+  function readAllowListMember(uint256 index) external view returns (address addr);
+
+  // Set the number of admin approvals admin actions require to [threshold]. Once the threshold is
+  // greater than one, calls to admin actions, whether made by admins, managers or enabled addresses,
+  // are only executed when enough of them made the same call. The threshold cannot exceed the number of admins, and
+  // admins cannot be removed once there are only as many admins as the threshold.
+  function setAdminThreshold(uint256 threshold) external;
+
+  // Read the number of admin approvals admin actions require.
+  function readAdminThreshold() external view returns (uint256 threshold);
+
+  // Read the number of approvals of [proposalID] by addresses that still hold the role they approved it with.
+  function readProposalApprovals(bytes32 proposalID) external view returns (uint256 approvals);
 }
@@ -0,0 +1,14 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+// Only the top-level call of a transaction is checked, so the calls an allowed function
+// makes to other contracts are not restricted. Only enabled senders may deploy contracts.
+interface IContractCallAllowList is IAllowList {
+  // setCallAllowed allows or disallows non-enabled senders to call selector of target.
+  // Only callable by admins and managers.
+  function setCallAllowed(address target, bytes4 selector, bool allowed) external;
+
+  // isCallAllowed returns true if non-enabled senders may call selector of target
+  function isCallAllowed(address target, bytes4 selector) external view returns (bool allowed);
+}
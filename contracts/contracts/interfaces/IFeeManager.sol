@@ -32,4 +32,37 @@ interface IFeeManager is IAllowList {
 
   // Get the last block number changed the fee config from the contract storage
   function getFeeConfigLastChangedAt() external view returns (uint256 blockNumber);
+
+  // Schedule fee config fields to take effect at the first block with a timestamp at or after activationTimestamp
+  // Replaces any previously scheduled fee config
+  function setPendingFeeConfig(
+    uint256 gasLimit,
+    uint256 targetBlockRate,
+    uint256 minBaseFee,
+    uint256 targetGas,
+    uint256 baseFeeChangeDenominator,
+    uint256 minBlockGasCost,
+    uint256 maxBlockGasCost,
+    uint256 blockGasCostStep,
+    uint256 activationTimestamp
+  ) external;
+
+  // Get the scheduled fee config from the contract storage, activationTimestamp is 0 if none is scheduled
+  function getPendingFeeConfig()
+    external
+    view
+    returns (
+      uint256 gasLimit,
+      uint256 targetBlockRate,
+      uint256 minBaseFee,
+      uint256 targetGas,
+      uint256 baseFeeChangeDenominator,
+      uint256 minBlockGasCost,
+      uint256 maxBlockGasCost,
+      uint256 blockGasCostStep,
+      uint256 activationTimestamp
+    );
+
+  // Cancel the scheduled fee config
+  function cancelPendingFeeConfig() external;
 }
@@ -0,0 +1,14 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface IFreezeList is IAllowList {
+  // freezeAccount prevents addr from sending value or originating transactions
+  function freezeAccount(address addr) external;
+
+  // unfreezeAccount lifts a previous freeze of addr
+  function unfreezeAccount(address addr) external;
+
+  // isFrozen returns true if addr is frozen
+  function isFrozen(address addr) external view returns (bool frozen);
+}
@@ -0,0 +1,25 @@
+//SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+import "./IAllowList.sol";
+
+interface IGasSponsor is IAllowList {
+  // setSponsorshipPolicy sets the fees the calling sponsor pays at most on behalf of each user per day
+  function setSponsorshipPolicy(uint256 dailyBudget) external;
+
+  // setSponsoredTarget starts or stops paying the fees of transactions sent to target,
+  // unless target is sponsored by another sponsor
+  function setSponsoredTarget(address target, bool sponsored) external;
+
+  // setTargetSponsor sets the sponsor paying the fees of transactions sent to target, overriding
+  // any sponsor that claimed it. Setting the zero address stops sponsoring target. Only callable by admins.
+  function setTargetSponsor(address target, address sponsor) external;
+
+  // getSponsorshipPolicy returns the daily budget per user of sponsor
+  function getSponsorshipPolicy(address sponsor) external view returns (uint256 dailyBudget);
+
+  // getTargetSponsor returns the sponsor paying the fees of transactions sent to target
+  function getTargetSponsor(address target) external view returns (address sponsor);
+
+  // getSponsoredSpend returns the fees sponsor paid on behalf of user today
+  function getSponsoredSpend(address sponsor, address user) external view returns (uint256 spent);
+}
@@ -5,4 +5,24 @@ import "./IAllowList.sol";
 interface INativeMinter is IAllowList {
   // Mint [amount] number of native coins and send to [addr]
   function mintNativeCoin(address addr, uint256 amount) external;
+
+  // Burn [amount] number of native coins from the caller's balance
+  function burnNativeCoin(uint256 amount) external;
+
+  // Read the total amounts of native coins minted and burned through this precompile
+  function readSupplyCounters() external view returns (uint256 totalMinted, uint256 totalBurned);
+
+  // Set the mint window to [window] seconds and the amount all minters combined can mint in any [window] seconds to [globalCap]
+  // A [globalCap] of 0 leaves the combined amount uncapped. A [window] of 0 disables all mint caps and requires a [globalCap] of 0
+  function setMintLimits(uint256 window, uint256 globalCap) external;
+
+  // Set the amount [addr] can mint in any window to [cap]. A [cap] of 0 leaves [addr] uncapped
+  // A non-zero [cap] requires a non-zero mint window
+  function setMinterCap(address addr, uint256 cap) external;
+
+  // Read the mint window, the global cap and the amount minted by all minters in the last window
+  function readMintLimits() external view returns (uint256 window, uint256 globalCap, uint256 globalMinted);
+
+  // Read the cap of [addr] and the amount it minted in the last window
+  function readMinterCap(address addr) external view returns (uint256 cap, uint256 minted);
 }
@@ -17,4 +17,13 @@ interface IRewardManager is IAllowList {
 
   // areFeeRecipientsAllowed returns true if fee recipients are allowed
   function areFeeRecipientsAllowed() external view returns (bool isAllowed);
+
+  // setFeeSplit splits block fees across the given recipients by their shares in basis points,
+  // the remaining share is paid to the block producer. Only callable by admins.
+  // Shares are paid in the order of the recipients and capped at the remaining balance of the
+  // block producer, which may have spent some of the fees within the block.
+  function setFeeSplit(address[] calldata recipients, uint16[] calldata basisPoints) external;
+
+  // currentFeeSplit returns the recipients of the fee split and their shares in basis points
+  function currentFeeSplit() external view returns (address[] memory recipients, uint16[] memory basisPoints);
 }
@@ -0,0 +1,13 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// IWarpMessageReceiver is called by the transactions of the built-in warp relayer.
+// The relayed message is the predicate at [index] of the transaction and can be read with
+// IWarpMessenger.getVerifiedWarpMessage(index).
+interface IWarpMessageReceiver {
+  function receiveWarpMessage(uint32 index) external;
+}
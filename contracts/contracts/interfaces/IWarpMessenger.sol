@@ -16,6 +16,14 @@ struct WarpBlockHash {
   bytes32 blockHash;
 }
 
+struct WarpStorageValue {
+  bytes32 sourceChainID;
+  bytes32 blockHash;
+  address account;
+  bytes32 slot;
+  bytes32 value;
+}
+
 interface IWarpMessenger {
   event SendWarpMessage(address indexed sender, bytes32 indexed messageID, bytes message);
 
@@ -35,6 +43,22 @@ interface IWarpMessenger {
   // Otherwise, returns false and the empty value for the message.
   function getVerifiedWarpMessage(uint32 index) external view returns (WarpMessage calldata message, bool valid);
 
+  // consumeVerifiedWarpMessage returns the pre-verified warp message in the
+  // predicate storage slots like getVerifiedWarpMessage and marks it as consumed
+  // by the caller. A message that was already consumed by the caller is returned
+  // as invalid, so contracts receiving messages through this function are
+  // protected against replays. Reverts if the payload is a ContractCall typed
+  // payload addressed to another contract.
+  function consumeVerifiedWarpMessage(uint32 index) external returns (WarpMessage calldata message, bool valid);
+
+  // isMessageConsumed returns true if the message with [messageID] sent from
+  // [sourceChainID] was consumed by [consumer] through consumeVerifiedWarpMessage.
+  function isMessageConsumed(
+    bytes32 sourceChainID,
+    bytes32 messageID,
+    address consumer
+  ) external view returns (bool consumed);
+
   // getVerifiedWarpBlockHash parses the pre-verified WarpBlockHash message in the
   // predicate storage slots as a WarpBlockHash message and returns it to the caller.
   // If the message exists and passes verification, returns the verified message
@@ -44,6 +68,22 @@ interface IWarpMessenger {
     uint32 index
   ) external view returns (WarpBlockHash calldata warpBlockHash, bool valid);
 
+  // getVerifiedWarpStorageValue proves the value of storage [slot] of [account] on the source chain
+  // of the pre-verified WarpBlockHash message in the predicate storage slots, at that block.
+  // [blockHeader] is the RLP encoded header of the block, and [accountProof] and [storageProof]
+  // are the Merkle proofs returned by eth_getProof on the source chain for that block.
+  // If the message exists and passes verification, returns the proven value and true.
+  // Otherwise, returns false and the empty value.
+  // Reverts if the header does not match the verified block hash or if the proofs are invalid.
+  function getVerifiedWarpStorageValue(
+    uint32 index,
+    bytes calldata blockHeader,
+    address account,
+    bytes32 slot,
+    bytes[] calldata accountProof,
+    bytes[] calldata storageProof
+  ) external view returns (WarpStorageValue calldata storageValue, bool valid);
+
   // getBlockchainID returns the snow.Context BlockchainID of this chain.
   // This blockchainID is the hash of the transaction that created this blockchain on the P-Chain
   // and is not related to the Ethereum ChainID.
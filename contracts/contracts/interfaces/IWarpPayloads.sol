@@ -0,0 +1,95 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// Standard formats for the payload of a warp message sent with IWarpMessenger.sendWarpMessage.
+// A typed payload is abi.encode(uint8 payloadType, bytes body), where body is the ABI encoding
+// of the fields of the payload struct in order. This matches the pack/unpack helpers in x/warp.
+enum WarpPayloadType {
+  Unknown,
+  NativeTokenTransfer,
+  ERC20Transfer,
+  ContractCall
+}
+
+struct NativeTokenTransfer {
+  bytes32 destinationChainID;
+  address recipient;
+  uint256 amount;
+}
+
+struct ERC20Transfer {
+  bytes32 destinationChainID;
+  address token;
+  address recipient;
+  uint256 amount;
+}
+
+struct ContractCall {
+  bytes32 destinationChainID;
+  address destinationAddress;
+  uint256 gasLimit;
+  bytes data;
+}
+
+library WarpPayloads {
+  // payloadType returns the type of the typed payload [payload] and its encoded body.
+  function payloadType(bytes memory payload) internal pure returns (WarpPayloadType, bytes memory) {
+    (uint8 payloadType_, bytes memory body) = abi.decode(payload, (uint8, bytes));
+    return (WarpPayloadType(payloadType_), body);
+  }
+
+  function encodeNativeTokenTransfer(NativeTokenTransfer memory transfer) internal pure returns (bytes memory) {
+    return
+      abi.encode(
+        uint8(WarpPayloadType.NativeTokenTransfer),
+        abi.encode(transfer.destinationChainID, transfer.recipient, transfer.amount)
+      );
+  }
+
+  function decodeNativeTokenTransfer(bytes memory payload) internal pure returns (NativeTokenTransfer memory transfer) {
+    bytes memory body = decodeBody(payload, WarpPayloadType.NativeTokenTransfer);
+    (transfer.destinationChainID, transfer.recipient, transfer.amount) = abi.decode(body, (bytes32, address, uint256));
+  }
+
+  function encodeERC20Transfer(ERC20Transfer memory transfer) internal pure returns (bytes memory) {
+    return
+      abi.encode(
+        uint8(WarpPayloadType.ERC20Transfer),
+        abi.encode(transfer.destinationChainID, transfer.token, transfer.recipient, transfer.amount)
+      );
+  }
+
+  function decodeERC20Transfer(bytes memory payload) internal pure returns (ERC20Transfer memory transfer) {
+    bytes memory body = decodeBody(payload, WarpPayloadType.ERC20Transfer);
+    (transfer.destinationChainID, transfer.token, transfer.recipient, transfer.amount) = abi.decode(
+      body,
+      (bytes32, address, address, uint256)
+    );
+  }
+
+  function encodeContractCall(ContractCall memory call) internal pure returns (bytes memory) {
+    return
+      abi.encode(
+        uint8(WarpPayloadType.ContractCall),
+        abi.encode(call.destinationChainID, call.destinationAddress, call.gasLimit, call.data)
+      );
+  }
+
+  function decodeContractCall(bytes memory payload) internal pure returns (ContractCall memory call) {
+    bytes memory body = decodeBody(payload, WarpPayloadType.ContractCall);
+    (call.destinationChainID, call.destinationAddress, call.gasLimit, call.data) = abi.decode(
+      body,
+      (bytes32, address, uint256, bytes)
+    );
+  }
+
+  function decodeBody(bytes memory payload, WarpPayloadType expectedType) private pure returns (bytes memory) {
+    (uint8 payloadType_, bytes memory body) = abi.decode(payload, (uint8, bytes));
+    require(payloadType_ == uint8(expectedType), "unexpected warp payload type");
+    return body;
+  }
+}
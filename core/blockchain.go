@@ -146,18 +146,22 @@ const (
 	trieCleanCacheStatsNamespace = "trie/memcache/clean/fastcache"
 )
 
-// cacheableFeeConfig encapsulates fee configuration itself and the block number that it has changed at,
-// in order to cache them together.
+// cacheableFeeConfig encapsulates fee configuration itself, the block number that it has changed at
+// and the scheduled fee configuration with its activation timestamp, in order to cache them together.
 type cacheableFeeConfig struct {
 	feeConfig     commontype.FeeConfig
 	lastChangedAt *big.Int
+
+	pendingFeeConfig    commontype.FeeConfig
+	pendingActivationAt uint64
 }
 
-// cacheableCoinbaseConfig encapsulates coinbase address itself and allowFeeRecipient flag,
-// in order to cache them together.
+// cacheableCoinbaseConfig encapsulates coinbase address itself, allowFeeRecipient flag and
+// the fee split, in order to cache them together.
 type cacheableCoinbaseConfig struct {
 	coinbaseAddress    common.Address
 	allowFeeRecipients bool
+	feeSplit           []commontype.FeeSplitRecipient
 }
 
 // CacheConfig contains the configuration values for the trie database
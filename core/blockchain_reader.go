@@ -354,14 +354,39 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 		return config.FeeConfig, common.Big0, nil
 	}
 
+	cached, err := bc.getStoredFeeConfigs(parent)
+	if err != nil {
+		return commontype.EmptyFeeConfig, nil, err
+	}
+	return cached.feeConfig, cached.lastChangedAt, nil
+}
+
+// GetPendingFeeConfigAt returns the fee configuration scheduled through the FeeManager at [parent]
+// and the timestamp it takes effect at. The returned timestamp is 0 if FeeManager is not activated
+// at [parent] or no fee config change is scheduled.
+func (bc *BlockChain) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	if !bc.Config().IsPrecompileEnabled(feemanager.ContractAddress, parent.Time) {
+		return commontype.EmptyFeeConfig, 0, nil
+	}
+
+	cached, err := bc.getStoredFeeConfigs(parent)
+	if err != nil {
+		return commontype.EmptyFeeConfig, 0, err
+	}
+	return cached.pendingFeeConfig, cached.pendingActivationAt, nil
+}
+
+// getStoredFeeConfigs returns the fee config and the pending fee config stored in the
+// FeeManager precompile contract state at [parent].
+func (bc *BlockChain) getStoredFeeConfigs(parent *types.Header) (*cacheableFeeConfig, error) {
 	// try to return it from the cache
 	if cached, hit := bc.feeConfigCache.Get(parent.Root); hit {
-		return cached.feeConfig, cached.lastChangedAt, nil
+		return cached, nil
 	}
 
 	stateDB, err := bc.StateAt(parent.Root)
 	if err != nil {
-		return commontype.EmptyFeeConfig, nil, err
+		return nil, err
 	}
 
 	storedFeeConfig := feemanager.GetStoredFeeConfig(stateDB)
@@ -370,13 +395,25 @@ func (bc *BlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	// However an external stateDB call can modify the contract state.
 	// This check is added to add a defense in-depth.
 	if err := storedFeeConfig.Verify(); err != nil {
-		return commontype.EmptyFeeConfig, nil, err
+		return nil, err
 	}
 	lastChangedAt := feemanager.GetFeeConfigLastChangedAt(stateDB)
-	cacheable := &cacheableFeeConfig{feeConfig: storedFeeConfig, lastChangedAt: lastChangedAt}
+	pendingFeeConfig, pendingActivationAt := feemanager.GetPendingFeeConfig(stateDB)
+	if pendingActivationAt != 0 {
+		// Same defense in-depth as above for the scheduled fee config.
+		if err := pendingFeeConfig.Verify(); err != nil {
+			return nil, err
+		}
+	}
+	cacheable := &cacheableFeeConfig{
+		feeConfig:           storedFeeConfig,
+		lastChangedAt:       lastChangedAt,
+		pendingFeeConfig:    pendingFeeConfig,
+		pendingActivationAt: pendingActivationAt,
+	}
 	// add it to the cache
 	bc.feeConfigCache.Add(parent.Root, cacheable)
-	return storedFeeConfig, lastChangedAt, nil
+	return cacheable, nil
 }
 
 // GetCoinbaseAt returns the configured coinbase address at [parent].
@@ -396,20 +433,46 @@ func (bc *BlockChain) GetCoinbaseAt(parent *types.Header) (common.Address, bool,
 		}
 	}
 
+	coinbaseConfig, err := bc.getStoredCoinbaseConfig(parent)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	return coinbaseConfig.coinbaseAddress, coinbaseConfig.allowFeeRecipients, nil
+}
+
+// GetFeeSplitAt returns the fee split configured through the RewardManager at [parent].
+// Returns nil if RewardManager is not activated at [parent] or fees are not split.
+func (bc *BlockChain) GetFeeSplitAt(parent *types.Header) ([]commontype.FeeSplitRecipient, error) {
+	if !bc.Config().IsPrecompileEnabled(rewardmanager.ContractAddress, parent.Time) {
+		return nil, nil
+	}
+	coinbaseConfig, err := bc.getStoredCoinbaseConfig(parent)
+	if err != nil {
+		return nil, err
+	}
+	return coinbaseConfig.feeSplit, nil
+}
+
+// getStoredCoinbaseConfig returns the reward manager config stored in the precompile contract state at [parent].
+func (bc *BlockChain) getStoredCoinbaseConfig(parent *types.Header) (*cacheableCoinbaseConfig, error) {
 	// try to return it from the cache
 	if cached, hit := bc.coinbaseConfigCache.Get(parent.Root); hit {
-		return cached.coinbaseAddress, cached.allowFeeRecipients, nil
+		return cached, nil
 	}
 
 	stateDB, err := bc.StateAt(parent.Root)
 	if err != nil {
-		return common.Address{}, false, err
+		return nil, err
 	}
 	rewardAddress, feeRecipients := rewardmanager.GetStoredRewardAddress(stateDB)
 
-	cacheable := &cacheableCoinbaseConfig{coinbaseAddress: rewardAddress, allowFeeRecipients: feeRecipients}
+	cacheable := &cacheableCoinbaseConfig{
+		coinbaseAddress:    rewardAddress,
+		allowFeeRecipients: feeRecipients,
+		feeSplit:           rewardmanager.GetStoredFeeSplit(stateDB),
+	}
 	bc.coinbaseConfigCache.Add(parent.Root, cacheable)
-	return rewardAddress, feeRecipients, nil
+	return cacheable, nil
 }
 
 // GetLogs fetches all logs from a given block.
@@ -337,7 +337,7 @@ func makeHeader(chain consensus.ChainReader, config *params.ChainConfig, parent
 	}
 
 	if chain.Config().IsSubnetEVM(time) {
-		feeConfig, _, err := chain.GetFeeConfigAt(parent.Header())
+		feeConfig, err := dummy.GetFeeConfigAt(chain, parent.Header(), time)
 		if err != nil {
 			panic(err)
 		}
@@ -371,6 +371,14 @@ func (cr *fakeChainReader) GetFeeConfigAt(parent *types.Header) (commontype.FeeC
 	return cr.config.FeeConfig, nil, nil
 }
 
+func (cr *fakeChainReader) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	return commontype.EmptyFeeConfig, 0, nil
+}
+
 func (cr *fakeChainReader) GetCoinbaseAt(parent *types.Header) (common.Address, bool, error) {
 	return constants.BlackholeAddr, cr.config.AllowFeeRecipients, nil
 }
+
+func (cr *fakeChainReader) GetFeeSplitAt(parent *types.Header) ([]commontype.FeeSplitRecipient, error) {
+	return nil, nil
+}
@@ -86,6 +86,44 @@ type Genesis struct {
 // GenesisAlloc specifies the initial state that is part of the genesis block.
 type GenesisAlloc map[common.Address]GenesisAccount
 
+// TotalBalance returns the sum of the balances allocated to all accounts in [ga].
+func (ga GenesisAlloc) TotalBalance() *big.Int {
+	total := new(big.Int)
+	for _, account := range ga {
+		if account.Balance != nil {
+			total.Add(total, account.Balance)
+		}
+	}
+	return total
+}
+
+// TotalBalance returns the sum of the balances allocated in the genesis, including the airdrop.
+// Returns an error if the airdrop data is not available.
+func (g *Genesis) TotalBalance() (*big.Int, error) {
+	total := g.Alloc.TotalBalance()
+	if g.AirdropHash == (common.Hash{}) {
+		return total, nil
+	}
+	if h := common.BytesToHash(crypto.Keccak256(g.AirdropData)); g.AirdropHash != h {
+		return nil, fmt.Errorf("expected standard allocation %s but got %s", g.AirdropHash, h)
+	}
+	airdrop := []*Airdrop{}
+	if err := json.Unmarshal(g.AirdropData, &airdrop); err != nil {
+		return nil, err
+	}
+	// The airdrop sets the balance of each address, and is overridden by the allocation
+	airdropped := make(map[common.Address]struct{}, len(airdrop))
+	for _, alloc := range airdrop {
+		if _, ok := g.Alloc[alloc.Address]; !ok {
+			airdropped[alloc.Address] = struct{}{}
+		}
+	}
+	if g.AirdropAmount != nil {
+		total.Add(total, new(big.Int).Mul(g.AirdropAmount, big.NewInt(int64(len(airdropped)))))
+	}
+	return total, nil
+}
+
 func (ga *GenesisAlloc) UnmarshalJSON(data []byte) error {
 	m := make(map[common.UnprefixedAddress]GenesisAccount)
 	if err := json.Unmarshal(data, &m); err != nil {
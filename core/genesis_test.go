@@ -45,6 +45,7 @@ import (
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -197,7 +198,7 @@ func TestStatefulPrecompilesConfigure(t *testing.T) {
 				return &config
 			},
 			assertState: func(t *testing.T, sdb *state.StateDB) {
-				assert.Equal(t, allowlist.AdminRole, deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr), "unexpected allow list status for modified address")
+				assert.Equal(t, allowlist.AdminRole, deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr, 0), "unexpected allow list status for modified address")
 				assert.Equal(t, uint64(1), sdb.GetNonce(deployerallowlist.ContractAddress))
 			},
 		},
@@ -328,3 +329,31 @@ func TestGenesisWriteUpgradesRegression(t *testing.T) {
 	_, _, err = SetupGenesisBlock(db, trieDB, genesis, lastAcceptedBlock.Hash(), false)
 	require.NoError(err)
 }
+
+func TestGenesisTotalBalance(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr1       = common.HexToAddress("0x01")
+		addr2       = common.HexToAddress("0x02")
+		airdropData = []byte(`[{"address":"0x0000000000000000000000000000000000000001"},{"address":"0x0000000000000000000000000000000000000003"}]`)
+	)
+	genesis := &Genesis{
+		Alloc: GenesisAlloc{
+			addr1: {Balance: big.NewInt(10)},
+			addr2: {Balance: big.NewInt(20)},
+		},
+		AirdropHash:   common.BytesToHash(crypto.Keccak256(airdropData)),
+		AirdropAmount: big.NewInt(5),
+		AirdropData:   airdropData,
+	}
+	// The airdrop to [addr1] is overridden by its allocation
+	total, err := genesis.TotalBalance()
+	require.NoError(err)
+	require.Equal(big.NewInt(35), total)
+
+	// Freed airdrop data
+	genesis.AirdropData = nil
+	_, err = genesis.TotalBalance()
+	require.Error(err)
+}
@@ -35,9 +35,12 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/stateupgrade"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -233,9 +236,43 @@ func applyStateUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockCon
 	return nil
 }
 
+// seedAllowListMembers lists the members configured by the allow lists of the enabled precompiles
+// in their member tables when the block transition from [parentTimestamp] to the timestamp set in
+// [blockContext] activates the DUpgrade, which starts tracking allow list members.
+func seedAllowListMembers(c *params.ChainConfig, parentTimestamp *uint64, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) {
+	blockTimestamp := blockContext.Timestamp()
+	if !utils.IsForkTransition(c.DUpgradeTimestamp, parentTimestamp, blockTimestamp) {
+		return
+	}
+	for _, module := range modules.RegisteredModules() {
+		if !c.IsPrecompileEnabled(module.Address, blockTimestamp) {
+			continue
+		}
+		// Addresses of configs preceding a disable had their roles wiped and are skipped.
+		for _, config := range c.GetActivatingPrecompileConfigs(module.Address, nil, blockTimestamp, c.PrecompileUpgrades) {
+			if seeder, ok := config.(allowlist.MemberSeeder); ok {
+				seeder.SeedAllowListMembers(statedb, module.Address, blockTimestamp)
+			}
+		}
+	}
+}
+
+// applyPendingFeeConfig stores the fee config scheduled through the FeeManager precompile
+// once the timestamp set in [blockContext] reaches its activation timestamp.
+func applyPendingFeeConfig(c *params.ChainConfig, blockContext contract.ConfigurationBlockContext, statedb *state.StateDB) error {
+	if !c.IsPrecompileEnabled(feemanager.ContractAddress, blockContext.Timestamp()) {
+		return nil
+	}
+	if err := feemanager.ApplyPendingFeeConfig(statedb, blockContext); err != nil {
+		return fmt.Errorf("could not apply pending fee config: %w", err)
+	}
+	return nil
+}
+
 // ApplyUpgrades checks if any of the precompile or state upgrades specified by the chain config are activated by the block
 // transition from [parentTimestamp] to the timestamp set in [header]. If this is the case, it calls [Configure]
-// to apply the necessary state transitions for the upgrade.
+// to apply the necessary state transitions for the upgrade. It also lists the configured allow list members
+// once the DUpgrade activates and applies a scheduled fee config change once its activation timestamp is reached.
 // This function is called:
 // - in block processing to update the state when processing a block.
 // - in the miner to apply the state upgrades when producing a block.
@@ -243,5 +280,9 @@ func ApplyUpgrades(c *params.ChainConfig, parentTimestamp *uint64, blockContext
 	if err := ApplyPrecompileActivations(c, parentTimestamp, blockContext, statedb); err != nil {
 		return err
 	}
-	return applyStateUpgrades(c, parentTimestamp, blockContext, statedb)
+	seedAllowListMembers(c, parentTimestamp, blockContext, statedb)
+	if err := applyStateUpgrades(c, parentTimestamp, blockContext, statedb); err != nil {
+		return err
+	}
+	return applyPendingFeeConfig(c, blockContext, statedb)
 }
@@ -27,20 +27,28 @@
 package core
 
 import (
+	"crypto/ecdsa"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/gassponsor"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -445,6 +453,329 @@ func TestBadTxAllowListBlock(t *testing.T) {
 	}
 }
 
+// TestBadTxFrozenBlock tests the output generated when the
+// blockchain imports a bad block with a transaction from an
+// address frozen by the freeze list.
+func TestBadTxFrozenBlock(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		testAddr = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+
+		config = &params.ChainConfig{
+			ChainID:             big.NewInt(1),
+			FeeConfig:           params.DefaultFeeConfig,
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			MuirGlacierBlock:    big.NewInt(0),
+			MandatoryNetworkUpgrades: params.MandatoryNetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+			},
+			GenesisPrecompiles: params.Precompiles{
+				freezelist.ConfigKey: freezelist.NewConfig(utils.NewUint64(0), nil, nil, nil, []common.Address{testAddr}),
+			},
+		}
+		signer     = types.LatestSigner(config)
+		testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+		gspec = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				testAddr: GenesisAccount{
+					Balance: big.NewInt(1000000000000000000), // 1 ether
+					Nonce:   0,
+				},
+			},
+			GasLimit: config.FeeConfig.GasLimit.Uint64(),
+		}
+		blockchain, _ = NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	)
+	defer blockchain.Stop()
+
+	mkDynamicTx := func(nonce uint64, to common.Address, gasLimit uint64, gasTipCap, gasFeeCap *big.Int) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+		}), signer, testKey)
+		return tx
+	}
+
+	for i, tt := range []struct {
+		txs  []*types.Transaction
+		want string
+	}{
+		{ // Frozen address
+			txs: []*types.Transaction{
+				mkDynamicTx(0, common.Address{}, params.TxGas, big.NewInt(0), big.NewInt(225000000000)),
+			},
+			want: "could not apply tx 0 [0xc5725e8baac950b2925dd4fea446ccddead1cc0affdae18b31a7d910629d9225]: cannot issue transaction from frozen address: 0x71562b71999873DB5b286dF957af199Ec94617F7",
+		},
+	} {
+		block := GenerateBadBlock(gspec.ToBlock(), dummy.NewCoinbaseFaker(), tt.txs, gspec.Config)
+		_, err := blockchain.InsertChain(types.Blocks{block})
+		if err == nil {
+			t.Fatal("block imported without errors")
+		}
+		if have, want := err.Error(), tt.want; have != want {
+			t.Errorf("test %d:\nhave \"%v\"\nwant \"%v\"\n", i, have, want)
+		}
+	}
+}
+
+// TestBadTxNotAllowedCallBlock tests the output generated when the
+// blockchain imports a bad block with a transaction calling a contract
+// function not allowed by the contract call allow list.
+func TestBadTxNotAllowedCallBlock(t *testing.T) {
+	var (
+		db         = rawdb.NewMemoryDatabase()
+		testAddr   = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+		target     = common.HexToAddress("0x0123")
+		selector   = [callallowlist.SelectorLen]byte{0xa9, 0x05, 0x9c, 0xbb}
+		otherInput = []byte{0x01, 0x02, 0x03, 0x04}
+
+		config = &params.ChainConfig{
+			ChainID:             big.NewInt(1),
+			FeeConfig:           params.DefaultFeeConfig,
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			MuirGlacierBlock:    big.NewInt(0),
+			MandatoryNetworkUpgrades: params.MandatoryNetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+			},
+			GenesisPrecompiles: params.Precompiles{
+				callallowlist.ConfigKey: callallowlist.NewConfig(utils.NewUint64(0), nil, nil, nil, []callallowlist.AllowedCall{
+					{Target: target, Selector: selector[:]},
+				}),
+			},
+		}
+		signer     = types.LatestSigner(config)
+		testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+		gspec = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				testAddr: GenesisAccount{
+					Balance: big.NewInt(1000000000000000000), // 1 ether
+					Nonce:   0,
+				},
+				target: GenesisAccount{
+					Balance: big.NewInt(0),
+					Code:    []byte{0x00}, // STOP
+				},
+			},
+			GasLimit: config.FeeConfig.GasLimit.Uint64(),
+		}
+		blockchain, _ = NewBlockChain(db, DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	)
+	defer blockchain.Stop()
+
+	mkDynamicTx := func(nonce uint64, to common.Address, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+			Data:      data,
+		}), signer, testKey)
+		return tx
+	}
+	mkCreateTx := func(nonce uint64, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, code []byte) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			Value:     big.NewInt(0),
+			Data:      code,
+		}), signer, testKey)
+		return tx
+	}
+
+	for i, tt := range []struct {
+		txs  []*types.Transaction
+		want string
+	}{
+		{ // Call to a function that is not allowed
+			txs: []*types.Transaction{
+				mkDynamicTx(0, target, 100_000, big.NewInt(0), big.NewInt(225000000000), otherInput),
+			},
+			want: "cannot call contract function not allowed by the contract call allow list: 0x0000000000000000000000000000000000000123 0x01020304",
+		},
+		{ // Contract creation from a sender not enabled on the allow list
+			txs: []*types.Transaction{
+				mkCreateTx(0, 100_000, big.NewInt(0), big.NewInt(225000000000), []byte{0x00}),
+			},
+			want: "cannot deploy contract from address not enabled on the contract call allow list: 0x71562b71999873DB5b286dF957af199Ec94617F7",
+		},
+	} {
+		block := GenerateBadBlock(gspec.ToBlock(), dummy.NewCoinbaseFaker(), tt.txs, gspec.Config)
+		_, err := blockchain.InsertChain(types.Blocks{block})
+		if err == nil {
+			t.Fatal("block imported without errors")
+		}
+		if have, want := err.Error(), tt.want; !strings.Contains(have, want) {
+			t.Errorf("test %d:\nhave \"%v\"\nwant \"%v\"\n", i, have, want)
+		}
+	}
+
+	// A call to the allowed function is imported.
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(i int, b *BlockGen) {
+		b.AddTx(mkDynamicTx(0, target, 100_000, big.NewInt(0), big.NewInt(225000000000), selector[:]))
+	})
+	require.NoError(t, err)
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+}
+
+// TestSeedAllowListMembersAtDUpgrade tests that the members configured by an allow list
+// activated before the DUpgrade are listed, and its admins counted, when the DUpgrade activates.
+func TestSeedAllowListMembersAtDUpgrade(t *testing.T) {
+	var (
+		admin   = common.HexToAddress("0x0100")
+		enabled = common.HexToAddress("0x0200")
+		removed = common.HexToAddress("0x0300")
+
+		config = &params.ChainConfig{
+			ChainID: big.NewInt(1),
+			MandatoryNetworkUpgrades: params.MandatoryNetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+				DUpgradeTimestamp:  utils.NewUint64(10),
+			},
+			GenesisPrecompiles: params.Precompiles{
+				txallowlist.ConfigKey: txallowlist.NewConfig(utils.NewUint64(0), []common.Address{admin}, []common.Address{enabled, removed}, nil),
+			},
+		}
+		mkBlock = func(number int64, time uint64) *types.Block {
+			return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number), Time: time})
+		}
+	)
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	require.NoError(t, ApplyPrecompileActivations(config, nil, mkBlock(0, 0), statedb))
+	require.Empty(t, allowlist.GetAllowListMembers(statedb, txallowlist.ContractAddress))
+	allowlist.SetAllowListRole(statedb, txallowlist.ContractAddress, removed, allowlist.NoRole)
+
+	// Blocks before the DUpgrade do not list the configured members.
+	require.NoError(t, ApplyUpgrades(config, utils.NewUint64(0), mkBlock(1, 5), statedb))
+	require.Empty(t, allowlist.GetAllowListMembers(statedb, txallowlist.ContractAddress))
+
+	require.NoError(t, ApplyUpgrades(config, utils.NewUint64(5), mkBlock(2, 10), statedb))
+	require.Equal(t, []common.Address{enabled, admin}, allowlist.GetAllowListMembers(statedb, txallowlist.ContractAddress))
+	require.Equal(t, uint64(1), allowlist.GetAdminCount(statedb, txallowlist.ContractAddress))
+
+	// Later blocks leave the member table as is.
+	require.NoError(t, ApplyUpgrades(config, utils.NewUint64(10), mkBlock(3, 15), statedb))
+	require.Equal(t, []common.Address{enabled, admin}, allowlist.GetAllowListMembers(statedb, txallowlist.ContractAddress))
+	require.Equal(t, uint64(1), allowlist.GetAdminCount(statedb, txallowlist.ContractAddress))
+}
+
+// TestGasSponsoredBlocks tests that the gas of a transaction sent to a target
+// sponsored through the gas sponsor is paid by its sponsor within its daily budget.
+func TestGasSponsoredBlocks(t *testing.T) {
+	var (
+		sponsorKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sponsorAddr   = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		userKey, _    = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		userAddr      = crypto.PubkeyToAddress(userKey.PublicKey)
+		target        = common.HexToAddress("0x0123")
+		dailyBudget   = big.NewInt(params.Ether)
+
+		config = &params.ChainConfig{
+			ChainID:             big.NewInt(1),
+			FeeConfig:           params.DefaultFeeConfig,
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			MuirGlacierBlock:    big.NewInt(0),
+			MandatoryNetworkUpgrades: params.MandatoryNetworkUpgrades{
+				SubnetEVMTimestamp: utils.NewUint64(0),
+			},
+			GenesisPrecompiles: params.Precompiles{
+				gassponsor.ConfigKey: gassponsor.NewConfig(utils.NewUint64(0), nil, []common.Address{sponsorAddr}, nil),
+			},
+		}
+		signer = types.LatestSigner(config)
+
+		gspec = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				sponsorAddr: GenesisAccount{
+					Balance: big.NewInt(params.Ether),
+					Nonce:   0,
+				},
+			},
+			GasLimit: config.FeeConfig.GasLimit.Uint64(),
+		}
+	)
+
+	mkDynamicTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, gasLimit uint64, data []byte) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: big.NewInt(225000000000),
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+			Data:      data,
+		}), signer, key)
+		return tx
+	}
+
+	setPolicyInput, err := gassponsor.PackSetSponsorshipPolicy(dailyBudget)
+	require.NoError(t, err)
+	setTargetInput, err := gassponsor.PackSetSponsoredTarget(target, true)
+	require.NoError(t, err)
+
+	_, blocks, receipts, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			b.AddTx(mkDynamicTx(sponsorKey, 0, gassponsor.ContractAddress, 100_000, setPolicyInput))
+			b.AddTx(mkDynamicTx(sponsorKey, 1, gassponsor.ContractAddress, 100_000, setTargetInput))
+		case 1:
+			// The user has no balance, so the sponsor of [target] has to pay for the transaction.
+			b.AddTx(mkDynamicTx(userKey, 0, target, params.TxGas, nil))
+		}
+	})
+	require.NoError(t, err)
+
+	blockchain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfig, gspec, dummy.NewCoinbaseFaker(), vm.Config{}, common.Hash{}, false)
+	require.NoError(t, err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	statedb, err := blockchain.StateAt(blocks[1].Root())
+	require.NoError(t, err)
+	require.Zero(t, statedb.GetBalance(userAddr).Sign())
+	require.Equal(t, uint64(1), statedb.GetNonce(userAddr))
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipts[1][0].GasUsed), blocks[1].BaseFee())
+	require.Zero(t, fee.Cmp(gassponsor.GetSponsoredSpend(statedb, sponsorAddr, userAddr, blocks[1].Time())))
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
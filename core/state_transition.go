@@ -34,6 +34,9 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/gassponsor"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
@@ -264,6 +267,7 @@ type StateTransition struct {
 	initialGas   uint64
 	state        vm.StateDB
 	evm          *vm.EVM
+	feePayer     common.Address // account paying for gas, the sender unless the transaction is sponsored
 }
 
 // NewStateTransition initialises and returns a new state transition object.
@@ -293,6 +297,19 @@ func (st *StateTransition) buyGas() error {
 		balanceCheck.Mul(balanceCheck, st.msg.GasFeeCap)
 		balanceCheck.Add(balanceCheck, st.msg.Value)
 	}
+	st.feePayer = st.msg.From
+	// If the gas sponsor is enabled, a sponsor of the recipient pays for gas on behalf of the sender
+	// within its daily budget, so the sender only has to cover the value of the transaction.
+	if st.evm.ChainConfig().IsPrecompileEnabled(gassponsor.ContractAddress, st.evm.Context.Time) {
+		gasCost := new(big.Int).Sub(balanceCheck, st.msg.Value)
+		if st.msg.GasFeeCap == nil {
+			gasCost = mgval
+		}
+		if sponsor, ok := gassponsor.SponsorOf(st.state, st.msg.To, st.msg.From, gasCost, st.evm.Context.Time); ok {
+			st.feePayer = sponsor
+			balanceCheck = st.msg.Value
+		}
+	}
 	if have, want := st.state.GetBalance(st.msg.From), balanceCheck; have.Cmp(want) < 0 {
 		return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.msg.From.Hex(), have, want)
 	}
@@ -302,7 +319,7 @@ func (st *StateTransition) buyGas() error {
 	st.gasRemaining += st.msg.GasLimit
 
 	st.initialGas = st.msg.GasLimit
-	st.state.SubBalance(st.msg.From, mgval)
+	st.state.SubBalance(st.feePayer, mgval)
 	return nil
 }
 
@@ -335,11 +352,30 @@ func (st *StateTransition) preCheck() error {
 
 		// Check that the sender is on the tx allow list if enabled
 		if st.evm.ChainConfig().IsPrecompileEnabled(txallowlist.ContractAddress, st.evm.Context.Time) {
-			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From)
+			txAllowListRole := txallowlist.GetTxAllowListStatus(st.state, msg.From, st.evm.Context.Time)
 			if !txAllowListRole.IsEnabled() {
 				return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, msg.From)
 			}
 		}
+
+		// Check that the sender has not been frozen if the freeze list is enabled
+		if st.evm.ChainConfig().IsPrecompileEnabled(freezelist.ContractAddress, st.evm.Context.Time) {
+			if freezelist.IsAccountFrozen(st.state, msg.From) {
+				return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressFrozen, msg.From)
+			}
+		}
+
+		// Check that the sender may call the function of the recipient contract, or deploy a contract,
+		// if the contract call allow list is enabled
+		if st.evm.ChainConfig().IsPrecompileEnabled(callallowlist.ContractAddress, st.evm.Context.Time) {
+			if msg.To == nil {
+				if !callallowlist.IsCreatePermitted(st.state, msg.From, st.evm.Context.Time) {
+					return fmt.Errorf("%w: %s", vmerrs.ErrContractCreationNotAllowed, msg.From)
+				}
+			} else if st.state.GetCodeSize(*msg.To) > 0 && !callallowlist.IsCallPermitted(st.state, msg.From, *msg.To, msg.Data, st.evm.Context.Time) {
+				return fmt.Errorf("%w: %s %#x", vmerrs.ErrContractCallNotAllowed, msg.To, callallowlist.Selector(msg.Data))
+			}
+		}
 	}
 
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
@@ -386,7 +422,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	// 1. the nonce of the message caller is correct
 	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
 	// 3. the amount of gas required is available in the block
-	// 4. the message caller is on the tx allow list (if enabled)
+	// 4. the message caller is on the tx allow list and not frozen (if enabled)
 	// 5. the purchased gas is enough to cover intrinsic usage
 	// 6. there is no overflow when calculating intrinsic gas
 	// 7. caller has enough balance to cover asset transfer for **topmost** call
@@ -468,7 +504,13 @@ func (st *StateTransition) refundGas(subnetEVM bool) {
 
 	// Return ETH for remaining gas, exchanged at the original rate.
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gasRemaining), st.msg.GasPrice)
-	st.state.AddBalance(st.msg.From, remaining)
+	st.state.AddBalance(st.feePayer, remaining)
+
+	// Charge the fees paid by a sponsor to its daily budget for the sender.
+	if st.feePayer != st.msg.From {
+		spent := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.msg.GasPrice)
+		gassponsor.AddSponsoredSpend(st.state, st.feePayer, st.msg.From, spent, st.evm.Context.Time)
+	}
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
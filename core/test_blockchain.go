@@ -1535,22 +1535,22 @@ func TestStatefulPrecompiles(t *testing.T, create func(db ethdb.Database, gspec
 				gen.AddTx(signedTx)
 			},
 			verifyState: func(sdb *state.StateDB) error {
-				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1)
+				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1, 0)
 				if allowlist.AdminRole != res {
 					return fmt.Errorf("unexpected allow list status for addr1 %s, expected %s", res, allowlist.AdminRole)
 				}
-				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2)
+				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2, 0)
 				if allowlist.AdminRole != res {
 					return fmt.Errorf("unexpected allow list status for addr2 %s, expected %s", res, allowlist.AdminRole)
 				}
 				return nil
 			},
 			verifyGenesis: func(sdb *state.StateDB) {
-				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1)
+				res := deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr1, 0)
 				if allowlist.AdminRole != res {
 					t.Fatalf("unexpected allow list status for addr1 %s, expected %s", res, allowlist.AdminRole)
 				}
-				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2)
+				res = deployerallowlist.GetContractDeployerAllowListStatus(sdb, addr2, 0)
 				if allowlist.NoRole != res {
 					t.Fatalf("unexpected allow list status for addr2 %s, expected %s", res, allowlist.NoRole)
 				}
@@ -1581,7 +1581,7 @@ func TestStatefulPrecompiles(t *testing.T, create func(db ethdb.Database, gspec
 				gen.AddTx(signedTx)
 			},
 			verifyState: func(sdb *state.StateDB) error {
-				res := feemanager.GetFeeManagerStatus(sdb, addr1)
+				res := feemanager.GetFeeManagerStatus(sdb, addr1, 0)
 				assert.Equal(allowlist.AdminRole, res)
 
 				storedConfig := feemanager.GetStoredFeeConfig(sdb)
@@ -1593,7 +1593,7 @@ func TestStatefulPrecompiles(t *testing.T, create func(db ethdb.Database, gspec
 				return nil
 			},
 			verifyGenesis: func(sdb *state.StateDB) {
-				res := feemanager.GetFeeManagerStatus(sdb, addr1)
+				res := feemanager.GetFeeManagerStatus(sdb, addr1, 0)
 				assert.Equal(allowlist.AdminRole, res)
 
 				feeConfig, _, err := blockchain.GetFeeConfigAt(blockchain.Genesis().Header())
@@ -0,0 +1,295 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// maxBundleTxs is the maximum number of transactions in a bundle.
+	maxBundleTxs = 16
+
+	// maxBundles is the maximum number of bundles kept in the pool.
+	maxBundles = 1024
+
+	// maxBundlesPerSender is the maximum number of bundles with transactions of the same sender
+	// kept in the pool.
+	maxBundlesPerSender = 16
+)
+
+var (
+	// ErrEmptyBundle is returned if a bundle contains no transactions.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle contains more than [maxBundleTxs] transactions.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrInvalidBundleTimestamps is returned if the timestamp range of a bundle is empty.
+	ErrInvalidBundleTimestamps = errors.New("invalid bundle timestamp range")
+
+	// ErrBundleExpired is returned if a bundle can no longer be included in a block.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrBundlePoolOverflow is returned if the pool holds [maxBundles] bundles.
+	ErrBundlePoolOverflow = errors.New("bundle pool is full")
+
+	// ErrBundleNonceGap is returned if the nonces of the transactions of a sender in a bundle are
+	// not contiguous, or do not follow the nonce of the sender.
+	ErrBundleNonceGap = errors.New("bundle nonce gap")
+
+	// ErrBundleSenderLimit is returned if a sender of a bundle already has transactions in
+	// [maxBundlesPerSender] bundles of the pool.
+	ErrBundleSenderLimit = errors.New("too many bundles from sender")
+)
+
+// NewBundleEvent is posted when a bundle enters the transaction pool.
+type NewBundleEvent struct{ Bundle *Bundle }
+
+// Bundle is an ordered list of transactions that must all execute successfully and contiguously
+// in the same block, or not be included at all.
+type Bundle struct {
+	Txs types.Transactions
+	// MinTimestamp and MaxTimestamp are the bounds of the timestamps of the blocks the bundle may
+	// be included in. A zero bound is unset.
+	MinTimestamp uint64
+	MaxTimestamp uint64
+
+	// skippedAt is the hash of the head the miner last skipped the bundle at, so that it is not
+	// retried until the head changes. It is guarded by the pool lock.
+	skippedAt common.Hash
+}
+
+// Hash returns the hash of the transaction hashes of [b], identifying the bundle.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// Includable returns true if [b] may be included in a block with [timestamp].
+func (b *Bundle) Includable(timestamp uint64) bool {
+	return (b.MinTimestamp == 0 || timestamp >= b.MinTimestamp) && !b.expired(timestamp)
+}
+
+func (b *Bundle) expired(timestamp uint64) bool {
+	return b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp
+}
+
+// AddBundle validates [bundle] and adds it to the pool, to be included in a block by the miner.
+// Bundles are not gossiped, so they are only included in blocks built by this node.
+func (pool *TxPool) AddBundle(bundle *Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+	if len(bundle.Txs) > maxBundleTxs {
+		return fmt.Errorf("%w: %d transactions > max %d", ErrBundleTooLarge, len(bundle.Txs), maxBundleTxs)
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MinTimestamp > bundle.MaxTimestamp {
+		return fmt.Errorf("%w: min timestamp %d > max timestamp %d", ErrInvalidBundleTimestamps, bundle.MinTimestamp, bundle.MaxTimestamp)
+	}
+	var totalGas uint64
+	for i, tx := range bundle.Txs {
+		if err := pool.validateTxBasics(tx, true); err != nil {
+			return fmt.Errorf("invalid bundle transaction %d: %w", i, err)
+		}
+		totalGas += tx.Gas()
+	}
+	if maxGas := pool.currentMaxGas.Load(); totalGas > maxGas {
+		return fmt.Errorf("%w: bundle gas (%d) > current max gas (%d)", ErrGasLimit, totalGas, maxGas)
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if bundle.expired(pool.currentHead.Time) {
+		return fmt.Errorf("%w: max timestamp %d < head timestamp %d", ErrBundleExpired, bundle.MaxTimestamp, pool.currentHead.Time)
+	}
+	hash := bundle.Hash()
+	for _, existing := range pool.bundles {
+		if existing.Hash() == hash {
+			return ErrAlreadyKnown
+		}
+	}
+	if len(pool.bundles) >= maxBundles {
+		return ErrBundlePoolOverflow
+	}
+	if err := pool.validateBundleState(bundle); err != nil {
+		return err
+	}
+	pool.bundles = append(pool.bundles, bundle)
+	log.Trace("Added bundle to pool", "hash", hash, "txs", len(bundle.Txs))
+
+	go pool.bundleFeed.Send(NewBundleEvent{Bundle: bundle})
+	return nil
+}
+
+// Bundles returns the bundles that may be included in a block with [timestamp], in the order
+// they were added.
+func (pool *TxPool) Bundles(timestamp uint64) []*Bundle {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var (
+		head    = pool.currentHead.Hash()
+		bundles = make([]*Bundle, 0, len(pool.bundles))
+	)
+	for _, bundle := range pool.bundles {
+		if bundle.Includable(timestamp) && bundle.skippedAt != head {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// RemoveBundle removes [bundle] from the pool. It is called by the miner when a transaction of
+// [bundle] fails or reverts, so that the bundle is not retried in every block.
+func (pool *TxPool) RemoveBundle(bundle *Bundle) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	hash := bundle.Hash()
+	for i, existing := range pool.bundles {
+		if existing.Hash() == hash {
+			copy(pool.bundles[i:], pool.bundles[i+1:])
+			pool.bundles[len(pool.bundles)-1] = nil
+			pool.bundles = pool.bundles[:len(pool.bundles)-1]
+			log.Trace("Removed bundle from pool", "hash", hash)
+			return
+		}
+	}
+}
+
+// SkipBundle excludes [bundle] from [Bundles] until the head of the pool is no longer [head]. It
+// is called by the miner when [bundle] does not fit in a block built on [head].
+func (pool *TxPool) SkipBundle(bundle *Bundle, head common.Hash) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	bundle.skippedAt = head
+}
+
+// SubscribeNewBundleEvent registers a subscription of NewBundleEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeNewBundleEvent(ch chan<- NewBundleEvent) event.Subscription {
+	return pool.scope.Track(pool.bundleFeed.Subscribe(ch))
+}
+
+// pruneBundles removes the bundles that expired or were included at the current head.
+// pruneBundles assumes the pool lock is held.
+func (pool *TxPool) pruneBundles() {
+	bundles := pool.bundles[:0]
+	for _, bundle := range pool.bundles {
+		if bundle.expired(pool.currentHead.Time) || pool.bundleStale(bundle) {
+			log.Trace("Removed bundle from pool", "hash", bundle.Hash())
+			continue
+		}
+		bundles = append(bundles, bundle)
+	}
+	for i := len(bundles); i < len(pool.bundles); i++ {
+		pool.bundles[i] = nil
+	}
+	pool.bundles = bundles
+}
+
+// bundleStale returns true if the nonce of a transaction of [bundle] was already used at the
+// current head, so that the bundle can no longer be included.
+// bundleStale assumes the pool lock is held.
+func (pool *TxPool) bundleStale(bundle *Bundle) bool {
+	pool.currentStateLock.Lock()
+	defer pool.currentStateLock.Unlock()
+
+	for _, tx := range bundle.Txs {
+		from, _ := types.Sender(pool.signer, tx) // Already validated
+		if pool.currentState.GetNonce(from) > tx.Nonce() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBundleState checks [bundle] against the current state and the bundles in the pool:
+//   - the nonces of the transactions of each sender must be contiguous, and start at most one
+//     past the highest nonce of the sender in the state or in the other bundles of the pool;
+//   - each sender must be able to pay for all of its transactions in the bundle. Gas sponsorship
+//     is not taken into account;
+//   - each sender may have transactions in at most [maxBundlesPerSender] bundles.
+//
+// validateBundleState assumes the pool lock is held.
+func (pool *TxPool) validateBundleState(bundle *Bundle) error {
+	var (
+		costs       = make(map[common.Address]*big.Int)
+		firstNonces = make(map[common.Address]uint64)
+		nextNonces  = make(map[common.Address]uint64)
+	)
+	for i, tx := range bundle.Txs {
+		from, _ := types.Sender(pool.signer, tx) // Already validated
+		if cost, ok := costs[from]; ok {
+			if tx.Nonce() != nextNonces[from] {
+				return fmt.Errorf("%w: bundle transaction %d has nonce %d, expected %d", ErrBundleNonceGap, i, tx.Nonce(), nextNonces[from])
+			}
+			cost.Add(cost, tx.Cost())
+		} else {
+			costs[from] = tx.Cost()
+			firstNonces[from] = tx.Nonce()
+		}
+		nextNonces[from] = tx.Nonce() + 1
+	}
+
+	// Count the bundles of each sender and find the highest nonce of the sender in them
+	var (
+		counts       = make(map[common.Address]int, len(costs))
+		pooledNonces = make(map[common.Address]uint64, len(costs))
+	)
+	for _, existing := range pool.bundles {
+		seen := make(map[common.Address]bool)
+		for _, tx := range existing.Txs {
+			from, _ := types.Sender(pool.signer, tx) // Already validated
+			if _, ok := costs[from]; !ok {
+				continue
+			}
+			if !seen[from] {
+				seen[from] = true
+				counts[from]++
+			}
+			if tx.Nonce()+1 > pooledNonces[from] {
+				pooledNonces[from] = tx.Nonce() + 1
+			}
+		}
+	}
+
+	pool.currentStateLock.Lock()
+	defer pool.currentStateLock.Unlock()
+
+	for from, cost := range costs {
+		if counts[from] >= maxBundlesPerSender {
+			return fmt.Errorf("%w: %s has %d bundles", ErrBundleSenderLimit, from, counts[from])
+		}
+		var (
+			nonce      = firstNonces[from]
+			stateNonce = pool.currentState.GetNonce(from)
+		)
+		if nonce < stateNonce {
+			return fmt.Errorf("%w: address %s, tx: %d state: %d: %w", ErrBundleExpired, from, nonce, stateNonce, core.ErrNonceTooLow)
+		}
+		if nonce > stateNonce && nonce > pooledNonces[from] {
+			return fmt.Errorf("%w: address %s, tx: %d state: %d", ErrBundleNonceGap, from, nonce, stateNonce)
+		}
+		if balance := pool.currentState.GetBalance(from); balance.Cmp(cost) < 0 {
+			return fmt.Errorf("%w: address %s have %v want %v", core.ErrInsufficientFunds, from, balance, cost)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+	poorKey, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(poorKey.PublicKey), big.NewInt(100000))
+
+	bundle := &Bundle{Txs: types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}}
+	if err := pool.AddBundle(bundle); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	if err := pool.AddBundle(bundle); !errors.Is(err, ErrAlreadyKnown) {
+		t.Fatalf("adding duplicate bundle error mismatch: have %v, want %v", err, ErrAlreadyKnown)
+	}
+	// Bundles are kept apart from the pending and queued transactions
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("bundle transactions added to the pool: pending %d, queued %d", pending, queued)
+	}
+
+	tests := map[string]struct {
+		bundle *Bundle
+		err    error
+	}{
+		"empty": {
+			bundle: &Bundle{},
+			err:    ErrEmptyBundle,
+		},
+		"too large": {
+			bundle: &Bundle{Txs: make(types.Transactions, maxBundleTxs+1)},
+			err:    ErrBundleTooLarge,
+		},
+		"invalid timestamps": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(0, 100000, key)}, MinTimestamp: 2, MaxTimestamp: 1},
+			err:    ErrInvalidBundleTimestamps,
+		},
+		"invalid transaction": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(0, 100, key)}},
+			err:    core.ErrIntrinsicGas,
+		},
+		"exceeds gas limit": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(0, 6000000, key), transaction(1, 6000000, key)}},
+			err:    ErrGasLimit,
+		},
+		"nonce gap within bundle": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(2, 100000, key), transaction(4, 100000, key)}},
+			err:    ErrBundleNonceGap,
+		},
+		"nonce gap after pooled bundles": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(3, 100000, key)}},
+			err:    ErrBundleNonceGap,
+		},
+		"insufficient funds": {
+			bundle: &Bundle{Txs: types.Transactions{transaction(0, 60000, poorKey), transaction(1, 60000, poorKey)}},
+			err:    core.ErrInsufficientFunds,
+		},
+	}
+	for name, test := range tests {
+		if err := pool.AddBundle(test.bundle); !errors.Is(err, test.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", name, err, test.err)
+		}
+	}
+}
+
+func TestBundlesIncludable(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	early := &Bundle{Txs: types.Transactions{transaction(0, 100000, key)}, MaxTimestamp: 10}
+	late := &Bundle{Txs: types.Transactions{transaction(1, 100000, key)}, MinTimestamp: 20}
+	for _, bundle := range []*Bundle{early, late} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+	if bundles := pool.Bundles(5); len(bundles) != 1 || bundles[0] != early {
+		t.Fatalf("bundles includable at 5 mismatch: have %v", bundles)
+	}
+	if bundles := pool.Bundles(15); len(bundles) != 0 {
+		t.Fatalf("bundles includable at 15 mismatch: have %v", bundles)
+	}
+	if bundles := pool.Bundles(25); len(bundles) != 1 || bundles[0] != late {
+		t.Fatalf("bundles includable at 25 mismatch: have %v", bundles)
+	}
+
+	// Once the nonce of a bundle transaction is used, the bundle is dropped
+	testSetNonce(pool, crypto.PubkeyToAddress(key.PublicKey), 1)
+	pool.mu.Lock()
+	pool.pruneBundles()
+	pool.mu.Unlock()
+	if bundles := pool.Bundles(5); len(bundles) != 0 {
+		t.Fatalf("stale bundle not pruned: have %v", bundles)
+	}
+	if bundles := pool.Bundles(25); len(bundles) != 1 || bundles[0] != late {
+		t.Fatalf("bundles includable at 25 mismatch after pruning: have %v", bundles)
+	}
+}
+
+func TestAddBundleNonceState(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+	testSetNonce(pool, from, 2)
+
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{transaction(1, 100000, key)}}); !errors.Is(err, core.ErrNonceTooLow) {
+		t.Fatalf("adding bundle with used nonce error mismatch: have %v, want %v", err, core.ErrNonceTooLow)
+	}
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{transaction(3, 100000, key)}}); !errors.Is(err, ErrBundleNonceGap) {
+		t.Fatalf("adding bundle with nonce gap error mismatch: have %v, want %v", err, ErrBundleNonceGap)
+	}
+	// Alternative bundles may share nonces, and a bundle may follow another one
+	for _, bundle := range []*Bundle{
+		{Txs: types.Transactions{transaction(2, 100000, key)}},
+		{Txs: types.Transactions{transaction(2, 110000, key)}},
+		{Txs: types.Transactions{transaction(3, 100000, key)}},
+	} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+}
+
+func TestBundleSenderLimit(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	for i := 0; i < maxBundlesPerSender; i++ {
+		bundle := &Bundle{Txs: types.Transactions{transaction(0, uint64(100000+i), key)}}
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("failed to add bundle %d: %v", i, err)
+		}
+	}
+	bundle := &Bundle{Txs: types.Transactions{transaction(0, 200000, key)}}
+	if err := pool.AddBundle(bundle); !errors.Is(err, ErrBundleSenderLimit) {
+		t.Fatalf("adding bundle over sender limit error mismatch: have %v, want %v", err, ErrBundleSenderLimit)
+	}
+
+	// Other senders are not limited
+	otherKey, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(otherKey.PublicKey), big.NewInt(1000000000))
+	if err := pool.AddBundle(&Bundle{Txs: types.Transactions{transaction(0, 100000, otherKey)}}); err != nil {
+		t.Fatalf("failed to add bundle of other sender: %v", err)
+	}
+}
+
+func TestRemoveAndSkipBundle(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	failed := &Bundle{Txs: types.Transactions{transaction(0, 100000, key)}}
+	skipped := &Bundle{Txs: types.Transactions{transaction(0, 110000, key)}}
+	for _, bundle := range []*Bundle{failed, skipped} {
+		if err := pool.AddBundle(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+
+	pool.RemoveBundle(failed)
+	if bundles := pool.Bundles(0); len(bundles) != 1 || bundles[0] != skipped {
+		t.Fatalf("bundles mismatch after removal: have %v", bundles)
+	}
+
+	// A skipped bundle is not returned until the head changes
+	pool.mu.RLock()
+	head := pool.currentHead
+	pool.mu.RUnlock()
+	pool.SkipBundle(skipped, head.Hash())
+	if bundles := pool.Bundles(0); len(bundles) != 0 {
+		t.Fatalf("skipped bundle returned at the same head: have %v", bundles)
+	}
+	pool.mu.Lock()
+	pool.currentHead = &types.Header{Number: new(big.Int).Add(head.Number, common.Big1), GasLimit: head.GasLimit}
+	pool.mu.Unlock()
+	if bundles := pool.Bundles(0); len(bundles) != 1 || bundles[0] != skipped {
+		t.Fatalf("skipped bundle not returned at a new head: have %v", bundles)
+	}
+}
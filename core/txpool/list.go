@@ -348,7 +348,11 @@ func (l *list) Forward(threshold uint64) types.Transactions {
 // a point in calculating all the costs or if the balance covers all. If the threshold
 // is lower than the costgas cap, the caps will be reset to a new high after removing
 // the newly invalidated transactions.
-func (l *list) Filter(costLimit *big.Int, gasLimit uint64) (types.Transactions, types.Transactions) {
+//
+// If [sponsored] is non-nil, transactions it reports as sponsored only need their value
+// to be covered by [costLimit], since their gas is paid by a sponsor. [sponsored] is
+// called on the transactions within [gasLimit] in nonce order.
+func (l *list) Filter(costLimit *big.Int, gasLimit uint64, sponsored func(*types.Transaction) bool) (types.Transactions, types.Transactions) {
 	// If all transactions are below the threshold, short circuit
 	if l.costcap.Cmp(costLimit) <= 0 && l.gascap <= gasLimit {
 		return nil, nil
@@ -356,10 +360,35 @@ func (l *list) Filter(costLimit *big.Int, gasLimit uint64) (types.Transactions,
 	l.costcap = new(big.Int).Set(costLimit) // Lower the caps to the thresholds
 	l.gascap = gasLimit
 
+	// Sponsors pay for the transactions in nonce order, until their budget runs out
+	var sponsoredTxs map[uint64]bool
+	if sponsored != nil {
+		sponsoredTxs = make(map[uint64]bool)
+		for _, tx := range l.txs.flatten() {
+			if tx.Gas() <= gasLimit && sponsored(tx) {
+				sponsoredTxs[tx.Nonce()] = true
+			}
+		}
+	}
 	// Filter out all the transactions above the account's funds
 	removed := l.txs.Filter(func(tx *types.Transaction) bool {
-		return tx.Gas() > gasLimit || tx.Cost().Cmp(costLimit) > 0
+		if tx.Gas() > gasLimit {
+			return true
+		}
+		if sponsoredTxs[tx.Nonce()] {
+			return tx.Value().Cmp(costLimit) > 0
+		}
+		return tx.Cost().Cmp(costLimit) > 0
 	})
+	if sponsored != nil {
+		// Sponsored transactions may cost more than the threshold, so the cost cap
+		// has to cover the remaining transactions for the short circuit to stay valid.
+		for _, tx := range l.txs.items {
+			if cost := tx.Cost(); l.costcap.Cmp(cost) < 0 {
+				l.costcap = cost
+			}
+		}
+	}
 
 	if len(removed) == 0 {
 		return nil, nil
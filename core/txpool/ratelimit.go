@@ -0,0 +1,236 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/metrics"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minSpamScore is the spam score under which a sender is no longer considered noisy.
+const minSpamScore = 0.01
+
+var (
+	// ErrSenderRateLimited is returned if the sender of a remote transaction exceeds
+	// its admission rate limit.
+	ErrSenderRateLimited = errors.New("sender rate limited")
+
+	// ErrPeerRateLimited is returned if the peer relaying a transaction exceeds its
+	// admission rate limit.
+	ErrPeerRateLimited = errors.New("peer rate limited")
+
+	senderRateLimitMeter = metrics.NewRegisteredMeter("txpool/ratelimit/sender", nil)
+	peerRateLimitMeter   = metrics.NewRegisteredMeter("txpool/ratelimit/peer", nil)
+	noisySendersGauge    = metrics.NewRegisteredGauge("txpool/ratelimit/noisy", nil)
+)
+
+// rateLimits are the sustained admission rates of a rate limiter. A zero rate is unlimited.
+type rateLimits struct {
+	txRate  float64 // Transactions per second
+	gasRate float64 // Gas per second
+}
+
+func (l rateLimits) enabled() bool {
+	return l.txRate > 0 || l.gasRate > 0
+}
+
+// rateBucket tracks the admission allowance and the spam score of a single key.
+type rateBucket struct {
+	txs   float64 // Transactions that may be admitted
+	gas   float64 // Gas that may be admitted
+	score float64 // Decaying count of rejected transactions
+	last  time.Time
+}
+
+// rateLimiter limits the rate of transactions and gas admitted per key with token buckets
+// holding up to [burst] of the sustained rate. Each rejected transaction increments the
+// spam score of its key, which decays with [halfLife] and divides the rate at which the
+// allowance of the key refills, deprioritizing keys that keep exceeding their limit.
+type rateLimiter[K comparable] struct {
+	limits   rateLimits
+	burst    time.Duration
+	halfLife time.Duration
+	clock    mockable.Clock
+
+	lock    sync.Mutex
+	buckets map[K]*rateBucket
+}
+
+func newRateLimiter[K comparable](limits rateLimits, burst, halfLife time.Duration) *rateLimiter[K] {
+	return &rateLimiter[K]{
+		limits:   limits,
+		burst:    burst,
+		halfLife: halfLife,
+		buckets:  make(map[K]*rateBucket),
+	}
+}
+
+// allow returns true if a transaction with [gas] from [key] may be admitted, consuming
+// its allowance if so, or increments the spam score of [key] otherwise.
+func (r *rateLimiter[K]) allow(key K, gas uint64) bool {
+	if !r.limits.enabled() {
+		return true
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &rateBucket{
+			txs:  r.capacity(r.limits.txRate),
+			gas:  r.capacity(r.limits.gasRate),
+			last: r.clock.Time(),
+		}
+		r.buckets[key] = bucket
+	} else {
+		r.refill(bucket, r.clock.Time())
+	}
+
+	if (r.limits.txRate > 0 && bucket.txs < 1) || (r.limits.gasRate > 0 && bucket.gas < float64(gas)) {
+		bucket.score++
+		return false
+	}
+	bucket.txs--
+	bucket.gas -= float64(gas)
+	return true
+}
+
+// score returns the current spam score of [key].
+func (r *rateLimiter[K]) score(key K) float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		return 0
+	}
+	r.refill(bucket, r.clock.Time())
+	return bucket.score
+}
+
+// prune drops the buckets of keys with a full allowance and a negligible spam score, which
+// are equivalent to untracked keys, and returns the number of noisy keys remaining.
+func (r *rateLimiter[K]) prune() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var (
+		now   = r.clock.Time()
+		noisy int
+	)
+	for key, bucket := range r.buckets {
+		r.refill(bucket, now)
+		if bucket.score >= minSpamScore {
+			noisy++
+			continue
+		}
+		if (r.limits.txRate == 0 || bucket.txs >= r.capacity(r.limits.txRate)) &&
+			(r.limits.gasRate == 0 || bucket.gas >= r.capacity(r.limits.gasRate)) {
+			delete(r.buckets, key)
+		}
+	}
+	return noisy
+}
+
+// refill decays the spam score of [bucket] and refills its allowance up to [now].
+// refill assumes the lock is held.
+func (r *rateLimiter[K]) refill(bucket *rateBucket, now time.Time) {
+	elapsed := now.Sub(bucket.last)
+	if elapsed <= 0 {
+		return
+	}
+	bucket.last = now
+
+	// Refill at the rate slowed down by the average spam score over the elapsed time
+	decayed := bucket.score * math.Exp2(-elapsed.Seconds()/r.halfLife.Seconds())
+	averageScore := (bucket.score - decayed) * r.halfLife.Seconds() / (math.Ln2 * elapsed.Seconds())
+	bucket.score = decayed
+	seconds := elapsed.Seconds() / (1 + averageScore)
+	bucket.txs = math.Min(bucket.txs+seconds*r.limits.txRate, r.capacity(r.limits.txRate))
+	bucket.gas = math.Min(bucket.gas+seconds*r.limits.gasRate, r.capacity(r.limits.gasRate))
+}
+
+func (r *rateLimiter[K]) capacity(rate float64) float64 {
+	return rate * r.burst.Seconds()
+}
+
+// rateLimitLocked returns the transactions of [txs] whose remote senders are within their
+// admission rate limit, and sets the error of the others in the nil slots of [errs].
+// The transaction pool lock must be held.
+func (pool *TxPool) rateLimitLocked(txs []*types.Transaction, errs []error) []*types.Transaction {
+	if !pool.senderLimiter.limits.enabled() {
+		return txs
+	}
+	var (
+		allowed = txs[:0]
+		slot    = 0
+	)
+	for _, tx := range txs {
+		for errs[slot] != nil {
+			slot++
+		}
+		from, _ := types.Sender(pool.signer, tx) // Already validated
+		if pool.locals.contains(from) || pool.senderLimiter.allow(from, tx.Gas()) {
+			allowed = append(allowed, tx)
+		} else {
+			errs[slot] = ErrSenderRateLimited
+			senderRateLimitMeter.Mark(1)
+		}
+		slot++
+	}
+	return allowed
+}
+
+// AddRemotesFromPeer is like AddRemotes, but first applies the admission rate limit of [peer],
+// the identifier of the peer relaying [txs].
+func (pool *TxPool) AddRemotesFromPeer(peer string, txs []*types.Transaction) []error {
+	if !pool.peerLimiter.limits.enabled() {
+		return pool.AddRemotes(txs)
+	}
+	var (
+		errs    = make([]error, len(txs))
+		allowed = make([]*types.Transaction, 0, len(txs))
+	)
+	for i, tx := range txs {
+		if pool.all.Get(tx.Hash()) != nil {
+			// Known transactions are not charged to the peer
+			allowed = append(allowed, tx)
+			continue
+		}
+		if !pool.peerLimiter.allow(peer, tx.Gas()) {
+			errs[i] = ErrPeerRateLimited
+			peerRateLimitMeter.Mark(1)
+			continue
+		}
+		allowed = append(allowed, tx)
+	}
+	allowedErrs := pool.AddRemotes(allowed)
+	for i, j := 0, 0; i < len(errs); i++ {
+		if errs[i] == nil {
+			errs[i] = allowedErrs[j]
+			j++
+		}
+	}
+	return errs
+}
+
+// SpamScore returns the spam score of [sender], the decaying count of its remote transactions
+// rejected for exceeding its admission rate limit.
+func (pool *TxPool) SpamScore(sender common.Address) float64 {
+	return pool.senderLimiter.score(sender)
+}
+
+// pruneRateLimiters drops the rate limiting state of the senders and peers that are no longer
+// limited.
+func (pool *TxPool) pruneRateLimiters() {
+	noisySendersGauge.Update(int64(pool.senderLimiter.prune()))
+	pool.peerLimiter.prune()
+}
@@ -0,0 +1,125 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+func TestRateLimiterSpamScore(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter[string](rateLimits{txRate: 1, gasRate: 100}, 2*time.Second, 10*time.Second)
+	limiter.clock.Set(time.Unix(1000, 0))
+
+	// Both keys may use the burst allowance, after which the noisy key is rejected
+	for _, key := range []string{"quiet", "noisy"} {
+		for i := 0; i < 2; i++ {
+			if !limiter.allow(key, 10) {
+				t.Fatalf("%s: transaction %d rejected within burst", key, i)
+			}
+		}
+	}
+	if limiter.allow("noisy", 10) {
+		t.Fatal("transaction admitted over rate limit")
+	}
+	if score := limiter.score("noisy"); score != 1 {
+		t.Fatalf("spam score mismatch: have %f, want 1", score)
+	}
+	if limiter.allow("greedy", 1000) {
+		t.Fatal("transaction admitted over gas rate limit")
+	}
+
+	// The allowance of the noisy key refills slower than the allowance of the quiet key
+	limiter.clock.Set(limiter.clock.Time().Add(time.Second))
+	if limiter.allow("noisy", 10) {
+		t.Fatal("noisy key not deprioritized")
+	}
+	if !limiter.allow("quiet", 10) {
+		t.Fatal("transaction rejected after refill")
+	}
+
+	// Once the spam score decays, the buckets are pruned
+	limiter.clock.Set(limiter.clock.Time().Add(10 * time.Second))
+	if noisy := limiter.prune(); noisy != 2 {
+		t.Fatalf("noisy keys mismatch: have %d, want 2", noisy)
+	}
+	limiter.clock.Set(limiter.clock.Time().Add(5 * time.Minute))
+	if noisy := limiter.prune(); noisy != 0 {
+		t.Fatalf("noisy keys mismatch: have %d, want 0", noisy)
+	}
+	if len(limiter.buckets) != 0 {
+		t.Fatalf("buckets not pruned: have %d", len(limiter.buckets))
+	}
+}
+
+func TestSenderAndPeerRateLimits(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(10000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.NoLocals = false
+	config.SenderTxRate = 1.5
+	config.PeerTxRate = 2
+	config.RateLimitBurst = 2 * time.Second
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+	pool.senderLimiter.clock.Set(time.Unix(1000, 0))
+	pool.peerLimiter.clock.Set(time.Unix(1000, 0))
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+
+	// Remote senders are limited to 3 transactions within the burst
+	errs := pool.AddRemotesSync([]*types.Transaction{
+		transaction(0, 100000, keys[0]),
+		transaction(1, 100000, keys[0]),
+		transaction(2, 100000, keys[0]),
+		transaction(3, 100000, keys[0]),
+	})
+	if errs[0] != nil || errs[1] != nil || errs[2] != nil || !errors.Is(errs[3], ErrSenderRateLimited) {
+		t.Fatalf("sender rate limit errors mismatch: have %v", errs)
+	}
+	if score := pool.SpamScore(crypto.PubkeyToAddress(keys[0].PublicKey)); score <= 0 {
+		t.Fatalf("spam score of rate limited sender not increased: have %f", score)
+	}
+	// Local transactions are not limited
+	if err := pool.AddLocal(transaction(3, 100000, keys[0])); err != nil {
+		t.Fatalf("local transaction rejected: %v", err)
+	}
+
+	// Peers are limited to 4 transactions within the burst, known transactions are not charged
+	txs := []*types.Transaction{
+		transaction(0, 100000, keys[0]),
+		transaction(0, 100000, keys[1]),
+		transaction(1, 100000, keys[1]),
+		transaction(0, 100000, keys[2]),
+		transaction(1, 100000, keys[2]),
+		transaction(2, 100000, keys[2]),
+	}
+	errs = pool.AddRemotesFromPeer("peer", txs)
+	if !errors.Is(errs[0], ErrAlreadyKnown) || errs[1] != nil || errs[2] != nil || errs[3] != nil || errs[4] != nil || !errors.Is(errs[5], ErrPeerRateLimited) {
+		t.Fatalf("peer rate limit errors mismatch: have %v", errs)
+	}
+	if errs = pool.AddRemotesFromPeer("other", txs[5:]); errs[0] != nil {
+		t.Fatalf("transaction from other peer rejected: %v", errs[0])
+	}
+}
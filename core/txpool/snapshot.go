@@ -0,0 +1,117 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotBatchSize is the number of transactions restored from the snapshot at once.
+const snapshotBatchSize = 1024
+
+// snapshotTxs returns the transactions written to the pool snapshot: the pending and queued
+// transactions of remote accounts, and of local accounts if they are not journaled.
+// snapshotTxs assumes the pool lock is held.
+func (pool *TxPool) snapshotTxs() map[common.Address]types.Transactions {
+	txs := make(map[common.Address]types.Transactions)
+	add := func(lists map[common.Address]*list) {
+		for addr, list := range lists {
+			if pool.journal != nil && pool.locals.contains(addr) {
+				continue
+			}
+			txs[addr] = append(txs[addr], list.Flatten()...)
+		}
+	}
+	add(pool.pending)
+	add(pool.queue)
+	return txs
+}
+
+// saveSnapshot writes the transactions of the pool to the snapshot file, replacing the
+// previous snapshot.
+func (pool *TxPool) saveSnapshot() error {
+	pool.mu.RLock()
+	txs := pool.snapshotTxs()
+	pool.mu.RUnlock()
+
+	path := pool.config.Snapshot
+	output, err := os.OpenFile(path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	saved := 0
+	for _, accTxs := range txs {
+		for _, tx := range accTxs {
+			if err := rlp.Encode(output, tx); err != nil {
+				output.Close()
+				return err
+			}
+		}
+		saved += len(accTxs)
+	}
+	if err := output.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".new", path); err != nil {
+		return err
+	}
+	log.Debug("Saved transaction pool snapshot", "transactions", saved, "accounts", len(txs))
+	return nil
+}
+
+// loadSnapshot restores the transactions of the snapshot file into the pool. The transactions
+// are validated against the current head, and the invalid ones are dropped.
+func (pool *TxPool) loadSnapshot() error {
+	input, err := os.Open(pool.config.Snapshot)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	var (
+		stream         = rlp.NewStream(input, 0)
+		batch          = make(types.Transactions, 0, snapshotBatchSize)
+		total, dropped int
+	)
+	restoreBatch := func() {
+		// Restored transactions were admitted before the restart, so rate limits do not apply
+		for _, err := range pool.addTxs(batch, false, true, false) {
+			if err != nil {
+				log.Debug("Failed to restore snapshot transaction", "err", err)
+				dropped++
+			}
+		}
+		batch = batch[:0]
+	}
+	for {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			if len(batch) > 0 {
+				restoreBatch()
+			}
+			if err != io.EOF {
+				return fmt.Errorf("failed to decode snapshot transaction %d: %w", total, err)
+			}
+			break
+		}
+		total++
+		if batch = append(batch, tx); len(batch) == snapshotBatchSize {
+			restoreBatch()
+		}
+	}
+	log.Info("Restored transaction pool snapshot", "transactions", total, "dropped", dropped)
+	return nil
+}
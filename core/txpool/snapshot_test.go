@@ -0,0 +1,67 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txpool
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(10000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.Snapshot = filepath.Join(t.TempDir(), "snapshot.rlp")
+
+	key0, _ := crypto.GenerateKey()
+	key1, _ := crypto.GenerateKey()
+	addr0, addr1 := crypto.PubkeyToAddress(key0.PublicKey), crypto.PubkeyToAddress(key1.PublicKey)
+	statedb.AddBalance(addr0, big.NewInt(1000000000))
+	statedb.AddBalance(addr1, big.NewInt(1000000000))
+
+	// Fill the pool with pending and queued remote transactions, and save them on shutdown
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	errs := pool.AddRemotesSync([]*types.Transaction{
+		transaction(0, 100000, key0),
+		transaction(1, 100000, key0),
+		transaction(3, 100000, key0),
+		transaction(0, 100000, key1),
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool stats mismatch before restart: have %d pending, %d queued, want 3 pending, 1 queued", pending, queued)
+	}
+	pool.Stop()
+
+	// The transaction of [key1] was included while the node was down, so it is dropped on restore
+	statedb.SetNonce(addr1, 1)
+
+	pool = NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("pool stats mismatch after restart: have %d pending, %d queued, want 2 pending, 1 queued", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	if pool.Get(transaction(3, 100000, key0).Hash()) == nil {
+		t.Fatal("queued transaction not restored")
+	}
+}
@@ -43,7 +43,10 @@ import (
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/metrics"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/feemanager"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/gassponsor"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
@@ -176,6 +179,7 @@ type blockChain interface {
 	StateAt(root common.Hash) (*state.StateDB, error)
 	SenderCacher() *core.TxSenderCacher
 	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error)
 
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 }
@@ -187,6 +191,9 @@ type Config struct {
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
+	Snapshot         string        // Snapshot of remote transactions to survive node restarts, disabled if empty
+	SnapshotInterval time.Duration // Time interval to save the transaction snapshot
+
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
 
@@ -196,6 +203,13 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	SenderTxRate      float64       // Maximum sustained rate of remote transactions admitted per sender (txs/s), unlimited if 0
+	SenderGasRate     uint64        // Maximum sustained rate of gas of remote transactions admitted per sender (gas/s), unlimited if 0
+	PeerTxRate        float64       // Maximum sustained rate of transactions admitted per gossiping peer (txs/s), unlimited if 0
+	PeerGasRate       uint64        // Maximum sustained rate of gas of transactions admitted per gossiping peer (gas/s), unlimited if 0
+	RateLimitBurst    time.Duration // Duration of the sustained rate that may be admitted at once
+	SpamScoreHalfLife time.Duration // Half-life of the spam score slowing down senders exceeding their rate limit
 }
 
 // DefaultConfig contains the default configurations for the transaction
@@ -204,6 +218,8 @@ var DefaultConfig = Config{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
 
+	SnapshotInterval: 5 * time.Minute,
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -213,6 +229,9 @@ var DefaultConfig = Config{
 	GlobalQueue:  1024,
 
 	Lifetime: 3 * time.Hour,
+
+	RateLimitBurst:    10 * time.Second,
+	SpamScoreHalfLife: time.Minute,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -223,6 +242,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool journal time", "provided", conf.Rejournal, "updated", time.Second)
 		conf.Rejournal = time.Second
 	}
+	if conf.Snapshot != "" && conf.SnapshotInterval < time.Second {
+		log.Warn("Sanitizing invalid txpool snapshot interval", "provided", conf.SnapshotInterval, "updated", time.Second)
+		conf.SnapshotInterval = time.Second
+	}
 	if conf.PriceLimit < 1 {
 		log.Warn("Sanitizing invalid txpool price limit", "provided", conf.PriceLimit, "updated", DefaultConfig.PriceLimit)
 		conf.PriceLimit = DefaultConfig.PriceLimit
@@ -251,9 +274,37 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.SenderTxRate < 0 {
+		log.Warn("Sanitizing invalid txpool sender tx rate", "provided", conf.SenderTxRate, "updated", 0)
+		conf.SenderTxRate = 0
+	}
+	if conf.PeerTxRate < 0 {
+		log.Warn("Sanitizing invalid txpool peer tx rate", "provided", conf.PeerTxRate, "updated", 0)
+		conf.PeerTxRate = 0
+	}
+	if conf.senderLimits().enabled() || conf.peerLimits().enabled() {
+		if conf.RateLimitBurst < time.Second {
+			log.Warn("Sanitizing invalid txpool rate limit burst", "provided", conf.RateLimitBurst, "updated", DefaultConfig.RateLimitBurst)
+			conf.RateLimitBurst = DefaultConfig.RateLimitBurst
+		}
+		if conf.SpamScoreHalfLife < time.Second {
+			log.Warn("Sanitizing invalid txpool spam score half-life", "provided", conf.SpamScoreHalfLife, "updated", DefaultConfig.SpamScoreHalfLife)
+			conf.SpamScoreHalfLife = DefaultConfig.SpamScoreHalfLife
+		}
+	}
 	return conf
 }
 
+// senderLimits returns the admission rate limits of each remote sender.
+func (config *Config) senderLimits() rateLimits {
+	return rateLimits{txRate: config.SenderTxRate, gasRate: float64(config.SenderGasRate)}
+}
+
+// peerLimits returns the admission rate limits of each gossiping peer.
+func (config *Config) peerLimits() rateLimits {
+	return rateLimits{txRate: config.PeerTxRate, gasRate: float64(config.PeerGasRate)}
+}
+
 // TxPool contains all currently known transactions. Transactions
 // enter the pool when they are received from the network or submitted
 // locally. They exit the pool when they are included in the blockchain.
@@ -270,6 +321,7 @@ type TxPool struct {
 	txFeed      event.Feed
 	headFeed    event.Feed
 	reorgFeed   event.Feed
+	bundleFeed  event.Feed
 	scope       event.SubscriptionScope
 	signer      types.Signer
 	mu          sync.RWMutex
@@ -298,6 +350,10 @@ type TxPool struct {
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
+	bundles []*Bundle                    // Bundles of transactions included all together or not at all
+
+	senderLimiter *rateLimiter[common.Address] // Admission rate limits of remote senders
+	peerLimiter   *rateLimiter[string]         // Admission rate limits of gossiping peers
 
 	chainHeadCh         chan core.ChainHeadEvent
 	chainHeadSub        event.Subscription
@@ -343,6 +399,8 @@ func NewTxPool(config Config, chainconfig *params.ChainConfig, chain blockChain)
 		initDoneCh:          make(chan struct{}),
 		generalShutdownChan: make(chan struct{}),
 		gasPrice:            new(big.Int).SetUint64(config.PriceLimit),
+		senderLimiter:       newRateLimiter[common.Address](config.senderLimits(), config.RateLimitBurst, config.SpamScoreHalfLife),
+		peerLimiter:         newRateLimiter[string](config.peerLimits(), config.RateLimitBurst, config.SpamScoreHalfLife),
 	}
 	pool.locals = newAccountSet(pool.signer)
 	for _, addr := range config.Locals {
@@ -367,6 +425,12 @@ func NewTxPool(config Config, chainconfig *params.ChainConfig, chain blockChain)
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
+	// If the snapshot is enabled, restore the transactions that were in the pool before the restart
+	if config.Snapshot != "" {
+		if err := pool.loadSnapshot(); err != nil {
+			log.Warn("Failed to load transaction pool snapshot", "err", err)
+		}
+	}
 
 	// Subscribe events from blockchain and start the main event loop.
 	pool.chainHeadSub = pool.chain.SubscribeChainHeadEvent(pool.chainHeadCh)
@@ -397,6 +461,14 @@ func (pool *TxPool) loop() {
 	defer evict.Stop()
 	defer journal.Stop()
 
+	// Start the snapshot ticker if the snapshot is enabled
+	var snapshot <-chan time.Time
+	if pool.config.Snapshot != "" {
+		ticker := time.NewTicker(pool.config.SnapshotInterval)
+		defer ticker.Stop()
+		snapshot = ticker.C
+	}
+
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
 	for {
@@ -444,6 +516,7 @@ func (pool *TxPool) loop() {
 				}
 			}
 			pool.mu.Unlock()
+			pool.pruneRateLimiters()
 
 		// Handle local transaction journal rotation
 		case <-journal.C:
@@ -454,6 +527,12 @@ func (pool *TxPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+
+		// Handle transaction pool snapshot
+		case <-snapshot:
+			if err := pool.saveSnapshot(); err != nil {
+				log.Warn("Failed to save transaction pool snapshot", "err", err)
+			}
 		}
 	}
 }
@@ -471,6 +550,11 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.config.Snapshot != "" {
+		if err := pool.saveSnapshot(); err != nil {
+			log.Warn("Failed to save transaction pool snapshot", "err", err)
+		}
+	}
 	log.Info("Transaction pool stopped")
 }
 
@@ -718,7 +802,29 @@ func (pool *TxPool) checkTxState(from common.Address, tx *types.Transaction) err
 
 	// cost == V + GP * GL
 	balance := pool.currentState.GetBalance(from)
-	if balance.Cmp(tx.Cost()) < 0 {
+	// If the gas of the transaction is paid by a sponsor, the sender only has to cover its value.
+	// The gas sponsored for the other pending and queued transactions of the sender counts
+	// against the remaining budget of the sponsor.
+	var sponsored bool
+	if pool.rules.Load().IsPrecompileEnabled(gassponsor.ContractAddress) {
+		var others types.Transactions
+		for _, account := range []*list{pool.pending[from], pool.queue[from]} {
+			if account == nil {
+				continue
+			}
+			for _, otherTx := range account.Flatten() {
+				if otherTx.Nonce() != txNonce {
+					others = append(others, otherTx)
+				}
+			}
+		}
+		sponsored = pool.sponsoredFilter(from, others)(tx)
+	}
+	if sponsored {
+		if balance.Cmp(tx.Value()) < 0 {
+			return fmt.Errorf("%w: address %s have (%d) want (%d)", core.ErrInsufficientFunds, from.Hex(), balance, tx.Value())
+		}
+	} else if balance.Cmp(tx.Cost()) < 0 {
 		return fmt.Errorf("%w: address %s have (%d) want (%d)", core.ErrInsufficientFunds, from.Hex(), balance, tx.Cost())
 	}
 
@@ -726,10 +832,21 @@ func (pool *TxPool) checkTxState(from common.Address, tx *types.Transaction) err
 	list := pool.pending[from]
 	if list != nil { // Sender already has pending txs
 		sum := new(big.Int).Add(tx.Cost(), list.totalcost)
+		if sponsored {
+			sum.Sub(sum, gasCost(tx))
+		}
 		if repl := list.txs.Get(tx.Nonce()); repl != nil {
 			// Deduct the cost of a transaction replaced by this
 			sum.Sub(sum, repl.Cost())
 		}
+		// Deduct the gas of the other pending transactions paid by a sponsor
+		if isSponsored := pool.sponsoredFilter(from, nil); isSponsored != nil {
+			for _, pendingTx := range list.Flatten() {
+				if pendingTx.Nonce() != txNonce && isSponsored(pendingTx) {
+					sum.Sub(sum, gasCost(pendingTx))
+				}
+			}
+		}
 		if balance.Cmp(sum) < 0 {
 			log.Trace("Replacing transactions would overdraft", "sender", from, "balance", pool.currentState.GetBalance(from), "required", sum)
 			return ErrOverdraft
@@ -738,15 +855,71 @@ func (pool *TxPool) checkTxState(from common.Address, tx *types.Transaction) err
 
 	// If the tx allow list is enabled, return an error if the from address is not allow listed.
 	if pool.rules.Load().IsPrecompileEnabled(txallowlist.ContractAddress) {
-		txAllowListRole := txallowlist.GetTxAllowListStatus(pool.currentState, from)
+		txAllowListRole := txallowlist.GetTxAllowListStatus(pool.currentState, from, pool.currentHead.Time)
 		if !txAllowListRole.IsEnabled() {
 			return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressNotAllowListed, from)
 		}
 	}
 
+	// If the freeze list is enabled, return an error if the from address is frozen.
+	if pool.rules.Load().IsPrecompileEnabled(freezelist.ContractAddress) {
+		if freezelist.IsAccountFrozen(pool.currentState, from) {
+			return fmt.Errorf("%w: %s", vmerrs.ErrSenderAddressFrozen, from)
+		}
+	}
+
+	// If the contract call allow list is enabled, return an error if the from address may not call the function of the recipient contract,
+	// or may not deploy a contract.
+	if pool.rules.Load().IsPrecompileEnabled(callallowlist.ContractAddress) {
+		if to := tx.To(); to == nil {
+			if !callallowlist.IsCreatePermitted(pool.currentState, from, pool.currentHead.Time) {
+				return fmt.Errorf("%w: %s", vmerrs.ErrContractCreationNotAllowed, from)
+			}
+		} else if pool.currentState.GetCodeSize(*to) > 0 && !callallowlist.IsCallPermitted(pool.currentState, from, *to, tx.Data(), pool.currentHead.Time) {
+			return fmt.Errorf("%w: %s %#x", vmerrs.ErrContractCallNotAllowed, to, callallowlist.Selector(tx.Data()))
+		}
+	}
+
 	return nil
 }
 
+// gasCost returns the cost of the gas of [tx], the part of its cost a sponsor may pay.
+func gasCost(tx *types.Transaction) *big.Int {
+	return new(big.Int).Sub(tx.Cost(), tx.Value())
+}
+
+// sponsoredFilter returns a filter reporting whether the gas of a transaction sent by [addr] is paid
+// by a sponsor according to the gas sponsor state at the current head, or nil if the gas sponsor is
+// not enabled. The filter must be called on the transactions in nonce order, as the gas it reports
+// as sponsored counts against the remaining daily budget and balance of the sponsor for the following
+// transactions, starting with the gas sponsored for [preceding].
+func (pool *TxPool) sponsoredFilter(addr common.Address, preceding types.Transactions) func(*types.Transaction) bool {
+	if !pool.rules.Load().IsPrecompileEnabled(gassponsor.ContractAddress) {
+		return nil
+	}
+	sponsoredCosts := make(map[common.Address]*big.Int)
+	filter := func(tx *types.Transaction) bool {
+		to := tx.To()
+		if to == nil {
+			return false
+		}
+		sponsor := gassponsor.GetTargetSponsor(pool.currentState, *to)
+		sponsoredCost := gasCost(tx)
+		if preceding, ok := sponsoredCosts[sponsor]; ok {
+			sponsoredCost.Add(sponsoredCost, preceding)
+		}
+		if _, ok := gassponsor.SponsorOf(pool.currentState, to, addr, sponsoredCost, pool.currentHead.Time); !ok {
+			return false
+		}
+		sponsoredCosts[sponsor] = sponsoredCost
+		return true
+	}
+	for _, tx := range preceding {
+		filter(tx)
+	}
+	return filter
+}
+
 // validateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
@@ -1083,7 +1256,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 // This method is used to add transactions from the RPC API and performs synchronous pool
 // reorganization and event propagation.
 func (pool *TxPool) AddLocals(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, !pool.config.NoLocals, true)
+	return pool.addTxs(txs, !pool.config.NoLocals, true, false)
 }
 
 // AddLocal enqueues a single local transaction into the pool if it is valid. This is
@@ -1099,12 +1272,12 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 // This method is used to add transactions from the p2p network and does not wait for pool
 // reorganization and internal event propagation.
 func (pool *TxPool) AddRemotes(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, false)
+	return pool.addTxs(txs, false, false, true)
 }
 
 // AddRemotesSync is like AddRemotes, but waits for pool reorganization. Tests use this method.
 func (pool *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, true)
+	return pool.addTxs(txs, false, true, true)
 }
 
 // This is like AddRemotes with a single transaction, but waits for pool reorganization. Tests use this method.
@@ -1122,8 +1295,9 @@ func (pool *TxPool) AddRemote(tx *types.Transaction) error {
 	return errs[0]
 }
 
-// addTxs attempts to queue a batch of transactions if they are valid.
-func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
+// addTxs attempts to queue a batch of transactions if they are valid. If [limit] is
+// true, the admission rate limits of the remote senders apply.
+func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync, limit bool) []error {
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
 		errs = make([]error, len(txs))
@@ -1154,6 +1328,9 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
+	if limit {
+		news = pool.rateLimitLocked(news, errs)
+	}
 	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
 	pool.mu.Unlock()
 
@@ -1410,6 +1587,7 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
 		pool.reset(reset.oldHead, reset.newHead)
+		pool.pruneBundles()
 
 		// Nonces were reset, discard any events that became stale
 		for addr := range events {
@@ -1560,7 +1738,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	// when we reset txPool we should explicitly check if fee struct for min base fee has changed
 	// so that we can correctly drop txs with < minBaseFee from tx pool.
 	if pool.chainconfig.IsPrecompileEnabled(feemanager.ContractAddress, newHead.Time) {
-		feeConfig, _, err := pool.chain.GetFeeConfigAt(newHead)
+		feeConfig, err := dummy.GetFeeConfigAt(pool.chain, newHead, uint64(time.Now().Unix()))
 		if err != nil {
 			log.Error("Failed to get fee config state", "err", err, "root", newHead.Root)
 			return
@@ -1607,7 +1785,12 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
-		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas.Load())
+		// The gas sponsored for the pending transactions counts against the budget of their sponsors
+		var pending types.Transactions
+		if pendingList := pool.pending[addr]; pendingList != nil && pool.rules.Load().IsPrecompileEnabled(gassponsor.ContractAddress) {
+			pending = pendingList.Flatten()
+		}
+		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas.Load(), pool.sponsoredFilter(addr, pending))
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
@@ -1807,7 +1990,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
-		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas.Load())
+		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas.Load(), pool.sponsoredFilter(addr, nil))
 		for _, tx := range drops {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
@@ -1894,11 +2077,12 @@ func (pool *TxPool) updateBaseFee() {
 
 // assumes lock is already held
 func (pool *TxPool) updateBaseFeeAt(head *types.Header) error {
-	feeConfig, _, err := pool.chain.GetFeeConfigAt(head)
+	timestamp := uint64(time.Now().Unix())
+	feeConfig, err := dummy.GetFeeConfigAt(pool.chain, head, timestamp)
 	if err != nil {
 		return err
 	}
-	_, baseFeeEstimate, err := dummy.EstimateNextBaseFee(pool.chainconfig, feeConfig, head, uint64(time.Now().Unix()))
+	_, baseFeeEstimate, err := dummy.EstimateNextBaseFee(pool.chainconfig, feeConfig, head, timestamp)
 	if err != nil {
 		return err
 	}
@@ -46,6 +46,8 @@ import (
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/gassponsor"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -89,6 +91,10 @@ type testBlockChain struct {
 	gasLimit      atomic.Uint64
 	chainHeadFeed *event.Feed
 	lock          sync.Mutex
+
+	// pendingFeeConfig is scheduled to take effect at activationTimestamp, if non-zero
+	pendingFeeConfig    commontype.FeeConfig
+	activationTimestamp uint64
 }
 
 func newTestBlockChain(gasLimit uint64, statedb *state.StateDB, chainHeadFeed *event.Feed) *testBlockChain {
@@ -138,6 +144,13 @@ func (bc *testBlockChain) GetFeeConfigAt(parent *types.Header) (commontype.FeeCo
 	return testFeeConfig, common.Big0, nil
 }
 
+func (bc *testBlockChain) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return bc.pendingFeeConfig, bc.activationTimestamp, nil
+}
+
 func (bc *testBlockChain) SenderCacher() *core.TxSenderCacher {
 	// Zero threads avoids starting goroutines.
 	return core.NewTxSenderCacher(0)
@@ -2480,6 +2493,147 @@ func TestStatusCheck(t *testing.T) {
 }
 
 // Test the transaction slots consumption is computed correctly
+// Tests that the pool prices transactions with a scheduled fee config once its
+// activation time has passed.
+func TestScheduledFeeConfig(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Stop()
+
+	blockchain := pool.chain.(*testBlockChain)
+	pendingFeeConfig := testFeeConfig
+	pendingFeeConfig.MinBaseFee = new(big.Int).Mul(testFeeConfig.MinBaseFee, common.Big2)
+
+	tests := map[string]struct {
+		activationTimestamp uint64
+		expected            *big.Int
+	}{
+		"not activated": {
+			activationTimestamp: uint64(time.Now().Add(time.Hour).Unix()),
+			expected:            testFeeConfig.MinBaseFee,
+		},
+		"activated": {
+			activationTimestamp: uint64(time.Now().Add(-time.Hour).Unix()),
+			expected:            pendingFeeConfig.MinBaseFee,
+		},
+	}
+	for name, test := range tests {
+		blockchain.lock.Lock()
+		blockchain.pendingFeeConfig = pendingFeeConfig
+		blockchain.activationTimestamp = test.activationTimestamp
+		blockchain.lock.Unlock()
+
+		pool.mu.Lock()
+		err := pool.updateBaseFeeAt(pool.currentHead)
+		baseFee := pool.priced.urgent.baseFee
+		pool.mu.Unlock()
+		if err != nil {
+			t.Fatalf("%s: failed to update base fee: %v", name, err)
+		}
+		if baseFee.Cmp(test.expected) != 0 {
+			t.Fatalf("%s: base fee mismatch: have %d, want %d", name, baseFee, test.expected)
+		}
+	}
+}
+
+// Tests that the gas sponsored for the pending transactions of a sender counts
+// against the daily budget of their sponsor.
+func TestSponsoredTransactionsBudget(t *testing.T) {
+	t.Parallel()
+
+	sponsor := common.HexToAddress("0x0100")
+	target := common.HexToAddress("0x0123")
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		gassponsor.ConfigKey: gassponsor.NewConfig(utils.NewUint64(0), nil, []common.Address{sponsor}, nil),
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Stop()
+
+	// The sender has no balance, and the sponsor of [target] pays for two transactions
+	pool.mu.Lock()
+	pool.currentState.AddBalance(sponsor, big.NewInt(1000000000))
+	gassponsor.SetGasSponsorAllowListStatus(pool.currentState, sponsor, allowlist.EnabledRole)
+	gassponsor.SetDailyBudget(pool.currentState, sponsor, big.NewInt(2*int64(params.TxGas)))
+	gassponsor.SetTargetSponsor(pool.currentState, target, sponsor)
+	pool.mu.Unlock()
+
+	sponsoredTx := func(nonce uint64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, target, common.Big0, params.TxGas, common.Big1, nil), types.HomesteadSigner{}, key)
+		return tx
+	}
+	errs := pool.AddRemotesSync([]*types.Transaction{sponsoredTx(0), sponsoredTx(1), sponsoredTx(2)})
+	if errs[0] != nil || errs[1] != nil || !errors.Is(errs[2], core.ErrInsufficientFunds) {
+		t.Fatalf("sponsored transaction errors mismatch: have %v", errs)
+	}
+	// The gas of a replaced transaction is not counted against the budget twice
+	replacement, _ := types.SignTx(types.NewTransaction(1, target, common.Big0, params.TxGas, common.Big2, nil), types.HomesteadSigner{}, key)
+	if errs := pool.AddRemotesSync([]*types.Transaction{replacement}); !errors.Is(errs[0], core.ErrInsufficientFunds) {
+		t.Fatalf("replacement over budget error mismatch: have %v", errs[0])
+	}
+	pool.mu.Lock()
+	gassponsor.SetDailyBudget(pool.currentState, sponsor, big.NewInt(3*int64(params.TxGas)))
+	pool.mu.Unlock()
+	if errs := pool.AddRemotesSync([]*types.Transaction{replacement}); errs[0] != nil {
+		t.Fatalf("replacement within budget rejected: %v", errs[0])
+	}
+
+	// Once the budget shrinks, the pending transactions it no longer covers are dropped
+	pool.mu.Lock()
+	gassponsor.SetDailyBudget(pool.currentState, sponsor, big.NewInt(int64(params.TxGas)))
+	pool.mu.Unlock()
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d pending, %d queued, want 1 pending, 0 queued", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the values of the sponsored transactions of a sender may not
+// overdraft its balance together, although the sender does not pay their gas.
+func TestSponsoredTransactionsOverdraft(t *testing.T) {
+	t.Parallel()
+
+	sponsor := common.HexToAddress("0x0100")
+	target := common.HexToAddress("0x0123")
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		gassponsor.ConfigKey: gassponsor.NewConfig(utils.NewUint64(0), nil, []common.Address{sponsor}, nil),
+	}
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Stop()
+
+	pool.mu.Lock()
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000))
+	pool.currentState.AddBalance(sponsor, big.NewInt(1000000000))
+	gassponsor.SetGasSponsorAllowListStatus(pool.currentState, sponsor, allowlist.EnabledRole)
+	gassponsor.SetDailyBudget(pool.currentState, sponsor, big.NewInt(10*int64(params.TxGas)))
+	gassponsor.SetTargetSponsor(pool.currentState, target, sponsor)
+	pool.mu.Unlock()
+
+	sponsoredTx := func(nonce uint64, value int64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, target, big.NewInt(value), params.TxGas, common.Big1, nil), types.HomesteadSigner{}, key)
+		return tx
+	}
+	// Each value fits the balance, but not both of them
+	if errs := pool.AddRemotesSync([]*types.Transaction{sponsoredTx(0, 600)}); errs[0] != nil {
+		t.Fatalf("sponsored transaction rejected: %v", errs[0])
+	}
+	if errs := pool.AddRemotesSync([]*types.Transaction{sponsoredTx(1, 600)}); !errors.Is(errs[0], ErrOverdraft) {
+		t.Fatalf("overdrafting sponsored transaction error mismatch: have %v, want %v", errs[0], ErrOverdraft)
+	}
+	// The gas of the pending sponsored transaction is not charged to the sender
+	if errs := pool.AddRemotesSync([]*types.Transaction{sponsoredTx(1, 400)}); errs[0] != nil {
+		t.Fatalf("sponsored transaction within balance rejected: %v", errs[0])
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 func TestSlotCount(t *testing.T) {
 	t.Parallel()
 
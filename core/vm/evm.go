@@ -36,6 +36,7 @@ import (
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/predicate"
@@ -102,6 +103,11 @@ func (evm *EVM) precompile(addr common.Address) (contract.StatefulPrecompiledCon
 	return nil, false
 }
 
+// isFrozen returns true if the freeze list is enabled and [addr] has been frozen.
+func (evm *EVM) isFrozen(addr common.Address) bool {
+	return evm.chainRules.IsPrecompileEnabled(freezelist.ContractAddress) && freezelist.IsAccountFrozen(evm.StateDB, addr)
+}
+
 // BlockContext provides the EVM with auxiliary information. Once provided
 // it shouldn't be modified.
 type BlockContext struct {
@@ -260,6 +266,10 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	if value.Sign() != 0 && !evm.Context.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, gas, vmerrs.ErrInsufficientBalance
 	}
+	// Fail if a frozen account is trying to transfer value
+	if value.Sign() != 0 && evm.isFrozen(caller.Address()) {
+		return nil, gas, vmerrs.ErrFrozenValueTransfer
+	}
 	snapshot := evm.StateDB.Snapshot()
 	p, isPrecompile := evm.precompile(addr)
 	debug := evm.Config.Tracer != nil
@@ -509,6 +519,10 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if !evm.Context.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, common.Address{}, gas, vmerrs.ErrInsufficientBalance
 	}
+	// Fail if a frozen account is trying to endow the new contract with value
+	if value.Sign() != 0 && evm.isFrozen(caller.Address()) {
+		return nil, common.Address{}, gas, vmerrs.ErrFrozenValueTransfer
+	}
 	// If there is any collision with a prohibited address, return an error instead
 	// of allowing the contract to be created.
 	if IsProhibited(address) {
@@ -531,7 +545,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	// If the allow list is enabled, check that [evm.TxContext.Origin] has permission to deploy a contract.
 	if evm.chainRules.IsPrecompileEnabled(deployerallowlist.ContractAddress) {
-		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(evm.StateDB, evm.TxContext.Origin)
+		allowListRole := deployerallowlist.GetContractDeployerAllowListStatus(evm.StateDB, evm.TxContext.Origin, evm.Context.Time)
 		if !allowListRole.IsEnabled() {
 			return nil, common.Address{}, 0, fmt.Errorf("tx.origin %s is not authorized to deploy a contract", evm.TxContext.Origin)
 		}
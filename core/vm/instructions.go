@@ -825,6 +825,10 @@ func opSelfdestruct(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext
 	}
 	beneficiary := scope.Stack.pop()
 	balance := interpreter.evm.StateDB.GetBalance(scope.Contract.Address())
+	// Fail if a frozen contract is trying to transfer its balance
+	if balance.Sign() != 0 && interpreter.evm.isFrozen(scope.Contract.Address()) {
+		return nil, vmerrs.ErrFrozenValueTransfer
+	}
 	interpreter.evm.StateDB.AddBalance(beneficiary.Bytes20(), balance)
 	interpreter.evm.StateDB.Suicide(scope.Contract.Address())
 	if tracer := interpreter.evm.Config.Tracer; tracer != nil {
@@ -38,6 +38,9 @@ import (
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
@@ -631,6 +634,49 @@ func TestOpTstore(t *testing.T) {
 	}
 }
 
+func TestOpSelfdestructFrozen(t *testing.T) {
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		freezelist.ConfigKey: freezelist.NewConfig(utils.NewUint64(0), nil, nil, nil, nil),
+	}
+	var (
+		statedb, _     = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		env            = NewEVM(BlockContext{BlockNumber: big.NewInt(0)}, TxContext{}, statedb, &config, Config{})
+		evmInterpreter = NewEVMInterpreter(env)
+		frozen         = common.Address{1}
+		beneficiary    = common.Address{2}
+	)
+	env.interpreter = evmInterpreter
+	statedb.AddBalance(frozen, big.NewInt(100))
+	freezelist.SetAccountFrozen(statedb, frozen, true)
+
+	selfdestruct := func(addr common.Address) error {
+		stack := newstack()
+		stack.push(new(uint256.Int).SetBytes(beneficiary.Bytes()))
+		contract := NewContract(contractRef{common.Address{}}, AccountRef(addr), new(big.Int), 0)
+		pc := uint64(0)
+		_, err := opSelfdestruct(&pc, evmInterpreter, &ScopeContext{NewMemory(), stack, contract})
+		return err
+	}
+
+	// A frozen contract cannot move its balance to the beneficiary
+	if err := selfdestruct(frozen); err != vmerrs.ErrFrozenValueTransfer {
+		t.Fatalf("frozen selfdestruct error mismatch: have %v, want %v", err, vmerrs.ErrFrozenValueTransfer)
+	}
+	if balance := statedb.GetBalance(beneficiary); balance.Sign() != 0 {
+		t.Fatalf("beneficiary received balance of frozen contract: %d", balance)
+	}
+
+	// Once unfrozen, the contract can self destruct
+	freezelist.SetAccountFrozen(statedb, frozen, false)
+	if err := selfdestruct(frozen); err != errStopToken {
+		t.Fatalf("selfdestruct error mismatch: have %v, want %v", err, errStopToken)
+	}
+	if balance := statedb.GetBalance(beneficiary); balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("beneficiary balance mismatch: have %d, want 100", balance)
+	}
+}
+
 func BenchmarkOpKeccak256(bench *testing.B) {
 	var (
 		env            = NewEVM(BlockContext{}, TxContext{}, nil, params.TestChainConfig, Config{})
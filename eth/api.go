@@ -39,6 +39,7 @@ import (
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
 	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/internal/ethapi"
 	"github.com/ava-labs/subnet-evm/rpc"
@@ -69,6 +70,40 @@ func (api *EthereumAPI) Coinbase() (common.Address, error) {
 	return api.Etherbase()
 }
 
+// SendBundleArgs represents the arguments to submit a bundle of transactions.
+type SendBundleArgs struct {
+	// Txs are the signed, RLP encoded transactions of the bundle in execution order.
+	Txs []hexutil.Bytes `json:"txs"`
+	// MinTimestamp and MaxTimestamp optionally bound the timestamps of the blocks the bundle may be included in.
+	MinTimestamp *hexutil.Uint64 `json:"minTimestamp,omitempty"`
+	MaxTimestamp *hexutil.Uint64 `json:"maxTimestamp,omitempty"`
+}
+
+// SendBundle adds a bundle of signed transactions to the transaction pool. The transactions of the
+// bundle are either all included in the same block, contiguously and in order, and all execute
+// successfully, or none of them is included. Bundles are not gossiped, so they are only included
+// in blocks built by this node. It returns the hash of the bundle.
+func (api *EthereumAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	bundle := &txpool.Bundle{Txs: make(types.Transactions, 0, len(args.Txs))}
+	for i, encodedTx := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(encodedTx); err != nil {
+			return common.Hash{}, fmt.Errorf("failed to decode bundle transaction %d: %w", i, err)
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	if err := api.e.TxPool().AddBundle(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
 // AdminAPI is the collection of Ethereum full node related APIs for node
 // administration.
 type AdminAPI struct {
@@ -67,6 +67,12 @@ func (b *EthAPIBackend) ChainConfig() *params.ChainConfig {
 	return b.eth.blockchain.Config()
 }
 
+// GenesisBalance returns the total balance allocated in the genesis of the chain, including
+// the airdrop, or nil if it is unknown.
+func (b *EthAPIBackend) GenesisBalance() *big.Int {
+	return b.eth.genesisBalance
+}
+
 func (b *EthAPIBackend) GetVMConfig() *vm.Config {
 	return b.eth.blockchain.GetVMConfig()
 }
@@ -103,6 +109,10 @@ func (b *EthAPIBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConf
 	return b.eth.blockchain.GetFeeConfigAt(parent)
 }
 
+func (b *EthAPIBackend) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	return b.eth.blockchain.GetPendingFeeConfigAt(parent)
+}
+
 func (b *EthAPIBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -30,6 +30,7 @@ package eth
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -91,6 +92,8 @@ type Ethereum struct {
 
 	APIBackend *EthAPIBackend
 
+	genesisBalance *big.Int // Total balance allocated in the genesis, nil if unknown
+
 	miner     *miner.Miner
 	etherbase common.Address
 
@@ -216,6 +219,13 @@ func New(
 		return nil, err
 	}
 
+	if config.Genesis != nil {
+		eth.genesisBalance, err = config.Genesis.TotalBalance()
+		if err != nil {
+			log.Warn("Failed to compute the genesis balance", "err", err)
+		}
+	}
+
 	// Free airdrop data to save memory usage
 	defer func() {
 		config.Genesis.AirdropData = nil
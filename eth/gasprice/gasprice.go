@@ -32,6 +32,7 @@ import (
 	"math/big"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/subnet-evm/commontype"
@@ -103,6 +104,7 @@ type OracleBackend interface {
 	MinRequiredTip(ctx context.Context, header *types.Header) (*big.Int, error)
 	LastAcceptedBlock() *types.Block
 	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error)
 }
 
 // Oracle recommends gas prices based on the content of recent
@@ -191,7 +193,7 @@ func NewOracle(backend OracleBackend, config Config) (*Oracle, error) {
 			lastHead = ev.Block.Hash()
 		}
 	}()
-	feeConfig, _, err := backend.GetFeeConfigAt(backend.LastAcceptedBlock().Header())
+	feeConfig, err := dummy.GetFeeConfigAt(backend, backend.LastAcceptedBlock().Header(), uint64(time.Now().Unix()))
 	var minBaseFee *big.Int
 	if err != nil {
 		// resort back to chain config
@@ -256,7 +258,7 @@ func (oracle *Oracle) estimateNextBaseFee(ctx context.Context) (*big.Int, error)
 	if err != nil {
 		return nil, err
 	}
-	feeConfig, _, err := oracle.backend.GetFeeConfigAt(header)
+	feeConfig, err := dummy.GetFeeConfigAt(oracle.backend, header, oracle.clock.Unix())
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +321,11 @@ func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.In
 		feeConfig        commontype.FeeConfig
 	)
 	if oracle.backend.ChainConfig().IsPrecompileEnabled(feemanager.ContractAddress, head.Time) {
-		feeConfig, feeLastChangedAt, err = oracle.backend.GetFeeConfigAt(head)
+		_, feeLastChangedAt, err = oracle.backend.GetFeeConfigAt(head)
+		if err != nil {
+			return nil, nil, err
+		}
+		feeConfig, err = dummy.GetFeeConfigAt(oracle.backend, head, oracle.clock.Unix())
 		if err != nil {
 			return nil, nil, err
 		}
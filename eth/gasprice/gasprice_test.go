@@ -94,6 +94,10 @@ func (b *testBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig
 	return b.chain.GetFeeConfigAt(parent)
 }
 
+func (b *testBackend) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	return b.chain.GetPendingFeeConfigAt(parent)
+}
+
 func (b *testBackend) teardown() {
 	b.chain.Stop()
 }
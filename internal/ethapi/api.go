@@ -41,12 +41,16 @@ import (
 	"github.com/ava-labs/subnet-evm/accounts/scwallet"
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/eth/tracers/logger"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/davecgh/go-spew/spew"
@@ -650,7 +654,17 @@ func (s *BlockChainAPI) FeeConfig(ctx context.Context, blockNrOrHash *rpc.BlockN
 		}
 	}
 
-	feeConfig, lastChangedAt, err := s.b.GetFeeConfigAt(header)
+	_, lastChangedAt, err := s.b.GetFeeConfigAt(header)
+	if err != nil {
+		return nil, err
+	}
+	// Report the fee config in effect for the child of [header], including a scheduled
+	// fee config that activated by the time of the child, or now if there is no child yet.
+	timestamp := uint64(time.Now().Unix())
+	if child, err := s.b.HeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()+1)); err == nil && child != nil {
+		timestamp = child.Time
+	}
+	feeConfig, err := dummy.GetFeeConfigAt(s.b, header, timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -674,6 +688,96 @@ func (s *BlockChainAPI) GetBalance(ctx context.Context, address common.Address,
 	return (*hexutil.Big)(state.GetBalance(address)), state.Error()
 }
 
+// NativeSupplyResult breaks down the native coin supply at a given block.
+type NativeSupplyResult struct {
+	GenesisAlloc *hexutil.Big `json:"genesisAlloc,omitempty"` // total balance allocated in the genesis, including the airdrop, omitted if unknown
+	TotalMinted  *hexutil.Big `json:"totalMinted"`            // total minted by the native minter precompile
+	TotalBurned  *hexutil.Big `json:"totalBurned"`            // total burned by the native minter precompile
+	FeesBurned   *hexutil.Big `json:"feesBurned"`             // fees (and any other value) sent to the blackhole address
+	TotalSupply  *hexutil.Big `json:"totalSupply,omitempty"`  // genesisAlloc + totalMinted - totalBurned - feesBurned, omitted if incomplete
+	Complete     bool         `json:"complete"`               // whether genesisAlloc, totalMinted and totalBurned account for all of the supply
+}
+
+// GetNativeSupply returns the native coin supply in the state of the given block number.
+// Fees that are not paid to a fee recipient are sent to the blackhole address by the
+// dynamic fee logic and are counted as burned. Mints and burns through the native minter
+// precompile are only tracked from the activation of its supply counters at the DUpgrade
+// onwards, so the total supply is omitted on chains that enabled the native minter before.
+func (s *BlockChainAPI) GetNativeSupply(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*NativeSupplyResult, error) {
+	state, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	genesisBalance := s.b.GenesisBalance()
+	result := &NativeSupplyResult{
+		GenesisAlloc: (*hexutil.Big)(genesisBalance),
+		TotalMinted:  (*hexutil.Big)(nativeminter.GetTotalMinted(state)),
+		TotalBurned:  (*hexutil.Big)(nativeminter.GetTotalBurned(state)),
+		FeesBurned:   (*hexutil.Big)(state.GetBalance(constants.BlackholeAddr)),
+		Complete:     genesisBalance != nil && supplyTracked(s.b.ChainConfig()),
+	}
+	if result.Complete {
+		totalSupply := new(big.Int).Add(result.GenesisAlloc.ToInt(), result.TotalMinted.ToInt())
+		totalSupply.Sub(totalSupply, result.TotalBurned.ToInt())
+		totalSupply.Sub(totalSupply, result.FeesBurned.ToInt())
+		result.TotalSupply = (*hexutil.Big)(totalSupply)
+	}
+	return result, state.Error()
+}
+
+// supplyTracked returns true if the supply counters of the native minter precompile account for
+// all of its mints and burns, that is if it was not enabled before the DUpgrade activated them.
+func supplyTracked(config *params.ChainConfig) bool {
+	if config.DUpgradeTimestamp != nil && *config.DUpgradeTimestamp == 0 {
+		return true
+	}
+	to := uint64(math.MaxUint64)
+	if config.DUpgradeTimestamp != nil {
+		to = *config.DUpgradeTimestamp - 1
+	}
+	for _, cfg := range config.GetActivatingPrecompileConfigs(nativeminter.ContractAddress, nil, to, config.PrecompileUpgrades) {
+		if !cfg.IsDisabled() {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowListRoleResult is the role of an address in the allow list of a precompile.
+type AllowListRoleResult struct {
+	Address common.Address `json:"address"`
+	Role    string         `json:"role"`
+	Expiry  hexutil.Uint64 `json:"expiry,omitempty"` // timestamp at which the role expires, omitted if it does not expire
+}
+
+// GetAllowListRoles returns the role table of the allow list of the precompile at [precompileAddress]
+// in the state of the given block number. Members are enumerable from the DUpgrade on: the configured
+// members are listed when it activates, but roles granted through the role setters before it are only
+// listed once they are set again. Members whose role has expired by the block timestamp are omitted.
+func (s *BlockChainAPI) GetAllowListRoles(ctx context.Context, precompileAddress common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]AllowListRoleResult, error) {
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	if !s.b.ChainConfig().IsPrecompileEnabled(precompileAddress, header.Time) {
+		return nil, fmt.Errorf("precompile %s is not enabled at block %d", precompileAddress, header.Number)
+	}
+	members := allowlist.GetAllowListMembers(state, precompileAddress)
+	roles := make([]AllowListRoleResult, 0, len(members))
+	for _, member := range members {
+		role := allowlist.GetAllowListStatus(state, precompileAddress, member, header.Time)
+		if role.IsNoRole() {
+			continue
+		}
+		roles = append(roles, AllowListRoleResult{
+			Address: member,
+			Role:    role.String(),
+			Expiry:  hexutil.Uint64(allowlist.GetAllowListExpiry(state, precompileAddress, member)),
+		})
+	}
+	return roles, state.Error()
+}
+
 // Result structs for GetProof
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
@@ -43,6 +43,7 @@ import (
 	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/consensus"
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/bloombits"
 	"github.com/ava-labs/subnet-evm/core/rawdb"
@@ -51,7 +52,12 @@ import (
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/ethdb"
 	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/deployerallowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/txallowlist"
 	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -192,15 +198,17 @@ func allTransactionTypes(addr common.Address, config *params.ChainConfig) []type
 }
 
 type testBackend struct {
-	db    ethdb.Database
-	chain *core.BlockChain
+	db      ethdb.Database
+	chain   *core.BlockChain
+	genesis *core.Genesis
 }
 
 func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
 	var (
 		engine  = dummy.NewCoinbaseFaker()
 		backend = &testBackend{
-			db: rawdb.NewMemoryDatabase(),
+			db:      rawdb.NewMemoryDatabase(),
+			genesis: gspec,
 		}
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit: 256,
@@ -223,6 +231,10 @@ func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i i
 }
 
 func (b testBackend) SyncProgress() ethereum.SyncProgress { return ethereum.SyncProgress{} }
+func (b testBackend) GenesisBalance() *big.Int {
+	balance, _ := b.genesis.TotalBalance()
+	return balance
+}
 func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(0), nil
 }
@@ -319,6 +331,9 @@ func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) even
 func (b testBackend) GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error) {
 	panic("implement me")
 }
+func (b testBackend) GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error) {
+	panic("implement me")
+}
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
@@ -448,6 +463,177 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestGetNativeSupply(t *testing.T) {
+	t.Parallel()
+	// Initialize test accounts
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		genBlocks = 10
+		signer    = types.HomesteadSigner{}
+	)
+	backend := newTestBackend(t, genBlocks, genesis, func(i int, b *core.BlockGen) {
+		// Transfer from account[0] to account[1] paying fees to the blackhole address
+		b.SetCoinbase(constants.BlackholeAddr)
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: params.TxGas, GasPrice: b.BaseFee(), Data: nil}), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	api := NewBlockChainAPI(backend)
+
+	for _, blockNumber := range []rpc.BlockNumber{0, rpc.LatestBlockNumber} {
+		result, err := api.GetNativeSupply(context.Background(), rpc.BlockNumberOrHashWithNumber(blockNumber))
+		if err != nil {
+			t.Fatalf("block %d: want no error, have %v", blockNumber, err)
+		}
+		state, _, err := backend.StateAndHeaderByNumber(context.Background(), blockNumber)
+		if err != nil {
+			t.Fatalf("block %d: failed to get state: %v", blockNumber, err)
+		}
+		if have, want := result.GenesisAlloc.ToInt(), big.NewInt(2*params.Ether); have.Cmp(want) != 0 {
+			t.Errorf("block %d: genesis alloc mismatch, have %v, want %v", blockNumber, have, want)
+		}
+		if have, want := result.FeesBurned.ToInt(), state.GetBalance(constants.BlackholeAddr); have.Cmp(want) != 0 {
+			t.Errorf("block %d: fees burned mismatch, have %v, want %v", blockNumber, have, want)
+		}
+		if !result.Complete {
+			t.Fatalf("block %d: supply marked incomplete", blockNumber)
+		}
+		circulating := new(big.Int).Add(state.GetBalance(accounts[0].addr), state.GetBalance(accounts[1].addr))
+		if have := result.TotalSupply.ToInt(); have.Cmp(circulating) != 0 {
+			t.Errorf("block %d: total supply mismatch, have %v, want %v", blockNumber, have, circulating)
+		}
+	}
+}
+
+func TestSupplyTracked(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(dUpgrade *uint64, minterGenesis *uint64, minterUpgrades ...*uint64) *params.ChainConfig {
+		config := *params.TestChainConfig
+		config.DUpgradeTimestamp = dUpgrade
+		config.GenesisPrecompiles = params.Precompiles{}
+		if minterGenesis != nil {
+			config.GenesisPrecompiles[nativeminter.ConfigKey] = nativeminter.NewConfig(minterGenesis, nil, nil, nil, nil)
+		}
+		config.PrecompileUpgrades = nil
+		for _, timestamp := range minterUpgrades {
+			config.PrecompileUpgrades = append(config.PrecompileUpgrades, params.PrecompileUpgrade{
+				Config: nativeminter.NewConfig(timestamp, nil, nil, nil, nil),
+			})
+		}
+		return &config
+	}
+	tests := map[string]struct {
+		config *params.ChainConfig
+		want   bool
+	}{
+		"DUpgrade at genesis": {
+			config: newConfig(utils.NewUint64(0), utils.NewUint64(0)),
+			want:   true,
+		},
+		"minter never enabled": {
+			config: newConfig(nil, nil),
+			want:   true,
+		},
+		"minter enabled at DUpgrade": {
+			config: newConfig(utils.NewUint64(10), nil, utils.NewUint64(10)),
+			want:   true,
+		},
+		"minter enabled in genesis before DUpgrade": {
+			config: newConfig(utils.NewUint64(10), utils.NewUint64(0)),
+			want:   false,
+		},
+		"minter enabled by upgrade before DUpgrade": {
+			config: newConfig(utils.NewUint64(10), nil, utils.NewUint64(5)),
+			want:   false,
+		},
+		"minter enabled without DUpgrade": {
+			config: newConfig(nil, nil, utils.NewUint64(5)),
+			want:   false,
+		},
+	}
+	for name, test := range tests {
+		if have := supplyTracked(test.config); have != test.want {
+			t.Errorf("%s: have %v, want %v", name, have, test.want)
+		}
+	}
+}
+
+func TestGetAllowListRoles(t *testing.T) {
+	t.Parallel()
+	// Initialize test accounts
+	var (
+		accounts = newAccounts(3)
+		config   = *params.TestChainConfig
+		genesis  = &core.Genesis{
+			Config: &config,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		genBlocks = 1
+		signer    = types.HomesteadSigner{}
+	)
+	config.GenesisPrecompiles = params.Precompiles{
+		deployerallowlist.ConfigKey: deployerallowlist.NewConfig(
+			utils.NewUint64(0),
+			[]common.Address{accounts[0].addr},
+			[]common.Address{accounts[1].addr, accounts[2].addr},
+			nil,
+		),
+	}
+	backend := newTestBackend(t, genBlocks, genesis, func(i int, b *core.BlockGen) {
+		// Remove the role of accounts[1]
+		data, err := allowlist.PackModifyAllowList(accounts[1].addr, allowlist.NoRole)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &deployerallowlist.ContractAddress, Gas: 200_000, GasPrice: b.BaseFee(), Data: data}), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	api := NewBlockChainAPI(backend)
+
+	var tests = []struct {
+		blockNumber rpc.BlockNumber
+		want        []AllowListRoleResult
+	}{
+		{
+			blockNumber: 0,
+			want: []AllowListRoleResult{
+				{Address: accounts[1].addr, Role: allowlist.EnabledRole.String()},
+				{Address: accounts[2].addr, Role: allowlist.EnabledRole.String()},
+				{Address: accounts[0].addr, Role: allowlist.AdminRole.String()},
+			},
+		},
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			want: []AllowListRoleResult{
+				{Address: accounts[0].addr, Role: allowlist.AdminRole.String()},
+				{Address: accounts[2].addr, Role: allowlist.EnabledRole.String()},
+			},
+		},
+	}
+	for _, tc := range tests {
+		result, err := api.GetAllowListRoles(context.Background(), deployerallowlist.ContractAddress, rpc.BlockNumberOrHashWithNumber(tc.blockNumber))
+		if err != nil {
+			t.Fatalf("block %d: want no error, have %v", tc.blockNumber, err)
+		}
+		if !reflect.DeepEqual(result, tc.want) {
+			t.Errorf("block %d: role table mismatch, have %v, want %v", tc.blockNumber, result, tc.want)
+		}
+	}
+
+	if _, err := api.GetAllowListRoles(context.Background(), txallowlist.ContractAddress, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)); err == nil {
+		t.Errorf("want error for precompile that is not enabled")
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -80,6 +80,7 @@ type Backend interface {
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 	SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription
 	GetFeeConfigAt(parent *types.Header) (commontype.FeeConfig, *big.Int, error)
+	GetPendingFeeConfigAt(parent *types.Header) (commontype.FeeConfig, uint64, error)
 	BadBlocks() ([]*types.Block, []*core.BadBlockReason)
 
 	// Transaction pool API
@@ -94,6 +95,7 @@ type Backend interface {
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
+	GenesisBalance() *big.Int
 	Engine() consensus.Engine
 	LastAcceptedBlock() *types.Block
 
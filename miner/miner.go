@@ -47,7 +47,8 @@ type Backend interface {
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase common.Address `toml:",omitempty"` // Public address for block mining rewards
+	Etherbase        common.Address   `toml:",omitempty"` // Public address for block mining rewards
+	TxOrderingPolicy TxOrderingPolicy `toml:"-"`          // Order of including pending transactions, fee priority if nil
 }
 
 type Miner struct {
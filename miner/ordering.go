@@ -0,0 +1,218 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// FeePriorityOrderingName orders transactions by the fee paid to the miner.
+	FeePriorityOrderingName = "fee-priority"
+	// FIFOOrderingName orders transactions by the time they were first seen.
+	FIFOOrderingName = "fifo"
+	// SenderPriorityOrderingName orders transactions of configured senders first.
+	SenderPriorityOrderingName = "sender-priority"
+)
+
+var (
+	_ TxOrderingPolicy = FeePriorityOrdering{}
+	_ TxOrderingPolicy = FIFOOrdering{}
+	_ TxOrderingPolicy = (*SenderPriorityOrdering)(nil)
+
+	_ TransactionSet = (*types.TransactionsByPriceAndNonce)(nil)
+	_ TransactionSet = (*transactionsByArrival)(nil)
+	_ TransactionSet = (*chainedTransactionSet)(nil)
+)
+
+// TransactionSet is a set of pending transactions, retrieved one at a time while honouring
+// the nonce order of each sender.
+type TransactionSet interface {
+	// Peek returns the next transaction, or nil if the set is empty.
+	Peek() *types.Transaction
+	// Shift replaces the next transaction with the following one from the same sender.
+	Shift()
+	// Pop removes the next transaction and all following ones from the same sender.
+	Pop()
+}
+
+// TxOrderingPolicy determines the order in which the worker includes pending transactions in a block.
+type TxOrderingPolicy interface {
+	// NewTransactionSet returns the nonce sorted transactions of each sender in [txs] as a set
+	// retrieved in the order of the policy. [txs] is owned by the returned set.
+	NewTransactionSet(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet
+}
+
+// NewTxOrderingPolicy returns the built-in policy named [name]. [prioritySenders] are the senders
+// ordered first by the sender priority policy and must be empty for other policies.
+func NewTxOrderingPolicy(name string, prioritySenders []common.Address) (TxOrderingPolicy, error) {
+	if name != SenderPriorityOrderingName && len(prioritySenders) > 0 {
+		return nil, fmt.Errorf("cannot use priority senders with transaction ordering policy %q", name)
+	}
+	switch name {
+	case "", FeePriorityOrderingName:
+		return FeePriorityOrdering{}, nil
+	case FIFOOrderingName:
+		return FIFOOrdering{}, nil
+	case SenderPriorityOrderingName:
+		if len(prioritySenders) == 0 {
+			return nil, fmt.Errorf("cannot use transaction ordering policy %q without priority senders", name)
+		}
+		return &SenderPriorityOrdering{
+			Senders:  set.Of(prioritySenders...),
+			Ordering: FeePriorityOrdering{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering policy %q", name)
+	}
+}
+
+// FeePriorityOrdering orders transactions by the fee they pay to the miner, and transactions paying
+// the same fee by the time they were first seen.
+type FeePriorityOrdering struct{}
+
+func (FeePriorityOrdering) NewTransactionSet(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	return types.NewTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
+// FIFOOrdering orders transactions by the time they were first seen, regardless of the fee they pay.
+// This gives first-come-first-served inclusion on chains where all transactions pay the same gas price.
+type FIFOOrdering struct{}
+
+func (FIFOOrdering) NewTransactionSet(signer types.Signer, txs map[common.Address]types.Transactions, _ *big.Int) TransactionSet {
+	return newTransactionsByArrival(signer, txs)
+}
+
+// SenderPriorityOrdering orders the transactions sent by [Senders] before all other transactions.
+// The transactions of each group are ordered by [Ordering].
+type SenderPriorityOrdering struct {
+	Senders  set.Set[common.Address]
+	Ordering TxOrderingPolicy
+}
+
+func (o *SenderPriorityOrdering) NewTransactionSet(signer types.Signer, txs map[common.Address]types.Transactions, baseFee *big.Int) TransactionSet {
+	priorityTxs := make(map[common.Address]types.Transactions)
+	for from, accTxs := range txs {
+		if o.Senders.Contains(from) {
+			priorityTxs[from] = accTxs
+			delete(txs, from)
+		}
+	}
+	return &chainedTransactionSet{
+		sets: []TransactionSet{
+			o.Ordering.NewTransactionSet(signer, priorityTxs, baseFee),
+			o.Ordering.NewTransactionSet(signer, txs, baseFee),
+		},
+	}
+}
+
+// txsByArrival implements the heap interface over the next transaction of each sender, ordering
+// transactions by the time they were first seen, and by hash for deterministic sorting.
+type txsByArrival []*types.Transaction
+
+func (s txsByArrival) Len() int { return len(s) }
+func (s txsByArrival) Less(i, j int) bool {
+	if ti, tj := s[i].FirstSeen(), s[j].FirstSeen(); !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	hi, hj := s[i].Hash(), s[j].Hash()
+	return bytes.Compare(hi[:], hj[:]) < 0
+}
+func (s txsByArrival) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txsByArrival) Push(x interface{}) {
+	*s = append(*s, x.(*types.Transaction))
+}
+
+func (s *txsByArrival) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// transactionsByArrival is a TransactionSet retrieving transactions in the order they were
+// first seen, while honouring the nonce order of each sender.
+type transactionsByArrival struct {
+	txs    map[common.Address]types.Transactions // Per account nonce-sorted list of transactions
+	heads  txsByArrival                          // Next transaction for each unique account
+	signer types.Signer
+}
+
+func newTransactionsByArrival(signer types.Signer, txs map[common.Address]types.Transactions) *transactionsByArrival {
+	heads := make(txsByArrival, 0, len(txs))
+	for from, accTxs := range txs {
+		// Remove transactions whose sender doesn't match from
+		if acc, _ := types.Sender(signer, accTxs[0]); acc != from {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, accTxs[0])
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	return &transactionsByArrival{
+		txs:    txs,
+		heads:  heads,
+		signer: signer,
+	}
+}
+
+func (t *transactionsByArrival) Peek() *types.Transaction {
+	if len(t.heads) == 0 {
+		return nil
+	}
+	return t.heads[0]
+}
+
+func (t *transactionsByArrival) Shift() {
+	acc, _ := types.Sender(t.signer, t.heads[0])
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		t.heads[0], t.txs[acc] = txs[0], txs[1:]
+		heap.Fix(&t.heads, 0)
+		return
+	}
+	heap.Pop(&t.heads)
+}
+
+func (t *transactionsByArrival) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// chainedTransactionSet retrieves all transactions of each of [sets] in turn.
+type chainedTransactionSet struct {
+	sets []TransactionSet
+}
+
+func (c *chainedTransactionSet) Peek() *types.Transaction {
+	for len(c.sets) > 0 {
+		if tx := c.sets[0].Peek(); tx != nil {
+			return tx
+		}
+		c.sets = c.sets[1:]
+	}
+	return nil
+}
+
+func (c *chainedTransactionSet) Shift() {
+	if c.Peek() != nil {
+		c.sets[0].Shift()
+	}
+}
+
+func (c *chainedTransactionSet) Pop() {
+	if c.Peek() != nil {
+		c.sets[0].Pop()
+	}
+}
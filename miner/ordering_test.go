@@ -0,0 +1,106 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// drain returns the transactions of [txs] in the order they are retrieved, shifting after each one.
+func drain(txs TransactionSet) []*types.Transaction {
+	var ordered []*types.Transaction
+	for tx := txs.Peek(); tx != nil; tx = txs.Peek() {
+		ordered = append(ordered, tx)
+		txs.Shift()
+	}
+	return ordered
+}
+
+func TestTxOrderingPolicies(t *testing.T) {
+	require := require.New(t)
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	keys := make([]*ecdsa.PrivateKey, 3)
+	addrs := make([]common.Address, 3)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(err)
+		keys[i] = key
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	start := time.Now()
+	newTx := func(sender int, nonce uint64, gasPrice int64, seen int) *types.Transaction {
+		tx := types.MustSignNewTx(keys[sender], signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &common.Address{},
+			Gas:      21_000,
+			GasPrice: big.NewInt(gasPrice),
+		})
+		tx.SetFirstSeen(start.Add(time.Duration(seen) * time.Second))
+		return tx
+	}
+
+	// Sender 0 pays the most but its transactions were seen last, sender 2 is given priority
+	tx00 := newTx(0, 0, 30, 3)
+	tx01 := newTx(0, 1, 30, 4)
+	tx10 := newTx(1, 0, 10, 0)
+	tx11 := newTx(1, 1, 10, 5)
+	tx20 := newTx(2, 0, 20, 1)
+	pending := func() map[common.Address]types.Transactions {
+		return map[common.Address]types.Transactions{
+			addrs[0]: {tx00, tx01},
+			addrs[1]: {tx10, tx11},
+			addrs[2]: {tx20},
+		}
+	}
+
+	tests := map[string]struct {
+		name            string
+		prioritySenders []common.Address
+		expected        []*types.Transaction
+	}{
+		"fee priority": {
+			name:     FeePriorityOrderingName,
+			expected: []*types.Transaction{tx00, tx01, tx20, tx10, tx11},
+		},
+		"fifo": {
+			name:     FIFOOrderingName,
+			expected: []*types.Transaction{tx10, tx20, tx00, tx01, tx11},
+		},
+		"sender priority": {
+			name:            SenderPriorityOrderingName,
+			prioritySenders: []common.Address{addrs[2], addrs[1]},
+			expected:        []*types.Transaction{tx20, tx10, tx11, tx00, tx01},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := NewTxOrderingPolicy(test.name, test.prioritySenders)
+			require.NoError(err)
+			ordered := drain(policy.NewTransactionSet(signer, pending(), big.NewInt(1)))
+			require.Equal(test.expected, ordered)
+		})
+	}
+
+	// Popping a transaction drops the following transactions of its sender
+	txs := FIFOOrdering{}.NewTransactionSet(signer, pending(), big.NewInt(1))
+	require.Equal(tx10, txs.Peek())
+	txs.Pop()
+	require.Equal([]*types.Transaction{tx20, tx00, tx01}, drain(txs))
+
+	_, err := NewTxOrderingPolicy("unknown", nil)
+	require.ErrorContains(err, "unknown transaction ordering policy")
+	_, err = NewTxOrderingPolicy(SenderPriorityOrderingName, nil)
+	require.ErrorContains(err, "without priority senders")
+	_, err = NewTxOrderingPolicy(FIFOOrderingName, addrs)
+	require.ErrorContains(err, "cannot use priority senders")
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/core/vm"
 	"github.com/ava-labs/subnet-evm/params"
@@ -54,8 +55,14 @@ import (
 
 const (
 	targetTxsSize = 1800 * units.KiB
+
+	// maxBundlesPerBlock is the maximum number of bundles tried per block, bounding the number
+	// of state copies made by [commitBundle].
+	maxBundlesPerBlock = 64
 )
 
+var errBundleTxReverted = errors.New("bundle transaction reverted")
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer types.Signer
@@ -99,6 +106,8 @@ type worker struct {
 	mu       sync.RWMutex   // The lock used to protect the coinbase and extra fields
 	coinbase common.Address
 	clock    *mockable.Clock // Allows us mock the clock for testing
+
+	txOrderingPolicy TxOrderingPolicy
 }
 
 func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus.Engine, eth Backend, mux *event.TypeMux, clock *mockable.Clock) *worker {
@@ -112,6 +121,10 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		coinbase:    config.Etherbase,
 		clock:       clock,
 	}
+	worker.txOrderingPolicy = config.TxOrderingPolicy
+	if worker.txOrderingPolicy == nil {
+		worker.txOrderingPolicy = FeePriorityOrdering{}
+	}
 
 	return worker
 }
@@ -141,7 +154,7 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 	var gasLimit uint64
 	// The fee manager relies on the state of the parent block to set the fee config
 	// because the fee config may be changed by the current block.
-	feeConfig, _, err := w.chain.GetFeeConfigAt(parent)
+	feeConfig, err := dummy.GetFeeConfigAt(w.chain, parent, timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +215,15 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		return nil, err
 	}
 
+	// Include bundles before any other transactions, so they are not broken up by the ordering policy
+	bundles := w.eth.TxPool().Bundles(header.Time)
+	if len(bundles) > maxBundlesPerBlock {
+		bundles = bundles[:maxBundlesPerBlock]
+	}
+	for _, bundle := range bundles {
+		w.commitBundle(env, bundle, header.Coinbase)
+	}
+
 	// Get the pending txs from TxPool
 	pending := w.eth.TxPool().Pending(true)
 
@@ -215,11 +237,11 @@ func (w *worker) commitNewWork(predicateContext *precompileconfig.PredicateConte
 		}
 	}
 	if len(localTxs) > 0 {
-		txs := types.NewTransactionsByPriceAndNonce(env.signer, localTxs, header.BaseFee)
+		txs := w.txOrderingPolicy.NewTransactionSet(env.signer, localTxs, header.BaseFee)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
 	if len(remoteTxs) > 0 {
-		txs := types.NewTransactionsByPriceAndNonce(env.signer, remoteTxs, header.BaseFee)
+		txs := w.txOrderingPolicy.NewTransactionSet(env.signer, remoteTxs, header.BaseFee)
 		w.commitTransactions(env, txs, header.Coinbase)
 	}
 
@@ -279,7 +301,7 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, coin
 	return receipt.Logs, nil
 }
 
-func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByPriceAndNonce, coinbase common.Address) {
+func (w *worker) commitTransactions(env *environment, txs TransactionSet, coinbase common.Address) {
 	for {
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
@@ -334,6 +356,81 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 	}
 }
 
+// commitBundle executes the transactions of [bundle] in order, and reverts all of them unless all
+// execute successfully within the remaining gas and size of the block. A bundle that does not fit
+// in the block or whose nonces are ahead of the state is skipped until the head changes, and a
+// bundle with a used nonce or a failing or reverting transaction is removed from the pool. Returns whether the bundle was included.
+func (w *worker) commitBundle(env *environment, bundle *txpool.Bundle, coinbase common.Address) bool {
+	var size, gas uint64
+	for _, tx := range bundle.Txs {
+		size += tx.Size()
+		gas += tx.Gas()
+	}
+	if env.size+size > targetTxsSize || gas > env.gasPool.Gas() {
+		log.Trace("Skipping bundle that would exceed target size or gas", "hash", bundle.Hash(), "size", size, "gas", gas)
+		w.eth.TxPool().SkipBundle(bundle, env.parent.Hash())
+		return false
+	}
+	// Check the nonces before copying the state, as they may have been used by an earlier bundle
+	// or follow a bundle that was not included
+	nonces := make(map[common.Address]uint64)
+	for _, tx := range bundle.Txs {
+		from, _ := types.Sender(env.signer, tx)
+		nonce, ok := nonces[from]
+		if !ok {
+			nonce = env.state.GetNonce(from)
+		}
+		switch {
+		case tx.Nonce() < nonce:
+			log.Debug("Bundle transaction nonce already used, bundle removed", "hash", bundle.Hash(), "tx", tx.Hash(), "nonce", tx.Nonce(), "expected", nonce)
+			w.eth.TxPool().RemoveBundle(bundle)
+			return false
+		case tx.Nonce() > nonce:
+			// The bundle may follow another bundle of the sender that is not includable yet
+			log.Trace("Skipping bundle with nonce gap", "hash", bundle.Hash(), "tx", tx.Hash(), "nonce", tx.Nonce(), "expected", nonce)
+			w.eth.TxPool().SkipBundle(bundle, env.parent.Hash())
+			return false
+		}
+		nonces[from] = nonce + 1
+	}
+
+	// The state journal is cleared after each transaction, so the state is copied to revert
+	// the bundle as a whole. Bundles are committed before any other transaction, so the copy
+	// only holds the accounts touched by the upgrades and the earlier bundles of the block.
+	var (
+		snapshot = env.state.Copy()
+		gp       = env.gasPool.Gas()
+		gasUsed  = env.header.GasUsed
+		tcount   = env.tcount
+		envSize  = env.size
+		numTxs   = len(env.txs)
+	)
+	for _, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		_, err := w.commitTransaction(env, tx, coinbase)
+		if err == nil && env.receipts[len(env.receipts)-1].Status != types.ReceiptStatusSuccessful {
+			err = errBundleTxReverted
+		}
+		if err != nil {
+			log.Debug("Bundle transaction failed, bundle removed", "hash", bundle.Hash(), "tx", tx.Hash(), "err", err)
+			for _, included := range env.txs[numTxs:] {
+				env.predicateResults.DeleteTxResults(included.Hash())
+			}
+			env.state = snapshot
+			env.gasPool.SetGas(gp)
+			env.header.GasUsed = gasUsed
+			env.tcount = tcount
+			env.txs = env.txs[:numTxs]
+			env.receipts = env.receipts[:numTxs]
+			env.size = envSize
+			w.eth.TxPool().RemoveBundle(bundle)
+			return false
+		}
+		env.tcount++
+	}
+	return true
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 func (w *worker) commit(env *environment) (*types.Block, error) {
@@ -113,7 +113,7 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 	acceptCtx := &precompileconfig.AcceptContext{
 		SnowCtx:      b.vm.ctx,
 		SharedMemory: sharedMemoryWriter,
-		Warp:         b.vm.warpBackend,
+		Warp:         b.vm.warpMessageWriter(),
 	}
 	for _, receipt := range receipts {
 		for logIdx, log := range receipt.Logs {
@@ -121,7 +121,7 @@ func (b *Block) handlePrecompileAccept(rules *params.Rules, sharedMemoryWriter *
 			if !ok {
 				continue
 			}
-			if err := accepter.Accept(acceptCtx, log.BlockHash, log.BlockNumber, log.TxHash, logIdx, log.Topics, log.Data); err != nil {
+			if err := accepter.Accept(acceptCtx, log.BlockHash, log.BlockNumber, b.ethBlock.Time(), log.TxHash, logIdx, log.Topics, log.Data); err != nil {
 				return err
 			}
 		}
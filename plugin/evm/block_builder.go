@@ -4,16 +4,21 @@
 package evm
 
 import (
+	"math/big"
 	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
 	"github.com/ava-labs/subnet-evm/core"
 	"github.com/ava-labs/subnet-evm/core/txpool"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
 
 	"github.com/ava-labs/avalanchego/snow"
 	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -21,15 +26,26 @@ const (
 	// Minimum amount of time to wait after building a block before attempting to build a block
 	// a second time without changing the contents of the mempool.
 	minBlockBuildingRetryDelay = 500 * time.Millisecond
+
+	// Amount of time to wait before checking again whether the tips of the pending transactions
+	// cover the block fee, which decreases as time passes since the last block.
+	blockFeeRecheckDelay = 1 * time.Second
 )
 
 type blockBuilder struct {
 	ctx         *snow.Context
 	chainConfig *params.ChainConfig
+	clock       *mockable.Clock
 
+	chain    *core.BlockChain
 	txPool   *txpool.TxPool
 	gossiper Gossiper
 
+	// maxBuildDelay is the maximum time to delay signalling the engine while the projected tips of
+	// the pending transactions do not cover the required block fee. If 0, the engine is signalled
+	// as soon as there are pending transactions.
+	maxBuildDelay time.Duration
+
 	shutdownChan <-chan struct{}
 	shutdownWg   *sync.WaitGroup
 
@@ -44,6 +60,10 @@ type blockBuilder struct {
 	// are still waiting for buildBlock to be called.
 	buildSent bool
 
+	// pendingSince is the time signalling the engine was first delayed for insufficient tips,
+	// or zero if it is not being delayed.
+	pendingSince time.Time
+
 	// buildBlockTimer is a timer used to delay retrying block building a minimum amount of time
 	// with the same contents of the mempool.
 	// If the mempool receives a new transaction, the block builder will send a new notification to
@@ -55,7 +75,10 @@ func (vm *VM) NewBlockBuilder(notifyBuildBlockChan chan<- commonEng.Message) *bl
 	b := &blockBuilder{
 		ctx:                  vm.ctx,
 		chainConfig:          vm.chainConfig,
+		clock:                &vm.clock,
+		chain:                vm.blockChain,
 		txPool:               vm.txPool,
+		maxBuildDelay:        vm.config.BlockBuildingMaxDelay.Duration,
 		gossiper:             vm.gossiper,
 		shutdownChan:         vm.shutdownChan,
 		shutdownWg:           &vm.shutdownWg,
@@ -81,7 +104,9 @@ func (b *blockBuilder) buildBlockTimerCallback() {
 	// If there are still transactions in the mempool, send another notification to
 	// the engine to retry BuildBlock.
 	if b.needToBuild() {
-		b.markBuilding()
+		b.signalIfBlockFeeCovered()
+	} else {
+		b.pendingSince = time.Time{}
 	}
 }
 
@@ -98,10 +123,11 @@ func (b *blockBuilder) handleGenerateBlock() {
 }
 
 // needToBuild returns true if there are outstanding transactions to be issued
-// into a block.
+// into a block. Bundles the miner skipped or removed at the current head are not
+// returned by the pool, so they do not trigger block building on their own.
 func (b *blockBuilder) needToBuild() bool {
 	size := b.txPool.PendingSize()
-	return size > 0
+	return size > 0 || len(b.txPool.Bundles(uint64(b.clock.Unix()))) > 0
 }
 
 // markBuilding adds a PendingTxs message to the toEngine channel.
@@ -112,6 +138,7 @@ func (b *blockBuilder) markBuilding() {
 		return
 	}
 	b.buildBlockTimer.Cancel() // Cancel any future attempt from the timer to send a PendingTxs message
+	b.pendingSince = time.Time{}
 
 	select {
 	case b.notifyBuildBlockChan <- commonEng.PendingTxs:
@@ -128,13 +155,103 @@ func (b *blockBuilder) signalTxsReady() {
 	b.buildBlockLock.Lock()
 	defer b.buildBlockLock.Unlock()
 
-	// We take a naive approach here and signal the engine that we should build
-	// a block as soon as we receive at least one new transaction.
-	//
-	// In the future, we may wish to add optimization here to only signal the
-	// engine if the sum of the projected tips in the mempool satisfies the
-	// required block fee.
-	b.markBuilding()
+	b.signalIfBlockFeeCovered()
+}
+
+// signalIfBlockFeeCovered sends a PendingTxs notification to the consensus engine if the projected
+// tips of the pending transactions cover the fee required of the next block, or if signalling has
+// been delayed for [maxBuildDelay]. Otherwise, it schedules checking again once the required block
+// fee decreased.
+// signalIfBlockFeeCovered assumes the [buildBlockLock] is held.
+func (b *blockBuilder) signalIfBlockFeeCovered() {
+	if b.buildSent {
+		return
+	}
+	if b.maxBuildDelay <= 0 {
+		b.markBuilding()
+		return
+	}
+
+	now := b.clock.Time()
+	if b.pendingSince.IsZero() {
+		b.pendingSince = now
+	}
+	delayed := now.Sub(b.pendingSince)
+	if delayed >= b.maxBuildDelay || b.blockFeeCovered(now) {
+		b.markBuilding()
+		return
+	}
+
+	retryDelay := blockFeeRecheckDelay
+	if remaining := b.maxBuildDelay - delayed; remaining < retryDelay {
+		retryDelay = remaining
+	}
+	log.Trace("Delaying block building until pending tips cover the block fee", "delayed", delayed, "retryIn", retryDelay)
+	b.buildBlockTimer.SetTimeoutIn(retryDelay)
+}
+
+// blockFeeCovered returns true if the tips of the pending transactions would cover the block fee
+// required of a block built at [now]. Transactions are counted until their gas limits fill the block
+// gas limit, as the miner fills the block, and their tips are projected over their intrinsic gas,
+// the least gas they can use, so that the block fee is never overestimated.
+func (b *blockBuilder) blockFeeCovered(now time.Time) bool {
+	parent := b.chain.CurrentBlock()
+	timestamp := uint64(now.Unix())
+	if parent.Time >= timestamp {
+		timestamp = parent.Time
+	}
+	baseFee, blockGasCost, err := dummy.EstimateNextBlockFee(b.chain, parent, timestamp)
+	if err != nil {
+		// Let the engine attempt to build the block, which reports the error
+		log.Warn("Failed to estimate block fee of next block", "err", err)
+		return true
+	}
+	if blockGasCost == nil || blockGasCost.Sign() == 0 {
+		return true
+	}
+	feeConfig, err := dummy.GetFeeConfigAt(b.chain, parent, timestamp)
+	if err != nil {
+		log.Warn("Failed to get fee config of next block", "err", err)
+		return true
+	}
+
+	// The tips must purchase [blockGasCost] at [baseFee], as verified by the consensus engine.
+	requiredBlockFee := new(big.Int).Mul(blockGasCost, baseFee)
+	projectedBlockFee := new(big.Int)
+	txFee := new(big.Int)
+	// Bundles are included before the other pending transactions
+	txs := make(types.Transactions, 0)
+	for _, bundle := range b.txPool.Bundles(timestamp) {
+		txs = append(txs, bundle.Txs...)
+	}
+	for _, pending := range b.txPool.Pending(true) {
+		txs = append(txs, pending...)
+	}
+	var (
+		gasLimit = feeConfig.GasLimit.Uint64()
+		gas      uint64
+		rules    = b.chainConfig.AvalancheRules(new(big.Int).Add(parent.Number, common.Big1), timestamp)
+	)
+	for _, tx := range txs {
+		if gas+tx.Gas() > gasLimit {
+			break
+		}
+		gas += tx.Gas()
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			continue
+		}
+		intrinsicGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, rules)
+		if err != nil {
+			continue
+		}
+		txFee.SetUint64(intrinsicGas)
+		projectedBlockFee.Add(projectedBlockFee, txFee.Mul(txFee, tip))
+		if projectedBlockFee.Cmp(requiredBlockFee) >= 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // awaitSubmittedTxs waits for new transactions to be submitted
@@ -145,6 +262,8 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 	// may orphan transactions that were previously in a preferred block.
 	txSubmitChan := make(chan core.NewTxsEvent)
 	b.txPool.SubscribeNewTxsEvent(txSubmitChan)
+	bundleSubmitChan := make(chan txpool.NewBundleEvent)
+	b.txPool.SubscribeNewBundleEvent(bundleSubmitChan)
 
 	b.shutdownWg.Add(1)
 	go b.ctx.Log.RecoverAndPanic(func() {
@@ -166,6 +285,10 @@ func (b *blockBuilder) awaitSubmittedTxs() {
 						)
 					}
 				}
+			case <-bundleSubmitChan:
+				// Bundles are not gossiped, so they are only included in blocks built by this node
+				log.Trace("New bundle detected, trying to generate a block")
+				b.signalTxsReady()
 			case <-b.shutdownChan:
 				b.buildBlockTimer.Stop()
 				return
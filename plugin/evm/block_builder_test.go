@@ -4,14 +4,22 @@
 package evm
 
 import (
+	"context"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/snow"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
 )
 
 func attemptAwait(t *testing.T, wg *sync.WaitGroup, delay time.Duration) {
@@ -73,3 +81,89 @@ func TestBlockBuilderSkipsTimerInitialization(t *testing.T) {
 	// should be created when all prices should be set from the start
 	attemptAwait(t, wg, time.Millisecond)
 }
+
+func TestBlockBuilderWaitsForBlockFee(t *testing.T) {
+	genesis := &core.Genesis{}
+	require.NoError(t, genesis.UnmarshalJSON([]byte(genesisJSONSubnetEVM)))
+	// Require a block fee of 100k gas even for the first block
+	genesis.Config.FeeConfig = params.DefaultFeeConfig
+	genesis.Config.FeeConfig.MinBlockGasCost = big.NewInt(100_000)
+	genesisJSON, err := genesis.MarshalJSON()
+	require.NoError(t, err)
+	baseFee := genesis.Config.FeeConfig.MinBaseFee
+
+	// newTx returns a transfer with [gas] limit paying [tip] per gas to the block fee
+	newTx := func(key int, gas uint64, tip *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			To:        &common.Address{1},
+			Gas:       gas,
+			Value:     common.Big1,
+			GasFeeCap: new(big.Int).Add(baseFee, tip),
+			GasTipCap: tip,
+		}), types.LatestSigner(genesis.Config), testKeys[key])
+		require.NoError(t, err)
+		return tx
+	}
+	// Tip covering the block fee of 100k gas at the base fee with a single transfer
+	coveringTip := new(big.Int).Mul(baseFee, big.NewInt(100_000/int64(params.TxGas)+1))
+
+	requireNoSignal := func(issuer <-chan commonEng.Message, wait time.Duration) {
+		select {
+		case <-issuer:
+			t.Fatal("Unexpected block building signal")
+		case <-time.After(wait):
+		}
+	}
+	requireSignal := func(issuer <-chan commonEng.Message, wait time.Duration) {
+		select {
+		case msg := <-issuer:
+			require.Equal(t, commonEng.PendingTxs, msg)
+		case <-time.After(wait):
+			t.Fatal("Timed out waiting for block building signal")
+		}
+	}
+
+	t.Run("tips cover block fee", func(t *testing.T) {
+		issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), `{"block-building-max-delay": "1m"}`, "")
+		defer func() {
+			require.NoError(t, vm.Shutdown(context.Background()))
+		}()
+
+		errs := vm.txPool.AddRemotesSync([]*types.Transaction{newTx(0, params.TxGas, common.Big0)})
+		require.NoError(t, errs[0])
+		requireNoSignal(issuer, 500*time.Millisecond)
+
+		errs = vm.txPool.AddRemotesSync([]*types.Transaction{newTx(1, params.TxGas, coveringTip)})
+		require.NoError(t, errs[0])
+		requireSignal(issuer, 5*time.Second)
+
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(t, err)
+		require.Len(t, blk.(*chain.BlockWrapper).Block.(*Block).ethBlock.Transactions(), 2)
+	})
+
+	t.Run("tips over unused gas do not cover block fee", func(t *testing.T) {
+		issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), `{"block-building-max-delay": "1m"}`, "")
+		defer func() {
+			require.NoError(t, vm.Shutdown(context.Background()))
+		}()
+
+		// The tip only covers the block fee if the transfer used its entire gas limit
+		errs := vm.txPool.AddRemotesSync([]*types.Transaction{newTx(0, 200_000, baseFee)})
+		require.NoError(t, errs[0])
+		requireNoSignal(issuer, 500*time.Millisecond)
+	})
+
+	t.Run("max delay", func(t *testing.T) {
+		issuer, vm, _, _ := GenesisVM(t, true, string(genesisJSON), `{"block-building-max-delay": "2s"}`, "")
+		defer func() {
+			require.NoError(t, vm.Shutdown(context.Background()))
+		}()
+
+		errs := vm.txPool.AddRemotesSync([]*types.Transaction{newTx(0, params.TxGas, common.Big0)})
+		require.NoError(t, errs[0])
+		requireNoSignal(issuer, 500*time.Millisecond)
+		requireSignal(issuer, 5*time.Second)
+	})
+}
@@ -71,6 +71,7 @@ func TestHandlePrecompileAccept(t *testing.T) {
 			gomock.Not(gomock.Nil()),                // acceptCtx
 			ethBlock.Hash(),                         // blockHash
 			ethBlock.NumberU64(),                    // blockNumber
+			ethBlock.Time(),                         // blockTimestamp
 			ethBlock.Transactions()[txIndex].Hash(), // txHash
 			0,                                       // logIndex
 			receipt.Logs[0].Topics,                  // topics
@@ -80,6 +81,7 @@ func TestHandlePrecompileAccept(t *testing.T) {
 			gomock.Not(gomock.Nil()),                // acceptCtx
 			ethBlock.Hash(),                         // blockHash
 			ethBlock.NumberU64(),                    // blockNumber
+			ethBlock.Time(),                         // blockTimestamp
 			ethBlock.Transactions()[txIndex].Hash(), // txHash
 			2,                                       // logIndex
 			receipt.Logs[2].Topics,                  // topics
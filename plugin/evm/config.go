@@ -10,44 +10,51 @@ import (
 
 	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/miner"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/warp"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cast"
 )
 
 const (
-	defaultAcceptorQueueLimit                         = 64 // Provides 2 minutes of buffer (2s block target) for a commit delay
-	defaultPruningEnabled                             = true
-	defaultPruneWarpDB                                = false
-	defaultCommitInterval                             = 4096
-	defaultTrieCleanCache                             = 512
-	defaultTrieDirtyCache                             = 512
-	defaultTrieDirtyCommitTarget                      = 20
-	defaultSnapshotCache                              = 256
-	defaultSyncableCommitInterval                     = defaultCommitInterval * 4
-	defaultSnapshotWait                               = false
-	defaultRpcGasCap                                  = 50_000_000 // Default to 50M Gas Limit
-	defaultRpcTxFeeCap                                = 100        // 100 AVAX
-	defaultMetricsExpensiveEnabled                    = true
-	defaultApiMaxDuration                             = 0 // Default to no maximum API call duration
-	defaultWsCpuRefillRate                            = 0 // Default to no maximum WS CPU usage
-	defaultWsCpuMaxStored                             = 0 // Default to no maximum WS CPU usage
-	defaultMaxBlocksPerRequest                        = 0 // Default to no maximum on the number of blocks per getLogs request
-	defaultContinuousProfilerFrequency                = 15 * time.Minute
-	defaultContinuousProfilerMaxFiles                 = 5
-	defaultRegossipFrequency                          = 1 * time.Minute
-	defaultRegossipMaxTxs                             = 16
-	defaultRegossipTxsPerAddress                      = 1
-	defaultPriorityRegossipFrequency                  = 1 * time.Second
-	defaultPriorityRegossipMaxTxs                     = 32
-	defaultPriorityRegossipTxsPerAddress              = 16
-	defaultOfflinePruningBloomFilterSize       uint64 = 512 // Default size (MB) for the offline pruner to use
-	defaultLogLevel                                   = "info"
-	defaultLogJSONFormat                              = false
-	defaultMaxOutboundActiveRequests                  = 16
-	defaultMaxOutboundActiveCrossChainRequests        = 64
-	defaultPopulateMissingTriesParallelism            = 1024
-	defaultStateSyncServerTrieCache                   = 64 // MB
-	defaultAcceptedCacheSize                          = 32 // blocks
+	defaultAcceptorQueueLimit                          = 64 // Provides 2 minutes of buffer (2s block target) for a commit delay
+	defaultPruningEnabled                              = true
+	defaultPruneWarpDB                                 = false
+	defaultCommitInterval                              = 4096
+	defaultTrieCleanCache                              = 512
+	defaultTrieDirtyCache                              = 512
+	defaultTrieDirtyCommitTarget                       = 20
+	defaultSnapshotCache                               = 256
+	defaultSyncableCommitInterval                      = defaultCommitInterval * 4
+	defaultSnapshotWait                                = false
+	defaultRpcGasCap                                   = 50_000_000 // Default to 50M Gas Limit
+	defaultRpcTxFeeCap                                 = 100        // 100 AVAX
+	defaultMetricsExpensiveEnabled                     = true
+	defaultApiMaxDuration                              = 0 // Default to no maximum API call duration
+	defaultWsCpuRefillRate                             = 0 // Default to no maximum WS CPU usage
+	defaultWsCpuMaxStored                              = 0 // Default to no maximum WS CPU usage
+	defaultMaxBlocksPerRequest                         = 0 // Default to no maximum on the number of blocks per getLogs request
+	defaultContinuousProfilerFrequency                 = 15 * time.Minute
+	defaultContinuousProfilerMaxFiles                  = 5
+	defaultRegossipFrequency                           = 1 * time.Minute
+	defaultRegossipMaxTxs                              = 16
+	defaultRegossipTxsPerAddress                       = 1
+	defaultPriorityRegossipFrequency                   = 1 * time.Second
+	defaultPriorityRegossipMaxTxs                      = 32
+	defaultPriorityRegossipTxsPerAddress               = 16
+	defaultOfflinePruningBloomFilterSize        uint64 = 512 // Default size (MB) for the offline pruner to use
+	defaultLogLevel                                    = "info"
+	defaultLogJSONFormat                               = false
+	defaultMaxOutboundActiveRequests                   = 16
+	defaultMaxOutboundActiveCrossChainRequests         = 64
+	defaultWarpSignatureRequestThrottlingPeriod        = 1 * time.Second
+	defaultWarpSignatureRequestThrottlingLimit         = 256 // Per node, in each throttling period
+	defaultMaxActiveWarpSignatureRequests              = 64
+	defaultWarpPruningFrequency                        = 1 * time.Minute
+	defaultPopulateMissingTriesParallelism             = 1024
+	defaultStateSyncServerTrieCache                    = 64 // MB
+	defaultAcceptedCacheSize                           = 32 // blocks
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -125,6 +132,11 @@ type Config struct {
 	PopulateMissingTriesParallelism int     `json:"populate-missing-tries-parallelism"` // Number of concurrent readers to use when re-populating missing tries on startup.
 	PruneWarpDB                     bool    `json:"prune-warp-db-enabled"`              // Determines if the warpDB should be cleared on startup
 
+	// Warp DB Retention Settings
+	WarpRetentionBlocks  uint64   `json:"warp-retention-blocks"`  // Keeps warp messages accepted in this many most recent blocks, unlimited if 0
+	WarpRetentionPeriod  Duration `json:"warp-retention-period"`  // Keeps warp messages accepted in this most recent period, unlimited if 0
+	WarpPruningFrequency Duration `json:"warp-pruning-frequency"` // Frequency of pruning warp messages outside of the retention limits
+
 	// Metric Settings
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
 
@@ -140,6 +152,21 @@ type Config struct {
 	TxPoolAccountQueue uint64   `json:"tx-pool-account-queue"`
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 
+	TxPoolSnapshot         string   `json:"tx-pool-snapshot"`          // Snapshot of remote transactions to survive node restarts, disabled if empty
+	TxPoolSnapshotInterval Duration `json:"tx-pool-snapshot-interval"` // Time interval to save the transaction snapshot
+
+	TxPoolSenderTxRate      float64  `json:"tx-pool-sender-tx-rate"`       // Maximum sustained rate of remote transactions admitted per sender (txs/s), unlimited if 0
+	TxPoolSenderGasRate     uint64   `json:"tx-pool-sender-gas-rate"`      // Maximum sustained rate of gas of remote transactions admitted per sender (gas/s), unlimited if 0
+	TxPoolPeerTxRate        float64  `json:"tx-pool-peer-tx-rate"`         // Maximum sustained rate of transactions admitted per peer pushing gossip (txs/s), unlimited if 0
+	TxPoolPeerGasRate       uint64   `json:"tx-pool-peer-gas-rate"`        // Maximum sustained rate of gas of transactions admitted per peer pushing gossip (gas/s), unlimited if 0
+	TxPoolRateLimitBurst    Duration `json:"tx-pool-rate-limit-burst"`     // Duration of the sustained rate that may be admitted at once
+	TxPoolSpamScoreHalfLife Duration `json:"tx-pool-spam-score-half-life"` // Half-life of the spam score slowing down senders exceeding their rate limit
+
+	// Block Building Settings
+	BlockBuildingMaxDelay     Duration         `json:"block-building-max-delay"`     // Maximum delay of block building while pending tips do not cover the block fee, building is not delayed if 0
+	TxOrderingPolicy          string           `json:"tx-ordering-policy"`           // Order of including pending transactions in built blocks: fee-priority, fifo or sender-priority
+	TxOrderingPrioritySenders []common.Address `json:"tx-ordering-priority-senders"` // Senders whose transactions are included first by the sender-priority ordering policy
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
@@ -175,6 +202,21 @@ type Config struct {
 	OfflinePruningBloomFilterSize uint64 `json:"offline-pruning-bloom-filter-size"`
 	OfflinePruningDataDirectory   string `json:"offline-pruning-data-directory"`
 
+	// Warp Relayer Settings
+	WarpRelayerEnabled            bool             `json:"warp-relayer-enabled"`
+	WarpRelayerDestinationRPC     string           `json:"warp-relayer-destination-rpc"`
+	WarpRelayerDestinationAddress common.Address   `json:"warp-relayer-destination-address"` // Contract called with receiveWarpMessage(0) by relay transactions
+	WarpRelayerPrivateKeyFile     string           `json:"warp-relayer-private-key-file"`    // File holding the hex encoded key of an account funded on the destination chain
+	WarpRelayerSourceAddresses    []common.Address `json:"warp-relayer-source-addresses"`    // Only relays messages sent by these addresses if non-empty
+	WarpRelayerQuorumNumerator    uint64           `json:"warp-relayer-quorum-numerator"`    // Defaults to the default warp quorum if 0
+	WarpRelayerGasLimit           uint64           `json:"warp-relayer-gas-limit"`           // Defaults to the relayer default gas limit if 0
+	WarpRelayerMaxAttempts        uint64           `json:"warp-relayer-max-attempts"`        // Defaults to the relayer default max attempts if 0
+
+	// Warp Signature Request Settings
+	WarpSignatureRequestThrottlingPeriod Duration `json:"warp-signature-request-throttling-period"`
+	WarpSignatureRequestThrottlingLimit  int      `json:"warp-signature-request-throttling-limit"` // Maximum signature requests served per node in each throttling period, unlimited if 0
+	MaxActiveWarpSignatureRequests       int64    `json:"max-active-warp-signature-requests"`      // Maximum signature requests served concurrently, unlimited if 0
+
 	// VM2VM network
 	MaxOutboundActiveRequests           int64 `json:"max-outbound-active-requests"`
 	MaxOutboundActiveCrossChainRequests int64 `json:"max-outbound-active-cross-chain-requests"`
@@ -234,6 +276,9 @@ func (c *Config) SetDefaults() {
 	c.TxPoolGlobalSlots = txpool.DefaultConfig.GlobalSlots
 	c.TxPoolAccountQueue = txpool.DefaultConfig.AccountQueue
 	c.TxPoolGlobalQueue = txpool.DefaultConfig.GlobalQueue
+	c.TxPoolSnapshotInterval = Duration{txpool.DefaultConfig.SnapshotInterval}
+	c.TxPoolRateLimitBurst = Duration{txpool.DefaultConfig.RateLimitBurst}
+	c.TxPoolSpamScoreHalfLife = Duration{txpool.DefaultConfig.SpamScoreHalfLife}
 
 	c.APIMaxDuration.Duration = defaultApiMaxDuration
 	c.WSCPURefillRate.Duration = defaultWsCpuRefillRate
@@ -260,6 +305,11 @@ func (c *Config) SetDefaults() {
 	c.LogJSONFormat = defaultLogJSONFormat
 	c.MaxOutboundActiveRequests = defaultMaxOutboundActiveRequests
 	c.MaxOutboundActiveCrossChainRequests = defaultMaxOutboundActiveCrossChainRequests
+	c.WarpSignatureRequestThrottlingPeriod.Duration = defaultWarpSignatureRequestThrottlingPeriod
+	c.WarpSignatureRequestThrottlingLimit = defaultWarpSignatureRequestThrottlingLimit
+	c.MaxActiveWarpSignatureRequests = defaultMaxActiveWarpSignatureRequests
+	c.WarpPruningFrequency.Duration = defaultWarpPruningFrequency
+	c.TxOrderingPolicy = miner.FeePriorityOrderingName
 	c.PopulateMissingTriesParallelism = defaultPopulateMissingTriesParallelism
 	c.StateSyncServerTrieCache = defaultStateSyncServerTrieCache
 	c.StateSyncCommitInterval = defaultSyncableCommitInterval
@@ -305,5 +355,60 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if c.WarpRelayerEnabled {
+		if c.WarpRelayerDestinationRPC == "" {
+			return fmt.Errorf("cannot enable warp relayer without a destination rpc")
+		}
+		if c.WarpRelayerDestinationAddress == (common.Address{}) {
+			return fmt.Errorf("cannot enable warp relayer without a destination address")
+		}
+		if c.WarpRelayerPrivateKeyFile == "" {
+			return fmt.Errorf("cannot enable warp relayer without a private key file")
+		}
+		if c.WarpRelayerQuorumNumerator != 0 && (c.WarpRelayerQuorumNumerator < params.WarpQuorumNumeratorMinimum || c.WarpRelayerQuorumNumerator > params.WarpQuorumDenominator) {
+			return fmt.Errorf("cannot use warp relayer quorum numerator %d outside of [%d, %d]", c.WarpRelayerQuorumNumerator, params.WarpQuorumNumeratorMinimum, params.WarpQuorumDenominator)
+		}
+	}
+	if c.WarpSignatureRequestThrottlingLimit < 0 {
+		return fmt.Errorf("cannot use negative warp signature request throttling limit %d", c.WarpSignatureRequestThrottlingLimit)
+	}
+	if c.WarpSignatureRequestThrottlingLimit > 0 && c.WarpSignatureRequestThrottlingPeriod.Duration <= 0 {
+		return fmt.Errorf("cannot use warp signature request throttling limit without a positive throttling period")
+	}
+	if _, err := miner.NewTxOrderingPolicy(c.TxOrderingPolicy, c.TxOrderingPrioritySenders); err != nil {
+		return err
+	}
+	if c.TxPoolSnapshot != "" && c.TxPoolSnapshotInterval.Duration < time.Second {
+		return fmt.Errorf("cannot use tx pool snapshot with a snapshot interval under 1s (%s)", c.TxPoolSnapshotInterval.Duration)
+	}
+	if c.TxPoolSenderTxRate < 0 || c.TxPoolPeerTxRate < 0 {
+		return fmt.Errorf("cannot use negative tx pool tx rates (sender: %f, peer: %f)", c.TxPoolSenderTxRate, c.TxPoolPeerTxRate)
+	}
+	if c.TxPoolSenderTxRate > 0 || c.TxPoolSenderGasRate > 0 || c.TxPoolPeerTxRate > 0 || c.TxPoolPeerGasRate > 0 {
+		if c.TxPoolRateLimitBurst.Duration < time.Second {
+			return fmt.Errorf("cannot use tx pool rate limits with a rate limit burst under 1s (%s)", c.TxPoolRateLimitBurst.Duration)
+		}
+		if c.TxPoolSpamScoreHalfLife.Duration < time.Second {
+			return fmt.Errorf("cannot use tx pool rate limits with a spam score half-life under 1s (%s)", c.TxPoolSpamScoreHalfLife.Duration)
+		}
+	}
+	if c.BlockBuildingMaxDelay.Duration < 0 {
+		return fmt.Errorf("cannot use negative block building max delay %s", c.BlockBuildingMaxDelay.Duration)
+	}
+	if c.WarpRetentionPeriod.Duration < 0 {
+		return fmt.Errorf("cannot use negative warp retention period %s", c.WarpRetentionPeriod.Duration)
+	}
+	if c.warpRetentionPolicy().Enabled() && c.WarpPruningFrequency.Duration <= 0 {
+		return fmt.Errorf("cannot use warp retention limits without a positive pruning frequency")
+	}
+
 	return nil
 }
+
+// warpRetentionPolicy returns the retention policy of the warp backend database.
+func (c *Config) warpRetentionPolicy() warp.RetentionPolicy {
+	return warp.RetentionPolicy{
+		MaxBlockDepth: c.WarpRetentionBlocks,
+		MaxAge:        c.WarpRetentionPeriod.Duration,
+	}
+}
@@ -59,6 +59,25 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"tx pool snapshot",
+			[]byte(`{"tx-pool-snapshot": "mempool.rlp", "tx-pool-snapshot-interval": "1m"}`),
+			Config{TxPoolSnapshot: "mempool.rlp", TxPoolSnapshotInterval: Duration{time.Minute}},
+			false,
+		},
+		{
+			"tx pool rate limits",
+			[]byte(`{"tx-pool-sender-tx-rate": 0.5, "tx-pool-sender-gas-rate": 1000000, "tx-pool-peer-tx-rate": 100, "tx-pool-peer-gas-rate": 20000000, "tx-pool-rate-limit-burst": "30s", "tx-pool-spam-score-half-life": "5m"}`),
+			Config{
+				TxPoolSenderTxRate:      0.5,
+				TxPoolSenderGasRate:     1_000_000,
+				TxPoolPeerTxRate:        100,
+				TxPoolPeerGasRate:       20_000_000,
+				TxPoolRateLimitBurst:    Duration{30 * time.Second},
+				TxPoolSpamScoreHalfLife: Duration{5 * time.Minute},
+			},
+			false,
+		},
 
 		{
 			"state sync enabled",
@@ -106,6 +125,49 @@ func TestUnmarshalConfig(t *testing.T) {
 			Config{AllowUnprotectedTxHashes: []common.Hash{common.HexToHash("0x803351deb6d745e91545a6a3e1c0ea3e9a6a02a1a4193b70edfcd2f40f71a01c")}},
 			false,
 		},
+		{
+			"warp relayer configurations",
+			[]byte(`{"warp-relayer-enabled": true, "warp-relayer-destination-rpc": "http://127.0.0.1:9650/ext/bc/dest/rpc", "warp-relayer-destination-address": "0x0200000000000000000000000000000000000002", "warp-relayer-private-key-file": "/keys/relayer.key", "warp-relayer-source-addresses": ["0x0100000000000000000000000000000000000001"], "warp-relayer-quorum-numerator": 80, "warp-relayer-max-attempts": 3}`),
+			Config{
+				WarpRelayerEnabled:            true,
+				WarpRelayerDestinationRPC:     "http://127.0.0.1:9650/ext/bc/dest/rpc",
+				WarpRelayerDestinationAddress: common.HexToAddress("0x0200000000000000000000000000000000000002"),
+				WarpRelayerPrivateKeyFile:     "/keys/relayer.key",
+				WarpRelayerSourceAddresses:    []common.Address{common.HexToAddress("0x0100000000000000000000000000000000000001")},
+				WarpRelayerQuorumNumerator:    80,
+				WarpRelayerMaxAttempts:        3,
+			},
+			false,
+		},
+		{
+			"warp signature request limits",
+			[]byte(`{"warp-signature-request-throttling-period": "10s", "warp-signature-request-throttling-limit": 100, "max-active-warp-signature-requests": 8}`),
+			Config{
+				WarpSignatureRequestThrottlingPeriod: Duration{10 * time.Second},
+				WarpSignatureRequestThrottlingLimit:  100,
+				MaxActiveWarpSignatureRequests:       8,
+			},
+			false,
+		},
+		{
+			"tx ordering policy",
+			[]byte(`{"tx-ordering-policy": "sender-priority", "tx-ordering-priority-senders": ["0xf2b2e0a0a9dff6cc77b5a8b8ac6dd8fd6e6c5c48"]}`),
+			Config{
+				TxOrderingPolicy:          "sender-priority",
+				TxOrderingPrioritySenders: []common.Address{common.HexToAddress("0xf2b2e0a0a9dff6cc77b5a8b8ac6dd8fd6e6c5c48")},
+			},
+			false,
+		},
+		{
+			"warp retention",
+			[]byte(`{"warp-retention-blocks": 1000, "warp-retention-period": "24h", "warp-pruning-frequency": "5m"}`),
+			Config{
+				WarpRetentionBlocks:  1000,
+				WarpRetentionPeriod:  Duration{24 * time.Hour},
+				WarpPruningFrequency: Duration{5 * time.Minute},
+			},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
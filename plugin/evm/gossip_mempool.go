@@ -77,8 +77,11 @@ func (g *GossipTxPool) Subscribe(ctx context.Context) {
 	}
 }
 
-// Add enqueues the transaction to the mempool. Subscribe should be called
-// to receive an event if tx is actually added to the mempool or not.
+// Add enqueues the transaction to the mempool, subject to the admission rate
+// limit of its sender. The gossip set is not told which peer a transaction was
+// pulled from, so the peer rate limit only applies to pushed transactions.
+// Subscribe should be called to receive an event if tx is actually added to
+// the mempool or not.
 func (g *GossipTxPool) Add(tx *GossipTx) error {
 	return g.mempool.AddRemotes([]*types.Transaction{tx.Tx})[0]
 }
@@ -26,6 +26,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/subnet-evm/core/txpool"
 	"github.com/ava-labs/subnet-evm/core/types"
 )
 
@@ -130,3 +131,31 @@ func TestTxGossip(t *testing.T) {
 	require.NoError(client.AppRequest(context.Background(), set.Set[ids.NodeID]{vm.ctx.NodeID: struct{}{}}, requestBytes, onResponse))
 	wg.Wait()
 }
+
+func TestGossipTxPoolSenderRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	// Each sender is limited to 2 transactions within the burst, and so would a shared peer
+	_, vm, _, _ := GenesisVM(t, true, genesisJSONLatest, `{"tx-pool-sender-tx-rate": 1, "tx-pool-peer-tx-rate": 1, "tx-pool-rate-limit-burst": "2s"}`, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	txPool, err := NewGossipTxPool(vm.txPool)
+	require.NoError(err)
+
+	// The transactions are queued behind a nonce gap, so they are not promoted
+	newTx := func(key int, nonce uint64) *GossipTx {
+		tx := types.NewTransaction(nonce, testEthAddrs[0], big.NewInt(10), 21000, big.NewInt(testMinGasPrice), nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainConfig.ChainID), testKeys[key])
+		require.NoError(err)
+		return &GossipTx{Tx: signedTx}
+	}
+	require.NoError(txPool.Add(newTx(0, 1)))
+	require.NoError(txPool.Add(newTx(0, 2)))
+	require.ErrorIs(txPool.Add(newTx(0, 3)), txpool.ErrSenderRateLimited)
+
+	// Pulled transactions of other senders are not limited by the peer rate limit
+	require.NoError(txPool.Add(newTx(1, 1)))
+	require.NoError(txPool.Add(newTx(1, 2)))
+}
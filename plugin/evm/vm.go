@@ -42,6 +42,8 @@ import (
 	"github.com/ava-labs/subnet-evm/sync/client/stats"
 	"github.com/ava-labs/subnet-evm/trie"
 	"github.com/ava-labs/subnet-evm/warp"
+	"github.com/ava-labs/subnet-evm/warp/aggregator"
+	"github.com/ava-labs/subnet-evm/warp/relayer"
 	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
 
 	// Force-load tracer engine to trigger registration
@@ -136,11 +138,16 @@ const (
 
 var (
 	// Set last accepted key to be longer than the keys used to store accepted block IDs.
-	lastAcceptedKey = []byte("last_accepted_key")
-	acceptedPrefix  = []byte("snowman_accepted")
-	metadataPrefix  = []byte("metadata")
-	warpPrefix      = []byte("warp")
-	ethDBPrefix     = []byte("ethdb")
+	lastAcceptedKey   = []byte("last_accepted_key")
+	acceptedPrefix    = []byte("snowman_accepted")
+	metadataPrefix    = []byte("metadata")
+	warpPrefix        = []byte("warp")
+	warpRelayerPrefix = []byte("warp_relayer")
+	// Separate from [warpRelayerPrefix] since relay progress is not written through versiondb
+	warpRelayerProgressPrefix = []byte("warp_relayer_progress")
+	// Nested in [warpPrefix] so that signatures are cleared with the warpDB
+	warpSignaturesPrefix = []byte("warp_signatures")
+	ethDBPrefix          = []byte("ethdb")
 )
 
 var (
@@ -212,6 +219,14 @@ type VM struct {
 	// set to a prefixDB with the prefix [warpPrefix]
 	warpDB database.Database
 
+	// [warpRelayerDB] is used to store the warp relayer queue
+	// set to a prefixDB with the prefix [warpRelayerPrefix]
+	warpRelayerDB database.Database
+
+	// [warpRelayerProgressDB] is used to store the warp relayer progress
+	// set to a prefixDB with the prefix [warpRelayerProgressPrefix]
+	warpRelayerProgressDB database.Database
+
 	toEngine chan<- commonEng.Message
 
 	syntacticBlockValidator BlockValidator
@@ -249,6 +264,13 @@ type VM struct {
 	// Avalanche Warp Messaging backend
 	// Used to serve BLS signatures of warp messages over RPC
 	warpBackend warp.Backend
+
+	// Persists the validator signatures collected when aggregating warp signatures
+	// so that later aggregations of the same message only fetch missing signatures
+	warpSignatureStore aggregator.SignatureStore
+
+	// Relays accepted warp messages to a destination chain if the warp relayer is enabled
+	warpRelayer *relayer.Relayer
 }
 
 // Initialize implements the snowman.ChainVM interface
@@ -308,6 +330,11 @@ func (vm *VM) Initialize(
 	// that warp signatures are committed to the database atomically with
 	// the last accepted block.
 	vm.warpDB = prefixdb.New(warpPrefix, db)
+	// warpRelayerDB is part of versiondb so that messages are queued for relay
+	// atomically with accepting the block that sent them. The relayer records
+	// its progress outside of block acceptance, so warpRelayerProgressDB is not.
+	vm.warpRelayerDB = prefixdb.New(warpRelayerPrefix, vm.db)
+	vm.warpRelayerProgressDB = prefixdb.New(warpRelayerProgressPrefix, db)
 
 	if vm.config.InspectDatabase {
 		start := time.Now()
@@ -392,12 +419,20 @@ func (vm *VM) Initialize(
 	vm.ethConfig.TxPool.NoLocals = !vm.config.LocalTxsEnabled
 	vm.ethConfig.TxPool.Journal = vm.config.TxPoolJournal
 	vm.ethConfig.TxPool.Rejournal = vm.config.TxPoolRejournal.Duration
+	vm.ethConfig.TxPool.Snapshot = vm.config.TxPoolSnapshot
+	vm.ethConfig.TxPool.SnapshotInterval = vm.config.TxPoolSnapshotInterval.Duration
 	vm.ethConfig.TxPool.PriceLimit = vm.config.TxPoolPriceLimit
 	vm.ethConfig.TxPool.PriceBump = vm.config.TxPoolPriceBump
 	vm.ethConfig.TxPool.AccountSlots = vm.config.TxPoolAccountSlots
 	vm.ethConfig.TxPool.GlobalSlots = vm.config.TxPoolGlobalSlots
 	vm.ethConfig.TxPool.AccountQueue = vm.config.TxPoolAccountQueue
 	vm.ethConfig.TxPool.GlobalQueue = vm.config.TxPoolGlobalQueue
+	vm.ethConfig.TxPool.SenderTxRate = vm.config.TxPoolSenderTxRate
+	vm.ethConfig.TxPool.SenderGasRate = vm.config.TxPoolSenderGasRate
+	vm.ethConfig.TxPool.PeerTxRate = vm.config.TxPoolPeerTxRate
+	vm.ethConfig.TxPool.PeerGasRate = vm.config.TxPoolPeerGasRate
+	vm.ethConfig.TxPool.RateLimitBurst = vm.config.TxPoolRateLimitBurst.Duration
+	vm.ethConfig.TxPool.SpamScoreHalfLife = vm.config.TxPoolSpamScoreHalfLife.Duration
 
 	vm.ethConfig.AllowUnfinalizedQueries = vm.config.AllowUnfinalizedQueries
 	vm.ethConfig.AllowUnprotectedTxs = vm.config.AllowUnprotectedTxs
@@ -442,6 +477,10 @@ func (vm *VM) Initialize(
 		log.Info("Config has not specified any coinbase address. Defaulting to the blackhole address.")
 		vm.ethConfig.Miner.Etherbase = constants.BlackholeAddr
 	}
+	vm.ethConfig.Miner.TxOrderingPolicy, err = miner.NewTxOrderingPolicy(vm.config.TxOrderingPolicy, vm.config.TxOrderingPrioritySenders)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction ordering policy: %w", err)
+	}
 
 	vm.chainConfig = g.Config
 	vm.networkID = vm.ethConfig.NetworkId
@@ -467,7 +506,8 @@ func (vm *VM) Initialize(
 	vm.client = peer.NewNetworkClient(vm.Network)
 
 	// initialize warp backend
-	vm.warpBackend = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm, vm.warpDB, warpSignatureCacheSize)
+	vm.warpSignatureStore = aggregator.NewSignatureStore(prefixdb.New(warpSignaturesPrefix, vm.warpDB))
+	vm.warpBackend = warp.NewBackend(vm.ctx.NetworkID, vm.ctx.ChainID, vm.ctx.WarpSigner, vm, vm.warpDB, vm.warpSignatureStore, warpSignatureCacheSize)
 
 	// clear warpdb on initialization if config enabled
 	if vm.config.PruneWarpDB {
@@ -476,6 +516,13 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	if vm.config.WarpRelayerEnabled {
+		vm.warpRelayer, err = vm.newWarpRelayer()
+		if err != nil {
+			return fmt.Errorf("failed to initialize warp relayer: %w", err)
+		}
+	}
+
 	if err := vm.initializeChain(lastAcceptedHash, vm.ethConfig); err != nil {
 		return err
 	}
@@ -674,6 +721,24 @@ func (vm *VM) initBlockBuilding() error {
 		vm.shutdownWg.Done()
 	}()
 
+	if vm.warpRelayer != nil {
+		vm.shutdownWg.Add(1)
+		go func() {
+			vm.warpRelayer.Start(ctx)
+			vm.shutdownWg.Done()
+		}()
+	}
+
+	if retentionPolicy := vm.config.warpRetentionPolicy(); retentionPolicy.Enabled() {
+		vm.shutdownWg.Add(1)
+		go func() {
+			warp.RunPruning(ctx, vm.warpBackend, retentionPolicy, vm.config.WarpPruningFrequency.Duration, func() uint64 {
+				return vm.blockChain.LastAcceptedBlock().NumberU64()
+			})
+			vm.shutdownWg.Done()
+		}()
+	}
+
 	var txGossipHandler p2p.Handler
 
 	txGossipHandler, err = gossip.NewHandler[*GossipTx](txPool, txGossipHandlerConfig, vm.sdkMetrics)
@@ -730,7 +795,11 @@ func (vm *VM) setAppRequestHandlers() {
 		},
 	)
 
-	networkHandler := newNetworkHandler(vm.blockChain, vm.chaindb, evmTrieDB, vm.warpBackend, vm.networkCodec)
+	var warpThrottler p2p.Throttler
+	if vm.config.WarpSignatureRequestThrottlingLimit > 0 {
+		warpThrottler = p2p.NewSlidingWindowThrottler(vm.config.WarpSignatureRequestThrottlingPeriod.Duration, vm.config.WarpSignatureRequestThrottlingLimit)
+	}
+	networkHandler := newNetworkHandler(vm.blockChain, vm.chaindb, evmTrieDB, vm.warpBackend, warpThrottler, vm.config.MaxActiveWarpSignatureRequests, vm.networkCodec)
 	vm.Network.SetRequestHandler(networkHandler)
 }
 
@@ -927,7 +996,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 
 	if vm.config.WarpAPIEnabled {
 		validatorsState := warpValidators.NewState(vm.ctx)
-		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SubnetID, vm.ctx.ChainID, validatorsState, vm.warpBackend, vm.client)); err != nil {
+		if err := handler.RegisterName("warp", warp.NewAPI(vm.ctx.NetworkID, vm.ctx.SubnetID, vm.ctx.ChainID, validatorsState, vm.warpBackend, vm.client, vm.warpSignatureStore)); err != nil {
 			return nil, err
 		}
 		enabledAPIs = append(enabledAPIs, "warp")
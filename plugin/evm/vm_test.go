@@ -2148,7 +2148,7 @@ func TestBuildAllowListActivationBlock(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	role := deployerallowlist.GetContractDeployerAllowListStatus(genesisState, testEthAddrs[0])
+	role := deployerallowlist.GetContractDeployerAllowListStatus(genesisState, testEthAddrs[0], 0)
 	if role != allowlist.NoRole {
 		t.Fatalf("Expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	}
@@ -2178,7 +2178,7 @@ func TestBuildAllowListActivationBlock(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	role = deployerallowlist.GetContractDeployerAllowListStatus(blkState, testEthAddrs[0])
+	role = deployerallowlist.GetContractDeployerAllowListStatus(blkState, testEthAddrs[0], 0)
 	if role != allowlist.AdminRole {
 		t.Fatalf("Expected allow list status to be set role %s, but found: %s", allowlist.AdminRole, role)
 	}
@@ -2237,16 +2237,16 @@ func TestTxAllowListSuccessfulTx(t *testing.T) {
 	}
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0])
+	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0], 0)
 	if role != allowlist.AdminRole {
 		t.Fatalf("Expected allow list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
 	}
-	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1])
+	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1], 0)
 	if role != allowlist.NoRole {
 		t.Fatalf("Expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	}
 	// Should not be a manager role because DUpgrade has not activated yet
-	role = txallowlist.GetTxAllowListStatus(genesisState, managerAddress)
+	role = txallowlist.GetTxAllowListStatus(genesisState, managerAddress, 0)
 	require.Equal(t, allowlist.NoRole, role)
 
 	// Submit a successful transaction
@@ -2314,9 +2314,9 @@ func TestTxAllowListSuccessfulTx(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that address 0 is admin and address 1 is manager
-	role = txallowlist.GetTxAllowListStatus(blkState, testEthAddrs[0])
+	role = txallowlist.GetTxAllowListStatus(blkState, testEthAddrs[0], 0)
 	require.Equal(t, allowlist.AdminRole, role)
-	role = txallowlist.GetTxAllowListStatus(blkState, managerAddress)
+	role = txallowlist.GetTxAllowListStatus(blkState, managerAddress, 0)
 	require.Equal(t, allowlist.ManagerRole, role)
 
 	vm.clock.Set(vm.clock.Time().Add(2 * time.Second)) // add 2 seconds for gas fee to adjust
@@ -2454,11 +2454,11 @@ func TestTxAllowListDisablePrecompile(t *testing.T) {
 	}
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0])
+	role := txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[0], 0)
 	if role != allowlist.AdminRole {
 		t.Fatalf("Expected allow list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
 	}
-	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1])
+	role = txallowlist.GetTxAllowListStatus(genesisState, testEthAddrs[1], 0)
 	if role != allowlist.NoRole {
 		t.Fatalf("Expected allow list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	}
@@ -2565,11 +2565,11 @@ func TestFeeManagerChangeFee(t *testing.T) {
 	}
 
 	// Check that address 0 is whitelisted and address 1 is not
-	role := feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[0])
+	role := feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[0], 0)
 	if role != allowlist.AdminRole {
 		t.Fatalf("Expected fee manager list status to be set to admin: %s, but found: %s", allowlist.AdminRole, role)
 	}
-	role = feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[1])
+	role = feemanager.GetFeeManagerStatus(genesisState, testEthAddrs[1], 0)
 	if role != allowlist.NoRole {
 		t.Fatalf("Expected fee manager list status to be set to no role: %s, but found: %s", allowlist.NoRole, role)
 	}
@@ -3278,7 +3278,7 @@ func TestMessageSignatureRequestsToVM(t *testing.T) {
 	require.NoError(t, err)
 
 	// Add the known message and get its signature to confirm.
-	err = vm.warpBackend.AddMessage(warpMessage)
+	err = vm.warpBackend.AddMessage(warpMessage, 0, 0)
 	require.NoError(t, err)
 	signature, err := vm.warpBackend.GetMessageSignature(warpMessage.ID())
 	require.NoError(t, err)
@@ -0,0 +1,71 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/warp"
+	"github.com/ava-labs/subnet-evm/warp/relayer"
+	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newWarpRelayer creates the warp relayer from the VM config. The relayer aggregates
+// signatures from the validators of this subnet and submits relay transactions to the
+// configured destination chain RPC.
+func (vm *VM) newWarpRelayer() (*relayer.Relayer, error) {
+	privateKey, err := crypto.LoadECDSA(vm.config.WarpRelayerPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load warp relayer private key: %w", err)
+	}
+	client, err := ethclient.Dial(vm.config.WarpRelayerDestinationRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial warp relayer destination rpc: %w", err)
+	}
+
+	quorumNumerator := vm.config.WarpRelayerQuorumNumerator
+	if quorumNumerator == 0 {
+		quorumNumerator = params.WarpDefaultQuorumNumerator
+	}
+	validatorsState := warpValidators.NewState(vm.ctx)
+	aggregate := func(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64) (*avalancheWarp.Message, error) {
+		result, err := warp.AggregateSignatures(ctx, validatorsState, vm.client, vm.warpSignatureStore, vm.ctx.SubnetID, unsignedMessage, quorumNum)
+		if err != nil {
+			return nil, err
+		}
+		return result.Message, nil
+	}
+
+	return relayer.New(
+		relayer.Config{
+			DestinationAddress: vm.config.WarpRelayerDestinationAddress,
+			PrivateKey:         privateKey,
+			SourceAddresses:    vm.config.WarpRelayerSourceAddresses,
+			QuorumNumerator:    quorumNumerator,
+			GasLimit:           vm.config.WarpRelayerGasLimit,
+			MaxAttempts:        vm.config.WarpRelayerMaxAttempts,
+		},
+		vm.warpBackend,
+		aggregate,
+		client,
+		vm.warpRelayerDB,
+		vm.warpRelayerProgressDB,
+	)
+}
+
+// warpMessageWriter returns where warp messages are added when their block is accepted:
+// the warp relayer if enabled, which adds them to the warp backend before queueing them
+// for relay, or the warp backend otherwise.
+func (vm *VM) warpMessageWriter() precompileconfig.WarpMessageWriter {
+	if vm.warpRelayer != nil {
+		return vm.warpRelayer
+	}
+	return vm.warpBackend
+}
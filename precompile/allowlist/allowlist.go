@@ -6,6 +6,7 @@ package allowlist
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/vmerrs"
@@ -17,16 +18,22 @@ import (
 // in the storage trie.
 
 const (
-	SetAdminFuncKey      = "setAdmin"
-	SetManagerFuncKey    = "setManager"
-	SetEnabledFuncKey    = "setEnabled"
-	SetNoneFuncKey       = "setNone"
-	ReadAllowListFuncKey = "readAllowList"
+	SetAdminFuncKey             = "setAdmin"
+	SetManagerFuncKey           = "setManager"
+	SetEnabledFuncKey           = "setEnabled"
+	SetNoneFuncKey              = "setNone"
+	ReadAllowListFuncKey        = "readAllowList"
+	SetManagerWithExpiryFuncKey = "setManagerWithExpiry"
+	SetEnabledWithExpiryFuncKey = "setEnabledWithExpiry"
+	ReadAllowListExpiryFuncKey  = "readAllowListExpiry"
 
-	ModifyAllowListGasCost = contract.WriteGasCostPerSlot
-	ReadAllowListGasCost   = contract.ReadGasCostPerSlot
+	ModifyAllowListGasCost           = contract.WriteGasCostPerSlot
+	ModifyAllowListWithExpiryGasCost = 2 * contract.WriteGasCostPerSlot // write role and expiry slots
+	ReadAllowListGasCost             = contract.ReadGasCostPerSlot
+	ReadAllowListExpiryGasCost       = contract.ReadGasCostPerSlot
 
-	allowListInputLen = common.HashLength
+	allowListInputLen           = common.HashLength
+	allowListWithExpiryInputLen = common.HashLength + common.HashLength
 )
 
 var (
@@ -38,28 +45,67 @@ var (
 		ReadAllowListFuncKey,
 	}
 
+	// AllowListExpiryFuncKeys are the optional allow list functions for managing expiring roles.
+	AllowListExpiryFuncKeys = []string{
+		SetManagerWithExpiryFuncKey,
+		SetEnabledWithExpiryFuncKey,
+		ReadAllowListExpiryFuncKey,
+	}
+
 	// AllowList function signatures
 	setAdminSignature      = contract.CalculateFunctionSelector("setAdmin(address)")
 	setManagerSignature    = contract.CalculateFunctionSelector("setManager(address)")
 	setEnabledSignature    = contract.CalculateFunctionSelector("setEnabled(address)")
 	setNoneSignature       = contract.CalculateFunctionSelector("setNone(address)")
 	readAllowListSignature = contract.CalculateFunctionSelector("readAllowList(address)")
+
+	setManagerWithExpirySignature = contract.CalculateFunctionSelector("setManagerWithExpiry(address,uint256)")
+	setEnabledWithExpirySignature = contract.CalculateFunctionSelector("setEnabledWithExpiry(address,uint256)")
+	readAllowListExpirySignature  = contract.CalculateFunctionSelector("readAllowListExpiry(address)")
+
 	// Error returned when an invalid write is attempted
 	ErrCannotModifyAllowList = errors.New("cannot modify allow list")
+	// Error returned when a role is granted with an expiry that is not in the future
+	ErrInvalidRoleExpiry = errors.New("invalid role expiry")
+
+	// roleExpiryKeyPrefix is written over the leading (zero) bytes of an address hash
+	// to derive the slot holding the expiry of the role of that address.
+	roleExpiryKeyPrefix = []byte("roleExpiry")
 )
 
 // GetAllowListStatus returns the allow list role of [address] for the precompile
-// at [precompileAddr]
-func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address) Role {
+// at [precompileAddr] as of [timestamp]. A role granted with an expiry is reported
+// as NoRole once [timestamp] reaches the expiry.
+func GetAllowListStatus(state contract.StateDB, precompileAddr common.Address, address common.Address, timestamp uint64) Role {
 	// Generate the state key for [address]
 	addressKey := address.Hash()
-	return Role(state.GetState(precompileAddr, addressKey))
+	role := Role(state.GetState(precompileAddr, addressKey))
+	if role.IsNoRole() {
+		return role
+	}
+	if expiry := GetAllowListExpiry(state, precompileAddr, address); expiry != 0 && timestamp >= expiry {
+		return NoRole
+	}
+	return role
+}
+
+// GetAllowListExpiry returns the timestamp at which the role of [address] for the
+// precompile at [precompileAddr] expires, or 0 if the role does not expire.
+func GetAllowListExpiry(state contract.StateDB, precompileAddr common.Address, address common.Address) uint64 {
+	return state.GetState(precompileAddr, roleExpiryKey(address)).Big().Uint64()
 }
 
 // SetAllowListRole sets the permissions of [address] to [role] for the precompile
-// at [precompileAddr].
+// at [precompileAddr]. The role does not expire.
 // assumes [role] has already been verified as valid.
 func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.Address, role Role) {
+	SetAllowListRoleWithExpiry(stateDB, precompileAddr, address, role, 0)
+}
+
+// SetAllowListRoleWithExpiry sets the permissions of [address] to [role] for the precompile
+// at [precompileAddr] until [expiry]. An [expiry] of 0 means the role never expires.
+// assumes [role] has already been verified as valid.
+func SetAllowListRoleWithExpiry(stateDB contract.StateDB, precompileAddr, address common.Address, role Role, expiry uint64) {
 	// Generate the state key for [address]
 	addressKey := address.Hash()
 	// Assign [role] to the address
@@ -68,6 +114,20 @@ func SetAllowListRole(stateDB contract.StateDB, precompileAddr, address common.A
 	// conflicts with the same slot [role] is stored.
 	// Precompile implementations must use a different key than [addressKey]
 	stateDB.SetState(precompileAddr, addressKey, common.Hash(role))
+	// Only touch the expiry slot if there is something to write or clear, so that
+	// setting a permanent role costs no more storage than before expiries existed.
+	expiryKey := roleExpiryKey(address)
+	if expiry != 0 || stateDB.GetState(precompileAddr, expiryKey) != (common.Hash{}) {
+		stateDB.SetState(precompileAddr, expiryKey, common.BigToHash(new(big.Int).SetUint64(expiry)))
+	}
+}
+
+// roleExpiryKey returns the storage key holding the role expiry of [address].
+// Precompile implementations must not use keys starting with [roleExpiryKeyPrefix].
+func roleExpiryKey(address common.Address) common.Hash {
+	key := address.Hash()
+	copy(key[:], roleExpiryKeyPrefix)
+	return key
 }
 
 // PackModifyAllowList packs [address] and [role] into the appropriate arguments for modifying the allow list.
@@ -102,9 +162,40 @@ func PackReadAllowList(address common.Address) []byte {
 	return input
 }
 
+// PackModifyAllowListWithExpiry packs [address], [role] and [expiry] into the appropriate arguments for
+// granting an expiring role. Only [EnabledRole] and [ManagerRole] can be granted with an expiry.
+func PackModifyAllowListWithExpiry(address common.Address, role Role, expiry uint64) ([]byte, error) {
+	// function selector (4 bytes) + hash for address + hash for expiry
+	input := make([]byte, contract.SelectorLen+allowListWithExpiryInputLen)
+
+	var selector []byte
+	switch role {
+	case ManagerRole:
+		selector = setManagerWithExpirySignature
+	case EnabledRole:
+		selector = setEnabledWithExpirySignature
+	default:
+		return nil, fmt.Errorf("cannot pack modify list with expiry input with invalid role: %s", role)
+	}
+
+	err := contract.PackOrderedHashesWithSelector(input, selector, []common.Hash{
+		address.Hash(),
+		common.BigToHash(new(big.Int).SetUint64(expiry)),
+	})
+	return input, err
+}
+
+// PackReadAllowListExpiry packs [address] into the input data to the read allow list expiry function
+func PackReadAllowListExpiry(address common.Address) []byte {
+	input := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	input = append(input, readAllowListExpirySignature...)
+	input = append(input, address.Hash().Bytes()...)
+	return input
+}
+
 // createAllowListRoleSetter returns an execution function for setting the allow list status of the input address argument to [role].
-// This execution function is speciifc to [precompileAddr].
-func createAllowListRoleSetter(precompileAddr common.Address, role Role) contract.RunStatefulPrecompileFunc {
+// This execution function is speciifc to [precompileAddr] and is called with [selector].
+func createAllowListRoleSetter(precompileAddr common.Address, role Role, selector []byte) contract.RunStatefulPrecompileFunc {
 	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
 		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListGasCost); err != nil {
 			return nil, 0, err
@@ -121,20 +212,81 @@ func createAllowListRoleSetter(precompileAddr common.Address, role Role) contrac
 		}
 
 		stateDB := evm.GetStateDB()
+		timestamp := evm.GetBlockContext().Timestamp()
 
 		// Verify that the caller is an admin with permission to modify the allow list
-		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr)
+		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr, timestamp)
 		// Verify that the address we are trying to modify has a status that allows it to be modified
-		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress)
+		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress, timestamp)
 		if !callerStatus.CanModify(modifyStatus, role) {
 			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
 		}
+		execute, remainingGas, err := ApproveAdminAction(evm, precompileAddr, callerAddr, callerStatus, selector, input, remainingGas)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		if !execute {
+			return []byte{}, remainingGas, nil
+		}
+		if remainingGas, err = trackRoleChange(evm, precompileAddr, callerAddr, modifyAddress, modifyStatus, role, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
 		SetAllowListRole(stateDB, precompileAddr, modifyAddress, role)
 		// Return an empty output and the remaining gas
 		return []byte{}, remainingGas, nil
 	}
 }
 
+// createAllowListRoleSetterWithExpiry returns an execution function for setting the allow list status of the input
+// address argument to [role] until the input expiry timestamp argument.
+// This execution function is specific to [precompileAddr] and is called with [selector].
+func createAllowListRoleSetterWithExpiry(precompileAddr common.Address, role Role, selector []byte) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ModifyAllowListWithExpiryGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListWithExpiryInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for modifying allow list with expiry: %d", len(input))
+		}
+
+		modifyAddress := common.BytesToAddress(contract.PackedHash(input, 0))
+		expiryBig := new(big.Int).SetBytes(contract.PackedHash(input, 1))
+
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		stateDB := evm.GetStateDB()
+		timestamp := evm.GetBlockContext().Timestamp()
+
+		if !expiryBig.IsUint64() || expiryBig.Uint64() <= timestamp {
+			return nil, remainingGas, fmt.Errorf("%w: expiry %s must be after block timestamp %d", ErrInvalidRoleExpiry, expiryBig, timestamp)
+		}
+
+		// Verify that the caller is an admin with permission to modify the allow list
+		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr, timestamp)
+		// Verify that the address we are trying to modify has a status that allows it to be modified
+		modifyStatus := GetAllowListStatus(stateDB, precompileAddr, modifyAddress, timestamp)
+		if !callerStatus.CanModify(modifyStatus, role) {
+			return nil, remainingGas, fmt.Errorf("%w: modify address: %s, from role: %s, to role: %s", ErrCannotModifyAllowList, callerAddr, modifyStatus, role)
+		}
+		execute, remainingGas, err := ApproveAdminAction(evm, precompileAddr, callerAddr, callerStatus, selector, input, remainingGas)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		if !execute {
+			return []byte{}, remainingGas, nil
+		}
+		if remainingGas, err = trackRoleChange(evm, precompileAddr, callerAddr, modifyAddress, modifyStatus, role, remainingGas); err != nil {
+			return nil, remainingGas, err
+		}
+		SetAllowListRoleWithExpiry(stateDB, precompileAddr, modifyAddress, role, expiryBig.Uint64())
+		// Return an empty output and the remaining gas
+		return []byte{}, remainingGas, nil
+	}
+}
+
 // createReadAllowList returns an execution function that reads the allow list for the given [precompileAddr].
 // The execution function parses the input into a single address and returns the 32 byte hash that specifies the
 // designated role of that address
@@ -149,12 +301,31 @@ func createReadAllowList(precompileAddr common.Address) contract.RunStatefulPrec
 		}
 
 		readAddress := common.BytesToAddress(input)
-		role := GetAllowListStatus(evm.GetStateDB(), precompileAddr, readAddress)
+		role := GetAllowListStatus(evm.GetStateDB(), precompileAddr, readAddress, evm.GetBlockContext().Timestamp())
 		roleBytes := common.Hash(role).Bytes()
 		return roleBytes, remainingGas, nil
 	}
 }
 
+// createReadAllowListExpiry returns an execution function that reads the role expiry for the given [precompileAddr].
+// The execution function parses the input into a single address and returns the 32 byte encoded timestamp at
+// which the role of that address expires, or zero if the role does not expire.
+func createReadAllowListExpiry(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListExpiryGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for read allow list expiry: %d", len(input))
+		}
+
+		readAddress := common.BytesToAddress(input)
+		expiry := GetAllowListExpiry(evm.GetStateDB(), precompileAddr, readAddress)
+		return common.BigToHash(new(big.Int).SetUint64(expiry)).Bytes(), remainingGas, nil
+	}
+}
+
 // CreateAllowListPrecompile returns a StatefulPrecompiledContract with R/W control of an allow list at [precompileAddr]
 func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulPrecompiledContract {
 	// Construct the contract with no fallback function.
@@ -169,13 +340,27 @@ func CreateAllowListPrecompile(precompileAddr common.Address) contract.StatefulP
 }
 
 func CreateAllowListFunctions(precompileAddr common.Address) []*contract.StatefulPrecompileFunction {
-	setAdmin := contract.NewStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AdminRole))
-	setManager := contract.NewStatefulPrecompileFunctionWithActivator(setManagerSignature, createAllowListRoleSetter(precompileAddr, ManagerRole), isManagerRoleActivated)
-	setEnabled := contract.NewStatefulPrecompileFunction(setEnabledSignature, createAllowListRoleSetter(precompileAddr, EnabledRole))
-	setNone := contract.NewStatefulPrecompileFunction(setNoneSignature, createAllowListRoleSetter(precompileAddr, NoRole))
+	setAdmin := contract.NewStatefulPrecompileFunction(setAdminSignature, createAllowListRoleSetter(precompileAddr, AdminRole, setAdminSignature))
+	setManager := contract.NewStatefulPrecompileFunctionWithActivator(setManagerSignature, createAllowListRoleSetter(precompileAddr, ManagerRole, setManagerSignature), isManagerRoleActivated)
+	setEnabled := contract.NewStatefulPrecompileFunction(setEnabledSignature, createAllowListRoleSetter(precompileAddr, EnabledRole, setEnabledSignature))
+	setNone := contract.NewStatefulPrecompileFunction(setNoneSignature, createAllowListRoleSetter(precompileAddr, NoRole, setNoneSignature))
 	read := contract.NewStatefulPrecompileFunction(readAllowListSignature, createReadAllowList(precompileAddr))
+	// Expiring roles are activated alongside the manager role.
+	setManagerWithExpiry := contract.NewStatefulPrecompileFunctionWithActivator(setManagerWithExpirySignature, createAllowListRoleSetterWithExpiry(precompileAddr, ManagerRole, setManagerWithExpirySignature), isManagerRoleActivated)
+	setEnabledWithExpiry := contract.NewStatefulPrecompileFunctionWithActivator(setEnabledWithExpirySignature, createAllowListRoleSetterWithExpiry(precompileAddr, EnabledRole, setEnabledWithExpirySignature), isManagerRoleActivated)
+	readExpiry := contract.NewStatefulPrecompileFunctionWithActivator(readAllowListExpirySignature, createReadAllowListExpiry(precompileAddr), isManagerRoleActivated)
+	readCount := contract.NewStatefulPrecompileFunctionWithActivator(readAllowListCountSignature, createReadAllowListCount(precompileAddr), isAllowListEnumerationActivated)
+	readMember := contract.NewStatefulPrecompileFunctionWithActivator(readAllowListMemberSignature, createReadAllowListMember(precompileAddr), isAllowListEnumerationActivated)
+	setThreshold := contract.NewStatefulPrecompileFunctionWithActivator(setAdminThresholdSignature, createSetAdminThreshold(precompileAddr), isAdminThresholdActivated)
+	readThreshold := contract.NewStatefulPrecompileFunctionWithActivator(readAdminThresholdSignature, createReadAdminThreshold(precompileAddr), isAdminThresholdActivated)
+	readApprovals := contract.NewStatefulPrecompileFunctionWithActivator(readProposalApprovalsSignature, createReadProposalApprovals(precompileAddr), isAdminThresholdActivated)
 
-	return []*contract.StatefulPrecompileFunction{setAdmin, setManager, setEnabled, setNone, read}
+	return []*contract.StatefulPrecompileFunction{
+		setAdmin, setManager, setEnabled, setNone, read,
+		setManagerWithExpiry, setEnabledWithExpiry, readExpiry,
+		readCount, readMember,
+		setThreshold, readThreshold, readApprovals,
+	}
 }
 
 func isManagerRoleActivated(evm contract.AccessibleState) bool {
@@ -10,7 +10,9 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/modules"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -65,6 +67,66 @@ func TestAllowListRun(t *testing.T) {
 	RunPrecompileWithAllowListTests(t, dummyModule, state.NewTestStateDB, nil)
 }
 
+func TestAllowListRoleSetEvent(t *testing.T) {
+	dummyModule := modules.Module{
+		Address:      dummyAddr,
+		Contract:     CreateAllowListPrecompile(dummyAddr),
+		Configurator: &dummyConfigurator{},
+		ConfigKey:    "dummy",
+	}
+	test := testutils.PrecompileTest{
+		Caller:     TestAdminAddr,
+		BeforeHook: SetDefaultRoles(dummyAddr),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackModifyAllowList(TestEnabledAddr, ManagerRole)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, stateDB contract.StateDB) {
+			logs := stateDB.(*state.StateDB).Logs()
+			require.Len(t, logs, 1)
+			topics, data := PackRoleSetEvent(ManagerRole, TestEnabledAddr, TestAdminAddr, EnabledRole)
+			require.Equal(t, dummyAddr, logs[0].Address)
+			require.Equal(t, topics, logs[0].Topics)
+			require.Equal(t, data, logs[0].Data)
+		},
+	}
+	test.Run(t, dummyModule, state.NewTestStateDB(t))
+}
+
+func TestTrackAllowListMember(t *testing.T) {
+	stateDB := state.NewTestStateDB(t)
+	addrs := []common.Address{{1}, {2}, {3}}
+	for _, addr := range addrs {
+		TrackAllowListMember(stateDB, dummyAddr, addr, NoRole, EnabledRole)
+	}
+	// Tracking a listed member again does not duplicate it.
+	TrackAllowListMember(stateDB, dummyAddr, addrs[1], EnabledRole, AdminRole)
+	require.Equal(t, addrs, GetAllowListMembers(stateDB, dummyAddr))
+	require.EqualValues(t, 1, GetAdminCount(stateDB, dummyAddr))
+
+	// Removing a member moves the last member into its place.
+	TrackAllowListMember(stateDB, dummyAddr, addrs[0], EnabledRole, NoRole)
+	require.Equal(t, []common.Address{addrs[2], addrs[1]}, GetAllowListMembers(stateDB, dummyAddr))
+
+	// Removing the last member and an unlisted address.
+	TrackAllowListMember(stateDB, dummyAddr, addrs[1], AdminRole, NoRole)
+	TrackAllowListMember(stateDB, dummyAddr, addrs[0], NoRole, NoRole)
+	require.Equal(t, []common.Address{addrs[2]}, GetAllowListMembers(stateDB, dummyAddr))
+	require.Zero(t, GetAdminCount(stateDB, dummyAddr))
+
+	TrackAllowListMember(stateDB, dummyAddr, addrs[2], EnabledRole, NoRole)
+	require.Zero(t, GetAllowListMemberCount(stateDB, dummyAddr))
+	// An admin that was not listed yet is counted once it is tracked.
+	TrackAllowListMember(stateDB, dummyAddr, addrs[0], AdminRole, AdminRole)
+	require.Equal(t, []common.Address{addrs[0]}, GetAllowListMembers(stateDB, dummyAddr))
+	require.EqualValues(t, 1, GetAdminCount(stateDB, dummyAddr))
+}
+
 func BenchmarkAllowList(b *testing.B) {
 	dummyModule := modules.Module{
 		Address:      dummyAddr,
@@ -11,39 +11,84 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var ErrCannotAddManagersBeforeDUpgrade = fmt.Errorf("cannot add managers before DUpgrade")
+var (
+	ErrCannotAddManagersBeforeDUpgrade       = fmt.Errorf("cannot add managers before DUpgrade")
+	ErrCannotSetAdminThresholdBeforeDUpgrade = fmt.Errorf("cannot set admin threshold before DUpgrade")
+)
 
 // AllowListConfig specifies the initial set of addresses with Admin or Enabled roles.
 type AllowListConfig struct {
 	AdminAddresses   []common.Address `json:"adminAddresses,omitempty"`   // initial admin addresses
 	ManagerAddresses []common.Address `json:"managerAddresses,omitempty"` // initial manager addresses
 	EnabledAddresses []common.Address `json:"enabledAddresses,omitempty"` // initial enabled addresses
+	AdminThreshold   uint64           `json:"adminThreshold,omitempty"`   // admin approvals required by admin actions, threshold approval mode is off if 0 or 1
 }
 
 // Configure initializes the address space of [precompileAddr] by initializing the role of each of
 // the addresses in [AllowListAdmins].
 func (c *AllowListConfig) Configure(chainConfig precompileconfig.ChainConfig, precompileAddr common.Address, state contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	// Members configured once enumeration is activated are tracked like members added by the role setters.
+	track := chainConfig.IsDUpgrade(blockContext.Timestamp())
+	setRole := func(address common.Address, role Role) {
+		oldRole := GetAllowListStatus(state, precompileAddr, address, blockContext.Timestamp())
+		SetAllowListRole(state, precompileAddr, address, role)
+		if track {
+			TrackAllowListMember(state, precompileAddr, address, oldRole, role)
+		}
+	}
 	for _, enabledAddr := range c.EnabledAddresses {
-		SetAllowListRole(state, precompileAddr, enabledAddr, EnabledRole)
+		setRole(enabledAddr, EnabledRole)
 	}
 	for _, adminAddr := range c.AdminAddresses {
-		SetAllowListRole(state, precompileAddr, adminAddr, AdminRole)
+		setRole(adminAddr, AdminRole)
 	}
 	// Verify() should have been called before Configure()
 	// so we know manager role is activated
 	for _, managerAddr := range c.ManagerAddresses {
-		SetAllowListRole(state, precompileAddr, managerAddr, ManagerRole)
+		setRole(managerAddr, ManagerRole)
+	}
+	// Verify() should have been called before Configure()
+	// so we know threshold approval mode is activated
+	if c.AdminThreshold != 0 {
+		SetAdminThreshold(state, precompileAddr, c.AdminThreshold)
 	}
 	return nil
 }
 
-// Equal returns true iff [other] has the same admins in the same order in its allow list.
+// MemberSeeder is implemented by the precompile configs embedding an [AllowListConfig].
+type MemberSeeder interface {
+	SeedAllowListMembers(state contract.StateDB, precompileAddr common.Address, timestamp uint64)
+}
+
+// SeedAllowListMembers adds the addresses configured by [c] that still hold a role in the allow list
+// of [precompileAddr] to its member table, if they are not listed yet. Members configured before the
+// DUpgrade are not tracked by [Configure], so this is applied when the DUpgrade activates enumeration.
+// Roles granted through the role setters before the DUpgrade cannot be recovered and stay unlisted
+// until they are set again.
+func (c *AllowListConfig) SeedAllowListMembers(state contract.StateDB, precompileAddr common.Address, timestamp uint64) {
+	for _, addresses := range [][]common.Address{c.EnabledAddresses, c.AdminAddresses, c.ManagerAddresses} {
+		for _, address := range addresses {
+			if isAllowListMember(state, precompileAddr, address) {
+				continue
+			}
+			role := GetAllowListStatus(state, precompileAddr, address, timestamp)
+			if role.IsNoRole() {
+				continue
+			}
+			TrackAllowListMember(state, precompileAddr, address, NoRole, role)
+		}
+	}
+}
+
+// Equal returns true iff [other] has the same admins in the same order in its allow list
+// and the same admin threshold.
 func (c *AllowListConfig) Equal(other *AllowListConfig) bool {
 	if other == nil {
 		return false
 	}
 
-	return areEqualAddressLists(c.AdminAddresses, other.AdminAddresses) &&
+	return c.AdminThreshold == other.AdminThreshold &&
+		areEqualAddressLists(c.AdminAddresses, other.AdminAddresses) &&
 		areEqualAddressLists(c.ManagerAddresses, other.ManagerAddresses) &&
 		areEqualAddressLists(c.EnabledAddresses, other.EnabledAddresses)
 }
@@ -108,5 +153,15 @@ func (c *AllowListConfig) Verify(chainConfig precompileconfig.ChainConfig, upgra
 		addressMap[managerAddr] = ManagerRole
 	}
 
+	if c.AdminThreshold != 0 {
+		if upgrade.Timestamp() != nil && !chainConfig.IsDUpgrade(*upgrade.Timestamp()) {
+			return ErrCannotSetAdminThresholdBeforeDUpgrade
+		}
+		// Admins set by this config must be able to reach the threshold.
+		if c.AdminThreshold > uint64(len(c.AdminAddresses)) {
+			return fmt.Errorf("admin threshold %d exceeds the number of admins %d", c.AdminThreshold, len(c.AdminAddresses))
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,255 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Allow list enumeration keeps every address holding a role in an index-addressed
+// member table, so that the full role table can be listed on-chain and over RPC
+// without scanning logs. Members are tracked, and RoleSet events emitted, only for
+// roles set once the DUpgrade is active. When the DUpgrade activates, the addresses
+// configured before it that still hold a role are added to the member table.
+
+const (
+	ReadAllowListCountFuncKey  = "readAllowListCount"
+	ReadAllowListMemberFuncKey = "readAllowListMember"
+
+	ReadAllowListCountGasCost  = contract.ReadGasCostPerSlot
+	ReadAllowListMemberGasCost = contract.ReadGasCostPerSlot
+
+	// TrackAllowListMemberGasCost covers the worst case of removing an admin from the
+	// middle of the member table: read the admin threshold and count, its index, the
+	// count and the last member, then move the last member into its place, clear the
+	// vacated slots and update the admin count.
+	TrackAllowListMemberGasCost = 5*contract.ReadGasCostPerSlot + 6*contract.WriteGasCostPerSlot
+	// RoleSetEventGasCost is the cost of emitting a RoleSet event with 4 topics and the old role as data.
+	RoleSetEventGasCost = contract.LogGas + 4*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+	// AllowListEnumerationGasCost is charged on top of the role setter cost once enumeration is active.
+	AllowListEnumerationGasCost = TrackAllowListMemberGasCost + RoleSetEventGasCost
+)
+
+var (
+	// AllowListEnumerationFuncKeys are the optional allow list functions for enumerating members.
+	AllowListEnumerationFuncKeys = []string{
+		ReadAllowListCountFuncKey,
+		ReadAllowListMemberFuncKey,
+	}
+
+	readAllowListCountSignature  = contract.CalculateFunctionSelector("readAllowListCount()")
+	readAllowListMemberSignature = contract.CalculateFunctionSelector("readAllowListMember(uint256)")
+
+	// RoleSetEventID is the topic of the RoleSet(uint256 indexed role, address indexed account,
+	// address indexed sender, uint256 oldRole) event emitted by every allow list role setter.
+	RoleSetEventID = crypto.Keccak256Hash([]byte("RoleSet(uint256,address,address,uint256)"))
+
+	// Error returned when reading a member past the end of the member table
+	ErrAllowListIndexOutOfBounds = errors.New("allow list member index out of bounds")
+	// Error returned when removing an admin would leave fewer admins than the admin threshold
+	ErrAdminCountBelowThreshold = errors.New("cannot remove admin below admin threshold")
+
+	// The member count and member table keys start with a non-zero prefix so they never collide
+	// with a role slot. The member index key prefix is written over the leading (zero) bytes of an
+	// address hash, like [roleExpiryKeyPrefix].
+	allowListCountKey           = common.BytesToHash(common.RightPadBytes([]byte("allowListCount"), common.HashLength))
+	allowListMemberKeyPrefix    = []byte("allowListMember")
+	allowListMemberIdxKeyPrefix = []byte("allowListIdx")
+)
+
+// GetAllowListMemberCount returns the number of tracked members of the allow list of the
+// precompile at [precompileAddr].
+func GetAllowListMemberCount(state contract.StateDB, precompileAddr common.Address) uint64 {
+	return state.GetState(precompileAddr, allowListCountKey).Big().Uint64()
+}
+
+// GetAllowListMember returns the tracked member of the allow list of the precompile at
+// [precompileAddr] at [index]. Indices are not stable: removing a member moves the last
+// member into its place.
+func GetAllowListMember(state contract.StateDB, precompileAddr common.Address, index uint64) common.Address {
+	return common.BytesToAddress(state.GetState(precompileAddr, allowListMemberKey(index)).Bytes())
+}
+
+// GetAllowListMembers returns all tracked members of the allow list of the precompile at [precompileAddr].
+// Members keep being listed after their role expires, until their role is explicitly removed.
+func GetAllowListMembers(state contract.StateDB, precompileAddr common.Address) []common.Address {
+	count := GetAllowListMemberCount(state, precompileAddr)
+	members := make([]common.Address, 0, count)
+	for i := uint64(0); i < count; i++ {
+		members = append(members, GetAllowListMember(state, precompileAddr, i))
+	}
+	return members
+}
+
+// isAllowListMember returns true if [address] is listed in the member table of the precompile at [precompileAddr].
+func isAllowListMember(state contract.StateDB, precompileAddr common.Address, address common.Address) bool {
+	return state.GetState(precompileAddr, allowListMemberIdxKey(address)) != (common.Hash{})
+}
+
+// TrackAllowListMember updates the member table of the precompile at [precompileAddr] after
+// [address] has been set from [oldRole] to [role]: addresses with a role are added if not yet
+// listed and addresses set to [NoRole] are removed. The admin count covers the listed admins.
+func TrackAllowListMember(state contract.StateDB, precompileAddr common.Address, address common.Address, oldRole Role, role Role) {
+	idxKey := allowListMemberIdxKey(address)
+	// The index slot holds the member index plus one, so that zero means not listed.
+	idx := state.GetState(precompileAddr, idxKey).Big().Uint64()
+	count := GetAllowListMemberCount(state, precompileAddr)
+
+	if wasCounted, isCounted := idx != 0 && oldRole.IsAdmin(), role.IsAdmin(); wasCounted != isCounted {
+		adminCount := GetAdminCount(state, precompileAddr)
+		if isCounted {
+			adminCount++
+		} else {
+			adminCount--
+		}
+		state.SetState(precompileAddr, adminCountKey, uint64ToHash(adminCount))
+	}
+
+	if !role.IsNoRole() {
+		if idx != 0 {
+			return
+		}
+		state.SetState(precompileAddr, allowListMemberKey(count), address.Hash())
+		state.SetState(precompileAddr, idxKey, uint64ToHash(count+1))
+		state.SetState(precompileAddr, allowListCountKey, uint64ToHash(count+1))
+		return
+	}
+
+	if idx == 0 {
+		return
+	}
+	// Move the last member into the slot of the removed member.
+	last := count - 1
+	if idx-1 != last {
+		lastMember := GetAllowListMember(state, precompileAddr, last)
+		state.SetState(precompileAddr, allowListMemberKey(idx-1), lastMember.Hash())
+		state.SetState(precompileAddr, allowListMemberIdxKey(lastMember), uint64ToHash(idx))
+	}
+	state.SetState(precompileAddr, allowListMemberKey(last), common.Hash{})
+	state.SetState(precompileAddr, idxKey, common.Hash{})
+	state.SetState(precompileAddr, allowListCountKey, uint64ToHash(last))
+}
+
+// PackRoleSetEvent packs the topics and data of a RoleSet event recording [sender] setting
+// [account] from [oldRole] to [role].
+func PackRoleSetEvent(role Role, account common.Address, sender common.Address, oldRole Role) ([]common.Hash, []byte) {
+	topics := []common.Hash{
+		RoleSetEventID,
+		common.Hash(role),
+		account.Hash(),
+		sender.Hash(),
+	}
+	return topics, common.Hash(oldRole).Bytes()
+}
+
+// PackReadAllowListCount packs the input data to the read allow list count function
+func PackReadAllowListCount() []byte {
+	input := make([]byte, 0, contract.SelectorLen)
+	return append(input, readAllowListCountSignature...)
+}
+
+// PackReadAllowListMember packs [index] into the input data to the read allow list member function
+func PackReadAllowListMember(index uint64) []byte {
+	input := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	input = append(input, readAllowListMemberSignature...)
+	input = append(input, uint64ToHash(index).Bytes()...)
+	return input
+}
+
+// allowListMemberKey returns the storage key holding the member at [index] of the member table.
+// Precompile implementations must not use keys starting with [allowListMemberKeyPrefix].
+func allowListMemberKey(index uint64) common.Hash {
+	var key common.Hash
+	copy(key[:], allowListMemberKeyPrefix)
+	binary.BigEndian.PutUint64(key[common.HashLength-8:], index)
+	return key
+}
+
+// allowListMemberIdxKey returns the storage key holding the member table index of [address].
+// Precompile implementations must not use keys starting with [allowListMemberIdxKeyPrefix].
+func allowListMemberIdxKey(address common.Address) common.Hash {
+	key := address.Hash()
+	copy(key[:], allowListMemberIdxKeyPrefix)
+	return key
+}
+
+func uint64ToHash(v uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(v))
+}
+
+// trackRoleChange records [callerAddr] setting [modifyAddress] from [oldRole] to [role] in the member
+// table and as a RoleSet event, charging [AllowListEnumerationGasCost], once enumeration is activated.
+// Removing an admin fails if it would leave fewer admins than the admin threshold.
+// Must only be called after the role change has been authorized.
+func trackRoleChange(evm contract.AccessibleState, precompileAddr, callerAddr, modifyAddress common.Address, oldRole, role Role, suppliedGas uint64) (uint64, error) {
+	if !isAllowListEnumerationActivated(evm) {
+		return suppliedGas, nil
+	}
+	remainingGas, err := contract.DeductGas(suppliedGas, AllowListEnumerationGasCost)
+	if err != nil {
+		return 0, err
+	}
+	stateDB := evm.GetStateDB()
+	if oldRole.IsAdmin() && !role.IsAdmin() {
+		threshold, adminCount := GetAdminThreshold(stateDB, precompileAddr), GetAdminCount(stateDB, precompileAddr)
+		if threshold > 1 && adminCount <= threshold {
+			return remainingGas, fmt.Errorf("%w: threshold %d, admins %d", ErrAdminCountBelowThreshold, threshold, adminCount)
+		}
+	}
+	TrackAllowListMember(stateDB, precompileAddr, modifyAddress, oldRole, role)
+	topics, data := PackRoleSetEvent(role, modifyAddress, callerAddr, oldRole)
+	stateDB.AddLog(precompileAddr, topics, data, evm.GetBlockContext().Number().Uint64())
+	return remainingGas, nil
+}
+
+// createReadAllowListCount returns an execution function that reads the number of tracked
+// members of the allow list for the given [precompileAddr].
+func createReadAllowListCount(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListCountGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != 0 {
+			return nil, remainingGas, fmt.Errorf("invalid input length for read allow list count: %d", len(input))
+		}
+
+		count := GetAllowListMemberCount(evm.GetStateDB(), precompileAddr)
+		return uint64ToHash(count).Bytes(), remainingGas, nil
+	}
+}
+
+// createReadAllowListMember returns an execution function that reads a tracked member of the
+// allow list for the given [precompileAddr]. The execution function parses the input into a
+// single index and returns the 32 byte encoded address of the member at that index.
+func createReadAllowListMember(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAllowListMemberGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for read allow list member: %d", len(input))
+		}
+
+		stateDB := evm.GetStateDB()
+		index := new(big.Int).SetBytes(input)
+		count := GetAllowListMemberCount(stateDB, precompileAddr)
+		if !index.IsUint64() || index.Uint64() >= count {
+			return nil, remainingGas, fmt.Errorf("%w: index %s, count %d", ErrAllowListIndexOutOfBounds, index, count)
+		}
+		return GetAllowListMember(stateDB, precompileAddr, index.Uint64()).Hash().Bytes(), remainingGas, nil
+	}
+}
+
+func isAllowListEnumerationActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
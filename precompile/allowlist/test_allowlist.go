@@ -4,6 +4,7 @@
 package allowlist
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/precompile/contract"
@@ -12,6 +13,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -21,6 +23,10 @@ var (
 	TestEnabledAddr = common.HexToAddress("0x0000000000000000000000000000000000000022")
 	TestNoRoleAddr  = common.HexToAddress("0x0000000000000000000000000000000000000033")
 	TestManagerAddr = common.HexToAddress("0x0000000000000000000000000000000000000044")
+
+	testSecondAdminAddr = common.HexToAddress("0x0000000000000000000000000000000000000055")
+
+	testExpiry = uint64(1_000)
 )
 
 func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.PrecompileTest {
@@ -35,11 +41,11 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, AdminRole, res)
 			},
 		},
@@ -52,11 +58,11 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, EnabledRole, res)
 			},
 		},
@@ -69,11 +75,11 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestEnabledAddr)
+				res := GetAllowListStatus(state, contractAddress, TestEnabledAddr, 0)
 				require.Equal(t, NoRole, res)
 			},
 		},
@@ -260,10 +266,10 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 				config.EXPECT().IsDUpgrade(gomock.Any()).Return(true).AnyTimes()
 				return config
 			}(),
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, ManagerRole, res)
 			},
 		},
@@ -276,12 +282,12 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			ExpectedErr: "",
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, NoRole, res)
 			},
 		},
@@ -294,12 +300,12 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			ExpectedErr: "",
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, EnabledRole, res)
 			},
 		},
@@ -374,11 +380,11 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 
 				return input
 			},
-			SuppliedGas: ModifyAllowListGasCost,
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
 			ReadOnly:    false,
 			ExpectedRes: []byte{},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr)
+				res := GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0)
 				require.Equal(t, NoRole, res)
 			},
 		},
@@ -497,6 +503,170 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			ReadOnly:    true,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"admin set enabled with expiry": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, EnabledRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost + AllowListEnumerationGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry-1))
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry))
+				require.Equal(t, testExpiry, GetAllowListExpiry(state, contractAddress, TestNoRoleAddr))
+			},
+		},
+		"admin set manager with expiry": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, ManagerRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost + AllowListEnumerationGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry-1))
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry))
+			},
+		},
+		"manager set enabled with expiry": {
+			Caller:     TestManagerAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, EnabledRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost + AllowListEnumerationGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry-1))
+			},
+		},
+		"manager set manager with expiry": {
+			Caller:     TestManagerAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, ManagerRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrCannotModifyAllowList.Error(),
+		},
+		"set enabled with past expiry": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, EnabledRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrInvalidRoleExpiry.Error(),
+		},
+		"set enabled with expiry readOnly": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowListWithExpiry(TestNoRoleAddr, EnabledRole, testExpiry)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListWithExpiryGasCost,
+			ReadOnly:          true,
+			ExpectedErr:       vmerrs.ErrWriteProtection.Error(),
+		},
+		"set admin clears expiry": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				SetAllowListRoleWithExpiry(state, contractAddress, TestNoRoleAddr, EnabledRole, testExpiry)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, AdminRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ModifyAllowListGasCost + AllowListEnumerationGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, testExpiry))
+				require.Zero(t, GetAllowListExpiry(state, contractAddress, TestNoRoleAddr))
+			},
+		},
+		"expired role cannot modify allow list": {
+			Caller: TestManagerAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetDefaultRoles(contractAddress)(t, state)
+				SetAllowListRoleWithExpiry(state, contractAddress, TestManagerAddr, ManagerRole, testExpiry)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SetupBlockContext: setTestTimestamp(testExpiry),
+			SuppliedGas:       ModifyAllowListGasCost,
+			ReadOnly:          false,
+			ExpectedErr:       ErrCannotModifyAllowList.Error(),
+		},
+		"read allow list expired role": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetAllowListRoleWithExpiry(state, contractAddress, TestEnabledAddr, EnabledRole, testExpiry)
+			},
+			Input:             PackReadAllowList(TestEnabledAddr),
+			SetupBlockContext: setTestTimestamp(testExpiry),
+			SuppliedGas:       ReadAllowListGasCost,
+			ReadOnly:          true,
+			ExpectedRes:       common.Hash(NoRole).Bytes(),
+		},
+		"read allow list expiry": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetAllowListRoleWithExpiry(state, contractAddress, TestEnabledAddr, EnabledRole, testExpiry)
+			},
+			Input:             PackReadAllowListExpiry(TestEnabledAddr),
+			SetupBlockContext: setTestTimestamp(testExpiry - 1),
+			SuppliedGas:       ReadAllowListExpiryGasCost,
+			ReadOnly:          true,
+			ExpectedRes:       common.BigToHash(new(big.Int).SetUint64(testExpiry)).Bytes(),
+		},
+		"read allow list expiry out of gas": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  SetDefaultRoles(contractAddress),
+			Input:       PackReadAllowListExpiry(TestEnabledAddr),
+			SuppliedGas: ReadAllowListExpiryGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
 		"initial config sets admins": {
 			Config: mkConfigWithAllowList(
 				module,
@@ -507,8 +677,8 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
-				require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr))
+				require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr, 0))
 			},
 		},
 		"initial config sets managers": {
@@ -521,8 +691,8 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
-				require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr))
+				require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr, 0))
 			},
 		},
 		"initial config sets enabled": {
@@ -535,10 +705,393 @@ func AllowListTests(t testing.TB, module modules.Module) map[string]testutils.Pr
 			SuppliedGas: 0,
 			ReadOnly:    false,
 			AfterHook: func(t testing.TB, state contract.StateDB) {
-				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestAdminAddr))
-				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestAdminAddr, 0))
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+			},
+		},
+		"initial config tracks members": {
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses:   []common.Address{TestAdminAddr},
+					EnabledAddresses: []common.Address{TestEnabledAddr},
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, []common.Address{TestEnabledAddr, TestAdminAddr}, GetAllowListMembers(state, contractAddress))
+			},
+		},
+		"admin set enabled tracks member": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, []common.Address{TestNoRoleAddr}, GetAllowListMembers(state, contractAddress))
+			},
+		},
+		"admin set no role removes member": {
+			Caller:     TestAdminAddr,
+			BeforeHook: setTrackedRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestAdminAddr, NoRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				// The last member takes the place of the removed member.
+				require.Equal(t, []common.Address{TestManagerAddr, TestEnabledAddr}, GetAllowListMembers(state, contractAddress))
+			},
+		},
+		"admin set enabled before enumeration activation": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				require.Zero(t, GetAllowListMemberCount(state, contractAddress))
+			},
+		},
+		"admin set enabled insufficient enumeration gas": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
 			},
+			SuppliedGas: ModifyAllowListGasCost + AllowListEnumerationGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"read allow list count": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setTrackedRoles(contractAddress),
+			Input:       PackReadAllowListCount(),
+			SuppliedGas: ReadAllowListCountGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(big.NewInt(3)).Bytes(),
 		},
+		"read allow list count before activation": {
+			Caller:     TestNoRoleAddr,
+			BeforeHook: setTrackedRoles(contractAddress),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			Input:       PackReadAllowListCount(),
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"read allow list member": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setTrackedRoles(contractAddress),
+			Input:       PackReadAllowListMember(1),
+			SuppliedGas: ReadAllowListMemberGasCost,
+			ReadOnly:    true,
+			ExpectedRes: TestEnabledAddr.Hash().Bytes(),
+		},
+		"read allow list member out of bounds": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setTrackedRoles(contractAddress),
+			Input:       PackReadAllowListMember(3),
+			SuppliedGas: ReadAllowListMemberGasCost,
+			ReadOnly:    true,
+			ExpectedErr: ErrAllowListIndexOutOfBounds.Error(),
+		},
+		"read allow list member out of gas": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setTrackedRoles(contractAddress),
+			Input:       PackReadAllowListMember(0),
+			SuppliedGas: ReadAllowListMemberGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"initial config sets admin threshold": {
+			Config: mkConfigWithAllowList(
+				module,
+				&AllowListConfig{
+					AdminAddresses: []common.Address{TestAdminAddr, testSecondAdminAddr},
+					AdminThreshold: 2,
+				},
+			),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.EqualValues(t, 2, GetAdminThreshold(state, contractAddress))
+			},
+		},
+		"admin set enabled below admin threshold": {
+			Caller:     TestAdminAddr,
+			BeforeHook: setThresholdRoles(contractAddress, 2),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost + ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				proposalID := ProposalID(state, contractAddress, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.EqualValues(t, 1, GetProposalApprovals(state, contractAddress, proposalID, 0))
+			},
+		},
+		"admin set enabled at admin threshold": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setThresholdRoles(contractAddress, 2)(t, state)
+				_, _, err := approveProposal(state, contractAddress, testSecondAdminAddr, AdminRole, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.NoError(t, err)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost + 2*ProposalApproverGasCost + AllowListEnumerationGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				// The executed proposal cannot be approved again.
+				proposalID := ProposalID(state, contractAddress, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.Zero(t, GetProposalApprovals(state, contractAddress, proposalID, 0))
+			},
+		},
+		"admin approves proposal twice": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setThresholdRoles(contractAddress, 2)(t, state)
+				_, _, err := approveProposal(state, contractAddress, TestAdminAddr, AdminRole, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.NoError(t, err)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrProposalAlreadyApproved.Error(),
+		},
+		"admin set enabled insufficient approval gas": {
+			Caller:     TestAdminAddr,
+			BeforeHook: setThresholdRoles(contractAddress, 2),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"manager set enabled below admin threshold": {
+			Caller:     TestManagerAddr,
+			BeforeHook: setThresholdRoles(contractAddress, 2),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost + ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				proposalID := ProposalID(state, contractAddress, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.EqualValues(t, 1, GetProposalApprovals(state, contractAddress, proposalID, 0))
+			},
+		},
+		"approval of removed admin does not count": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setThresholdRoles(contractAddress, 2)(t, state)
+				_, _, err := approveProposal(state, contractAddress, testSecondAdminAddr, AdminRole, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.NoError(t, err)
+				SetAllowListRole(state, contractAddress, testSecondAdminAddr, NoRole)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(TestNoRoleAddr, EnabledRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost + 2*ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
+				proposalID := ProposalID(state, contractAddress, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.EqualValues(t, 1, GetProposalApprovals(state, contractAddress, proposalID, 0))
+			},
+		},
+		"admin removal below admin threshold": {
+			Caller: TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setThresholdRoles(contractAddress, 2)(t, state)
+				_, _, err := approveProposal(state, contractAddress, testSecondAdminAddr, AdminRole, setNoneSignature, testSecondAdminAddr.Hash().Bytes())
+				require.NoError(t, err)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackModifyAllowList(testSecondAdminAddr, NoRole)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: ModifyAllowListGasCost + ApproveAdminActionGasCost + 2*ProposalApproverGasCost + AllowListEnumerationGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrAdminCountBelowThreshold.Error(),
+		},
+		"admin set admin threshold": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setThresholdRoles(contractAddress, 0),
+			Input:       PackSetAdminThreshold(2),
+			SuppliedGas: SetAdminThresholdGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.EqualValues(t, 2, GetAdminThreshold(state, contractAddress))
+			},
+		},
+		"admin set admin threshold below admin threshold": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setThresholdRoles(contractAddress, 2),
+			Input:       PackSetAdminThreshold(1),
+			SuppliedGas: SetAdminThresholdGasCost + ApproveAdminActionGasCost + ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.EqualValues(t, 2, GetAdminThreshold(state, contractAddress))
+			},
+		},
+		"admin set admin threshold above admin count": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  setThresholdRoles(contractAddress, 0),
+			Input:       PackSetAdminThreshold(3),
+			SuppliedGas: SetAdminThresholdGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrAdminThresholdTooHigh.Error(),
+		},
+		"manager set admin threshold": {
+			Caller:      TestManagerAddr,
+			BeforeHook:  SetDefaultRoles(contractAddress),
+			Input:       PackSetAdminThreshold(2),
+			SuppliedGas: SetAdminThresholdGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetAdminThreshold.Error(),
+		},
+		"set admin threshold with readOnly enabled": {
+			Caller:      TestAdminAddr,
+			BeforeHook:  SetDefaultRoles(contractAddress),
+			Input:       PackSetAdminThreshold(2),
+			SuppliedGas: SetAdminThresholdGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set admin threshold before activation": {
+			Caller:     TestAdminAddr,
+			BeforeHook: SetDefaultRoles(contractAddress),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false).AnyTimes()
+				return config
+			}(),
+			Input:       PackSetAdminThreshold(2),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+		"read admin threshold": {
+			Caller:      TestNoRoleAddr,
+			BeforeHook:  setThresholdRoles(contractAddress, 2),
+			Input:       PackReadAdminThreshold(),
+			SuppliedGas: ReadAdminThresholdGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(big.NewInt(2)).Bytes(),
+		},
+		"read proposal approvals": {
+			Caller: TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				setThresholdRoles(contractAddress, 2)(t, state)
+				_, _, err := approveProposal(state, contractAddress, TestAdminAddr, AdminRole, setEnabledSignature, TestNoRoleAddr.Hash().Bytes())
+				require.NoError(t, err)
+			},
+			InputFn: func(t testing.TB) []byte {
+				// The proposal ID only depends on the call, not on the state it is read from.
+				proposalID := crypto.Keccak256Hash(crypto.Keccak256(setEnabledSignature, TestNoRoleAddr.Hash().Bytes()), common.Hash{}.Bytes())
+				return PackReadProposalApprovals(proposalID)
+			},
+			SuppliedGas: ReadProposalApprovalsGasCost + ProposalApproverGasCost,
+			ReadOnly:    true,
+			ExpectedRes: common.BigToHash(big.NewInt(1)).Bytes(),
+		},
+	}
+}
+
+// setThresholdRoles returns a BeforeHook that sets the tracked default roles, adds a second
+// tracked admin and sets the admin threshold to [threshold].
+func setThresholdRoles(contractAddress common.Address, threshold uint64) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		setTrackedRoles(contractAddress)(t, state)
+		SetAllowListRole(state, contractAddress, testSecondAdminAddr, AdminRole)
+		TrackAllowListMember(state, contractAddress, testSecondAdminAddr, NoRole, AdminRole)
+		SetAdminThreshold(state, contractAddress, threshold)
+	}
+}
+
+// setTrackedRoles returns a BeforeHook that sets the default roles and tracks TestAdminAddr,
+// TestEnabledAddr and TestManagerAddr as allow list members, in that order.
+func setTrackedRoles(contractAddress common.Address) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		SetDefaultRoles(contractAddress)(t, state)
+		TrackAllowListMember(state, contractAddress, TestAdminAddr, NoRole, AdminRole)
+		TrackAllowListMember(state, contractAddress, TestEnabledAddr, NoRole, EnabledRole)
+		TrackAllowListMember(state, contractAddress, TestManagerAddr, NoRole, ManagerRole)
+	}
+}
+
+// setTestTimestamp returns a SetupBlockContext that fixes the block timestamp to [timestamp].
+func setTestTimestamp(timestamp uint64) func(*contract.MockBlockContext) {
+	return func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+		mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
 	}
 }
 
@@ -549,10 +1102,10 @@ func SetDefaultRoles(contractAddress common.Address) func(t testing.TB, state co
 		SetAllowListRole(state, contractAddress, TestAdminAddr, AdminRole)
 		SetAllowListRole(state, contractAddress, TestManagerAddr, ManagerRole)
 		SetAllowListRole(state, contractAddress, TestEnabledAddr, EnabledRole)
-		require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestAdminAddr))
-		require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestManagerAddr))
-		require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr))
-		require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr))
+		require.Equal(t, AdminRole, GetAllowListStatus(state, contractAddress, TestAdminAddr, 0))
+		require.Equal(t, ManagerRole, GetAllowListStatus(state, contractAddress, TestManagerAddr, 0))
+		require.Equal(t, EnabledRole, GetAllowListStatus(state, contractAddress, TestEnabledAddr, 0))
+		require.Equal(t, NoRole, GetAllowListStatus(state, contractAddress, TestNoRoleAddr, 0))
 	}
 }
 
@@ -135,6 +135,34 @@ func AllowListConfigVerifyTests(t testing.TB, module modules.Module) map[string]
 			}),
 			ExpectedError: "",
 		},
+		"invalid allow list config with admin threshold before activation": {
+			Config: mkConfigWithUpgradeAndAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestManagerAddr},
+				AdminThreshold: 2,
+			}, precompileconfig.Upgrade{
+				BlockTimestamp: utils.NewUint64(1),
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrCannotSetAdminThresholdBeforeDUpgrade.Error(),
+		},
+		"invalid allow list config with admin threshold above admin count": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestManagerAddr},
+				AdminThreshold: 3,
+			}),
+			ExpectedError: "admin threshold 3 exceeds the number of admins 2",
+		},
+		"valid allow list config with admin threshold": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestManagerAddr},
+				AdminThreshold: 2,
+			}),
+			ExpectedError: "",
+		},
 	}
 }
 
@@ -188,6 +216,17 @@ func AllowListConfigEqualTests(t testing.TB, module modules.Module) map[string]t
 			}),
 			Expected: false,
 		},
+		"allowlist different admin threshold": {
+			Config: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestManagerAddr},
+				AdminThreshold: 2,
+			}),
+			Other: mkConfigWithAllowList(module, &AllowListConfig{
+				AdminAddresses: []common.Address{TestAdminAddr, TestManagerAddr},
+				AdminThreshold: 1,
+			}),
+			Expected: false,
+		},
 		"allowlist same config": {
 			Config: mkConfigWithAllowList(module, &AllowListConfig{
 				AdminAddresses:   []common.Address{TestAdminAddr},
@@ -0,0 +1,311 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	SetAdminThresholdFuncKey     = "setAdminThreshold"
+	ReadAdminThresholdFuncKey    = "readAdminThreshold"
+	ReadProposalApprovalsFuncKey = "readProposalApprovals"
+
+	SetAdminThresholdGasCost     = contract.WriteGasCostPerSlot + ReadAllowListGasCost + contract.ReadGasCostPerSlot // write threshold + read caller role and admin count
+	ReadAdminThresholdGasCost    = contract.ReadGasCostPerSlot
+	ReadProposalApprovalsGasCost = contract.ReadGasCostPerSlot // read approver count, plus [ProposalApproverGasCost] per approver
+
+	// ProposalApprovedEventGasCost is the cost of emitting a ProposalApproved event with 3 topics and the approval count as data.
+	ProposalApprovedEventGasCost = contract.LogGas + 3*contract.LogTopicGas + common.HashLength*contract.LogDataGas
+	// ApproveAdminActionGasCost is charged on top of the cost of an admin action in threshold approval mode:
+	// read the action nonce, the approval and the approver count, write the approval, the approver, the
+	// approver count and, when the threshold is reached, the action nonce.
+	// [ProposalApproverGasCost] is charged on top for every approver of the proposal.
+	ApproveAdminActionGasCost = 3*contract.ReadGasCostPerSlot + 4*contract.WriteGasCostPerSlot + ProposalApprovedEventGasCost
+	// ProposalApproverGasCost is the cost of checking that an approver of a proposal still holds
+	// its role: read the approver, its approval and its role.
+	ProposalApproverGasCost = contract.ReadGasCostPerSlot + contract.ReadGasCostPerSlot + ReadAllowListGasCost + ReadAllowListExpiryGasCost
+)
+
+var (
+	// AdminThresholdFuncKeys are the optional allow list functions for threshold approval of admin actions.
+	AdminThresholdFuncKeys = []string{
+		SetAdminThresholdFuncKey,
+		ReadAdminThresholdFuncKey,
+		ReadProposalApprovalsFuncKey,
+	}
+
+	setAdminThresholdSignature     = contract.CalculateFunctionSelector("setAdminThreshold(uint256)")
+	readAdminThresholdSignature    = contract.CalculateFunctionSelector("readAdminThreshold()")
+	readProposalApprovalsSignature = contract.CalculateFunctionSelector("readProposalApprovals(bytes32)")
+
+	// ProposalApprovedEventID is the topic of the ProposalApproved(bytes32 indexed proposalID,
+	// address indexed admin, uint256 approvals) event emitted when an admin approves an admin action.
+	ProposalApprovedEventID = crypto.Keccak256Hash([]byte("ProposalApproved(bytes32,address,uint256)"))
+
+	// Error returned when an admin approves the same proposal twice
+	ErrProposalAlreadyApproved = errors.New("proposal already approved by admin")
+	// Error returned when a non-admin attempts to set the admin threshold
+	ErrCannotSetAdminThreshold = errors.New("non-admin cannot set admin threshold")
+	// Error returned when the admin threshold would exceed the number of admins
+	ErrAdminThresholdTooHigh = errors.New("admin threshold exceeds the number of admins")
+
+	// adminThresholdKey and adminCountKey start with a non-zero prefix so they never collide with
+	// a role slot. The remaining threshold approval slots are hashed from the prefixes below.
+	adminThresholdKey           = common.BytesToHash(common.RightPadBytes([]byte("adminThreshold"), common.HashLength))
+	adminCountKey               = common.BytesToHash(common.RightPadBytes([]byte("adminCount"), common.HashLength))
+	adminActionNonceKeyPrefix   = []byte("adminActionNonce")
+	proposalApprovalsKeyPrefix  = []byte("proposalApprovals")
+	proposalApproverKeyPrefix   = []byte("proposalApprover")
+	proposalApprovedByKeyPrefix = []byte("proposalApprovedBy")
+)
+
+// GetAdminThreshold returns the number of admin approvals admin actions of the precompile at
+// [precompileAddr] require. Thresholds of 0 and 1 both mean admin actions execute right away.
+func GetAdminThreshold(state contract.StateDB, precompileAddr common.Address) uint64 {
+	return state.GetState(precompileAddr, adminThresholdKey).Big().Uint64()
+}
+
+// SetAdminThreshold sets the number of admin approvals admin actions of the precompile at
+// [precompileAddr] require to [threshold].
+func SetAdminThreshold(state contract.StateDB, precompileAddr common.Address, threshold uint64) {
+	state.SetState(precompileAddr, adminThresholdKey, uint64ToHash(threshold))
+}
+
+// GetAdminCount returns the number of admins tracked as allow list members of the precompile at
+// [precompileAddr]. The configured admins are counted when the DUpgrade activates, but admins granted
+// their role through the role setters before it are only counted once their role is set again.
+func GetAdminCount(state contract.StateDB, precompileAddr common.Address) uint64 {
+	return state.GetState(precompileAddr, adminCountKey).Big().Uint64()
+}
+
+// GetProposalApprovals returns the number of approvals of the proposal [proposalID] of the precompile
+// at [precompileAddr] as of [timestamp]. An approval only counts while its approver is an admin, or
+// still holds the role it approved the proposal with.
+func GetProposalApprovals(state contract.StateDB, precompileAddr common.Address, proposalID common.Hash, timestamp uint64) uint64 {
+	var approvals uint64
+	approvers := getProposalApproverCount(state, precompileAddr, proposalID)
+	for i := uint64(0); i < approvers; i++ {
+		approver := common.BytesToAddress(state.GetState(precompileAddr, proposalApproverKey(proposalID, i)).Bytes())
+		approvedWith := Role(state.GetState(precompileAddr, proposalApprovedByKey(proposalID, approver)))
+		if role := GetAllowListStatus(state, precompileAddr, approver, timestamp); role.IsAdmin() || role == approvedWith {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// getProposalApproverCount returns the number of addresses that approved the proposal [proposalID]
+// of the precompile at [precompileAddr], whether or not their approval still counts.
+func getProposalApproverCount(state contract.StateDB, precompileAddr common.Address, proposalID common.Hash) uint64 {
+	return state.GetState(precompileAddr, crypto.Keccak256Hash(proposalApprovalsKeyPrefix, proposalID[:])).Big().Uint64()
+}
+
+// ProposalID returns the ID of the pending proposal to call [selector] of the precompile at
+// [precompileAddr] with [input]. The ID changes every time the proposal is executed, so that
+// approvals are never reused.
+func ProposalID(state contract.StateDB, precompileAddr common.Address, selector []byte, input []byte) common.Hash {
+	nonce := state.GetState(precompileAddr, adminActionNonceKey(selector, input))
+	return crypto.Keccak256Hash(crypto.Keccak256(selector, input), nonce[:])
+}
+
+// ApproveAdminAction records the approval by [callerAddr], holding [callerStatus], of the admin action calling
+// [selector] of the precompile at [precompileAddr] with [input], and returns whether the action must be executed.
+// Must only be called after the caller has been authorized to perform the action.
+//
+// In threshold approval mode, enabled by setting an admin threshold greater than one, a call to an admin action
+// is not executed right away, whatever the role of its caller, so that a single enabled or manager key cannot get
+// around the threshold. Instead it counts as the approval of a proposal to make that exact call, and the call is
+// executed when the approval brings the proposal to the threshold. Authorized callers approve a proposal by
+// making the same call; the ProposalApproved event reports the proposal ID and its approval count. Until then,
+// the action returns an empty output. Approvals are counted when the proposal is approved, so the approvals of
+// callers that lost their role no longer count. Outside of threshold approval mode, actions are executed right away.
+func ApproveAdminAction(evm contract.AccessibleState, precompileAddr, callerAddr common.Address, callerStatus Role, selector []byte, input []byte, suppliedGas uint64) (bool, uint64, error) {
+	stateDB := evm.GetStateDB()
+	threshold := GetAdminThreshold(stateDB, precompileAddr)
+	if threshold <= 1 {
+		return true, suppliedGas, nil
+	}
+	remainingGas, err := contract.DeductGas(suppliedGas, ApproveAdminActionGasCost)
+	if err != nil {
+		return false, 0, err
+	}
+
+	proposalID, approvers, err := approveProposal(stateDB, precompileAddr, callerAddr, callerStatus, selector, input)
+	if err != nil {
+		return false, remainingGas, err
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, approvers*ProposalApproverGasCost); err != nil {
+		return false, 0, err
+	}
+	approvals := GetProposalApprovals(stateDB, precompileAddr, proposalID, evm.GetBlockContext().Timestamp())
+	topics, data := PackProposalApprovedEvent(proposalID, callerAddr, approvals)
+	stateDB.AddLog(precompileAddr, topics, data, evm.GetBlockContext().Number().Uint64())
+
+	if approvals < threshold {
+		return false, remainingGas, nil
+	}
+	// Move on to a new proposal ID so the approvals of this one cannot be reused.
+	nonceKey := adminActionNonceKey(selector, input)
+	nextNonce := new(big.Int).Add(stateDB.GetState(precompileAddr, nonceKey).Big(), common.Big1)
+	stateDB.SetState(precompileAddr, nonceKey, common.BigToHash(nextNonce))
+	return true, remainingGas, nil
+}
+
+// approveProposal records the approval by [approver], holding [role], of the pending proposal to call
+// [selector] of the precompile at [precompileAddr] with [input], and returns the proposal ID and the
+// number of addresses that approved it.
+func approveProposal(stateDB contract.StateDB, precompileAddr, approver common.Address, role Role, selector []byte, input []byte) (common.Hash, uint64, error) {
+	proposalID := ProposalID(stateDB, precompileAddr, selector, input)
+	approvedByKey := proposalApprovedByKey(proposalID, approver)
+	if stateDB.GetState(precompileAddr, approvedByKey) != (common.Hash{}) {
+		return common.Hash{}, 0, fmt.Errorf("%w: proposal: %s, admin: %s", ErrProposalAlreadyApproved, proposalID, approver)
+	}
+	stateDB.SetState(precompileAddr, approvedByKey, common.Hash(role))
+	approvers := getProposalApproverCount(stateDB, precompileAddr, proposalID)
+	stateDB.SetState(precompileAddr, proposalApproverKey(proposalID, approvers), approver.Hash())
+	stateDB.SetState(precompileAddr, crypto.Keccak256Hash(proposalApprovalsKeyPrefix, proposalID[:]), uint64ToHash(approvers+1))
+	return proposalID, approvers + 1, nil
+}
+
+// proposalApproverKey returns the storage key holding the approver at [index] of the proposal [proposalID].
+func proposalApproverKey(proposalID common.Hash, index uint64) common.Hash {
+	return crypto.Keccak256Hash(proposalApproverKeyPrefix, proposalID[:], uint64ToHash(index).Bytes())
+}
+
+// proposalApprovedByKey returns the storage key holding the role [approver] approved the proposal
+// [proposalID] with, or zero if it did not approve it.
+func proposalApprovedByKey(proposalID common.Hash, approver common.Address) common.Hash {
+	return crypto.Keccak256Hash(proposalApprovedByKeyPrefix, proposalID[:], approver[:])
+}
+
+// adminActionNonceKey returns the storage key holding the number of times the admin action calling
+// [selector] with [input] has been executed in threshold approval mode.
+func adminActionNonceKey(selector []byte, input []byte) common.Hash {
+	return crypto.Keccak256Hash(adminActionNonceKeyPrefix, crypto.Keccak256(selector, input))
+}
+
+// PackProposalApprovedEvent packs the topics and data of a ProposalApproved event recording
+// [admin] approving [proposalID], bringing it to [approvals] approvals.
+func PackProposalApprovedEvent(proposalID common.Hash, admin common.Address, approvals uint64) ([]common.Hash, []byte) {
+	topics := []common.Hash{
+		ProposalApprovedEventID,
+		proposalID,
+		admin.Hash(),
+	}
+	return topics, uint64ToHash(approvals).Bytes()
+}
+
+// PackSetAdminThreshold packs [threshold] into the input data to the set admin threshold function
+func PackSetAdminThreshold(threshold uint64) []byte {
+	input := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	input = append(input, setAdminThresholdSignature...)
+	input = append(input, uint64ToHash(threshold).Bytes()...)
+	return input
+}
+
+// PackReadAdminThreshold packs the input data to the read admin threshold function
+func PackReadAdminThreshold() []byte {
+	input := make([]byte, 0, contract.SelectorLen)
+	return append(input, readAdminThresholdSignature...)
+}
+
+// PackReadProposalApprovals packs [proposalID] into the input data to the read proposal approvals function
+func PackReadProposalApprovals(proposalID common.Hash) []byte {
+	input := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	input = append(input, readProposalApprovalsSignature...)
+	input = append(input, proposalID.Bytes()...)
+	return input
+}
+
+// createSetAdminThreshold returns an execution function for setting the admin threshold of [precompileAddr].
+// Changing the threshold is itself an admin action.
+func createSetAdminThreshold(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, SetAdminThresholdGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for setting admin threshold: %d", len(input))
+		}
+
+		if readOnly {
+			return nil, remainingGas, vmerrs.ErrWriteProtection
+		}
+
+		thresholdBig := new(big.Int).SetBytes(input)
+		if !thresholdBig.IsUint64() {
+			return nil, remainingGas, fmt.Errorf("invalid admin threshold: %s", thresholdBig)
+		}
+
+		stateDB := evm.GetStateDB()
+		callerStatus := GetAllowListStatus(stateDB, precompileAddr, callerAddr, evm.GetBlockContext().Timestamp())
+		if !callerStatus.IsAdmin() {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetAdminThreshold, callerAddr)
+		}
+		if threshold := thresholdBig.Uint64(); threshold > 1 && threshold > GetAdminCount(stateDB, precompileAddr) {
+			return nil, remainingGas, fmt.Errorf("%w: threshold %d, admins %d", ErrAdminThresholdTooHigh, threshold, GetAdminCount(stateDB, precompileAddr))
+		}
+		execute, remainingGas, err := ApproveAdminAction(evm, precompileAddr, callerAddr, callerStatus, setAdminThresholdSignature, input, remainingGas)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		if !execute {
+			return []byte{}, remainingGas, nil
+		}
+		SetAdminThreshold(stateDB, precompileAddr, thresholdBig.Uint64())
+		// Return an empty output and the remaining gas
+		return []byte{}, remainingGas, nil
+	}
+}
+
+// createReadAdminThreshold returns an execution function that reads the admin threshold of [precompileAddr].
+func createReadAdminThreshold(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadAdminThresholdGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != 0 {
+			return nil, remainingGas, fmt.Errorf("invalid input length for read admin threshold: %d", len(input))
+		}
+
+		threshold := GetAdminThreshold(evm.GetStateDB(), precompileAddr)
+		return uint64ToHash(threshold).Bytes(), remainingGas, nil
+	}
+}
+
+// createReadProposalApprovals returns an execution function that reads the number of admins that
+// approved the input proposal ID of [precompileAddr].
+func createReadProposalApprovals(precompileAddr common.Address) contract.RunStatefulPrecompileFunc {
+	return func(evm contract.AccessibleState, callerAddr common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+		if remainingGas, err = contract.DeductGas(suppliedGas, ReadProposalApprovalsGasCost); err != nil {
+			return nil, 0, err
+		}
+
+		if len(input) != allowListInputLen {
+			return nil, remainingGas, fmt.Errorf("invalid input length for read proposal approvals: %d", len(input))
+		}
+
+		stateDB := evm.GetStateDB()
+		proposalID := common.BytesToHash(input)
+		if remainingGas, err = contract.DeductGas(remainingGas, getProposalApproverCount(stateDB, precompileAddr, proposalID)*ProposalApproverGasCost); err != nil {
+			return nil, 0, err
+		}
+		approvals := GetProposalApprovals(stateDB, precompileAddr, proposalID, evm.GetBlockContext().Timestamp())
+		return uint64ToHash(approvals).Bytes(), remainingGas, nil
+	}
+}
+
+func isAdminThresholdActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
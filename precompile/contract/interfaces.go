@@ -28,6 +28,7 @@ type StateDB interface {
 
 	GetBalance(common.Address) *big.Int
 	AddBalance(common.Address, *big.Int)
+	SubBalance(common.Address, *big.Int)
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
@@ -0,0 +1,96 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// AllowedCall is a function of a contract non-privileged senders may call.
+type AllowedCall struct {
+	Target   common.Address `json:"target"`
+	Selector hexutil.Bytes  `json:"selector"`
+}
+
+// Config implements the StatefulPrecompileConfig interface while adding in the
+// ContractCallAllowList specific precompile config.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	InitialAllowedCalls []AllowedCall `json:"initialAllowedCalls,omitempty"` // calls to allow when the upgrade activates
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// ContractCallAllowList with the given [admins], [enableds] and [managers] as members of the allowlist.
+// Also allows [initialAllowedCalls] when the upgrade activates.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialAllowedCalls []AllowedCall) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:             precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialAllowedCalls: initialAllowedCalls,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables ContractCallAllowList.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+func (*Config) Key() string { return ConfigKey }
+
+// Equal returns true if [cfg] is a [*ContractCallAllowListConfig] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	eq := c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
+	if !eq {
+		return false
+	}
+
+	if len(c.InitialAllowedCalls) != len(other.InitialAllowedCalls) {
+		return false
+	}
+	for i, call := range c.InitialAllowedCalls {
+		otherCall := other.InitialAllowedCalls[i]
+		if call.Target != otherCall.Target || Selector(call.Selector) != Selector(otherCall.Selector) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	allowed := make(map[common.Hash]struct{}, len(c.InitialAllowedCalls))
+	for _, call := range c.InitialAllowedCalls {
+		if len(call.Selector) != SelectorLen {
+			return fmt.Errorf("invalid selector length %d for allowed call to %s, expected %d", len(call.Selector), call.Target, SelectorLen)
+		}
+		key := allowedCallKey(call.Target, Selector(call.Selector))
+		if _, ok := allowed[key]; ok {
+			return fmt.Errorf("duplicate allowed call to %s with selector %s", call.Target, call.Selector)
+		}
+		allowed[key] = struct{}{}
+	}
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
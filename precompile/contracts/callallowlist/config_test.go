@@ -0,0 +1,68 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	allowedCall := AllowedCall{Target: common.HexToAddress("0x0123"), Selector: []byte{0xa9, 0x05, 0x9c, 0xbb}}
+	tests := map[string]testutils.ConfigVerifyTest{
+		"invalid selector length": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []AllowedCall{{Target: allowedCall.Target, Selector: []byte{0xa9}}}),
+			ExpectedError: "invalid selector length",
+		},
+		"duplicate allowed call": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []AllowedCall{allowedCall, allowedCall}),
+			ExpectedError: "duplicate allowed call",
+		},
+		"valid allowed calls": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []AllowedCall{allowedCall}),
+			ExpectedError: "",
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	allowedCalls := []AllowedCall{{Target: common.HexToAddress("0x0123"), Selector: []byte{0xa9, 0x05, 0x9c, 0xbb}}}
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Other:    NewConfig(utils.NewUint64(4), admins, nil, nil, allowedCalls),
+			Expected: false,
+		},
+		"different allowed calls": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, []AllowedCall{{Target: common.HexToAddress("0x0123"), Selector: []byte{0x01, 0x02, 0x03, 0x04}}}),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, allowedCalls),
+			Expected: true,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
+}
@@ -0,0 +1,221 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	SetCallAllowedGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // write 1 slot + read caller role
+	IsCallAllowedGasCost  uint64 = contract.ReadGasCostPerSlot
+
+	// SelectorLen is the length of the function selector at the start of call data.
+	SelectorLen = 4
+)
+
+var (
+	ErrCannotSetCallAllowed = errors.New("non-admin or manager cannot call setCallAllowed")
+
+	// ContractCallAllowListRawABI contains the raw ABI of ContractCallAllowList contract.
+	//go:embed contract.abi
+	ContractCallAllowListRawABI string
+
+	ContractCallAllowListABI        = contract.ParseABI(ContractCallAllowListRawABI)
+	ContractCallAllowListPrecompile = createContractCallAllowListPrecompile()
+
+	// allowedCallKeyPrefix is written over the leading (zero) bytes of an address hash, ahead of the
+	// selector, so the allowed flag of a call never collides with an allow list role slot.
+	allowedCallKeyPrefix = []byte("cal")
+	allowedValue         = common.BigToHash(common.Big1)
+)
+
+// GetContractCallAllowListStatus returns the role of [address] as of [timestamp] for the ContractCallAllowList list.
+func GetContractCallAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
+}
+
+// SetContractCallAllowListStatus sets the permissions of [address] to [role] for the
+// ContractCallAllowList list. Assumes [role] has already been verified as valid.
+func SetContractCallAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// Selector returns the function selector called by [data]. Call data shorter than a selector,
+// such as a plain value transfer, calls the zero selector.
+func Selector(data []byte) [SelectorLen]byte {
+	var selector [SelectorLen]byte
+	if len(data) >= SelectorLen {
+		copy(selector[:], data)
+	}
+	return selector
+}
+
+// allowedCallKey returns the storage key holding the allowed flag of calls to [selector] of [target].
+func allowedCallKey(target common.Address, selector [SelectorLen]byte) common.Hash {
+	key := target.Hash()
+	copy(key[:], allowedCallKeyPrefix)
+	copy(key[common.HashLength-common.AddressLength-SelectorLen:], selector[:])
+	return key
+}
+
+// IsCallAllowed returns true if non-privileged senders may call [selector] of [target].
+func IsCallAllowed(stateDB contract.StateDB, target common.Address, selector [SelectorLen]byte) bool {
+	return stateDB.GetState(ContractAddress, allowedCallKey(target, selector)) == allowedValue
+}
+
+// SetCallAllowed allows non-privileged senders to call [selector] of [target] if [allowed] is true,
+// otherwise disallows it.
+func SetCallAllowed(stateDB contract.StateDB, target common.Address, selector [SelectorLen]byte, allowed bool) {
+	value := common.Hash{}
+	if allowed {
+		value = allowedValue
+	}
+	stateDB.SetState(ContractAddress, allowedCallKey(target, selector), value)
+}
+
+// IsCallPermitted returns true if [sender] may call [target] with [data] as of [timestamp]: either
+// [sender] is enabled on the allow list or the called function of [target] is allowed.
+// Callers are expected to only check calls to contracts.
+//
+// Only the top-level call of a transaction is checked: the calls an allowed function makes to
+// other contracts are not covered by the allow list.
+func IsCallPermitted(stateDB contract.StateDB, sender common.Address, target common.Address, data []byte, timestamp uint64) bool {
+	if GetContractCallAllowListStatus(stateDB, sender, timestamp).IsEnabled() {
+		return true
+	}
+	return IsCallAllowed(stateDB, target, Selector(data))
+}
+
+// IsCreatePermitted returns true if [sender] may deploy a contract as of [timestamp]. Only senders
+// enabled on the allow list may deploy contracts, as a constructor could otherwise make the calls
+// they are not allowed to make.
+func IsCreatePermitted(stateDB contract.StateDB, sender common.Address, timestamp uint64) bool {
+	return GetContractCallAllowListStatus(stateDB, sender, timestamp).IsEnabled()
+}
+
+// PackSetCallAllowed packs [target], [selector] and [allowed] into the appropriate arguments for setCallAllowed.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetCallAllowed(target common.Address, selector [SelectorLen]byte, allowed bool) ([]byte, error) {
+	return ContractCallAllowListABI.Pack("setCallAllowed", target, selector, allowed)
+}
+
+// UnpackSetCallAllowedInput attempts to unpack [input] into the target, selector and allowed arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetCallAllowedInput(input []byte) (common.Address, [SelectorLen]byte, bool, error) {
+	res, err := ContractCallAllowListABI.UnpackInput("setCallAllowed", input)
+	if err != nil {
+		return common.Address{}, [SelectorLen]byte{}, false, err
+	}
+	target := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	selector := *abi.ConvertType(res[1], new([SelectorLen]byte)).(*[SelectorLen]byte)
+	allowed := *abi.ConvertType(res[2], new(bool)).(*bool)
+	return target, selector, allowed, nil
+}
+
+// PackIsCallAllowed packs [target] and [selector] into the appropriate arguments for isCallAllowed.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackIsCallAllowed(target common.Address, selector [SelectorLen]byte) ([]byte, error) {
+	return ContractCallAllowListABI.Pack("isCallAllowed", target, selector)
+}
+
+// UnpackIsCallAllowedInput attempts to unpack [input] into the target and selector arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackIsCallAllowedInput(input []byte) (common.Address, [SelectorLen]byte, error) {
+	res, err := ContractCallAllowListABI.UnpackInput("isCallAllowed", input)
+	if err != nil {
+		return common.Address{}, [SelectorLen]byte{}, err
+	}
+	target := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	selector := *abi.ConvertType(res[1], new([SelectorLen]byte)).(*[SelectorLen]byte)
+	return target, selector, nil
+}
+
+// PackIsCallAllowedOutput attempts to pack given allowed of type bool
+// to conform the ABI outputs.
+func PackIsCallAllowedOutput(allowed bool) ([]byte, error) {
+	return ContractCallAllowListABI.PackOutput("isCallAllowed", allowed)
+}
+
+func setCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, selector, allowed, err := UnpackSetCallAllowedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is an admin or a manager and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() && callerStatus != allowlist.ManagerRole {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetCallAllowed, caller)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, ContractCallAllowListABI.Methods["setCallAllowed"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	SetCallAllowed(stateDB, target, selector, allowed)
+	return []byte{}, remainingGas, nil
+}
+
+func isCallAllowed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsCallAllowedGasCost); err != nil {
+		return nil, 0, err
+	}
+	target, selector, err := UnpackIsCallAllowedInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	packedOutput, err := PackIsCallAllowedOutput(IsCallAllowed(accessibleState.GetStateDB(), target, selector))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createContractCallAllowListPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createContractCallAllowListPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setCallAllowed": setCallAllowed,
+		"isCallAllowed":  isCallAllowed,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := ContractCallAllowListABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,154 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package callallowlist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTarget   = common.HexToAddress("0x0123")
+	testSelector = [SelectorLen]byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	tests = map[string]testutils.PrecompileTest{
+		"set call allowed from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(testTarget, testSelector, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetCallAllowed.Error(),
+		},
+		"set call allowed from manager succeeds": {
+			Caller:     allowlist.TestManagerAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(testTarget, testSelector, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsCallAllowed(state, testTarget, testSelector))
+				require.False(t, IsCallAllowed(state, testTarget, [SelectorLen]byte{}))
+				// allowing a call must not clobber the allow list role of the target
+				require.Equal(t, allowlist.NoRole, GetContractCallAllowListStatus(state, testTarget, 0))
+			},
+		},
+		"disallow call from admin succeeds": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetCallAllowed(state, testTarget, testSelector, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(testTarget, testSelector, false)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsCallAllowed(state, testTarget, testSelector))
+			},
+		},
+		"set call allowed readOnly": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(testTarget, testSelector, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set call allowed insufficient gas": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetCallAllowed(testTarget, testSelector, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetCallAllowedGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"is call allowed readOnly": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetCallAllowed(state, testTarget, testSelector, true)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsCallAllowed(testTarget, testSelector)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: IsCallAllowedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsCallAllowedOutput(true)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"initial allowed calls": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			Config: &Config{
+				InitialAllowedCalls: []AllowedCall{{Target: testTarget, Selector: testSelector[:]}},
+			},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsCallAllowed(state, testTarget, testSelector))
+			},
+		},
+	}
+)
+
+func TestContractCallAllowListRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkContractCallAllowList(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
+
+func TestIsCallPermitted(t *testing.T) {
+	state := state.NewTestStateDB(t)
+	SetContractCallAllowListStatus(state, allowlist.TestEnabledAddr, allowlist.EnabledRole)
+	SetCallAllowed(state, testTarget, testSelector, true)
+
+	calldata := append(testSelector[:], common.Hash{}.Bytes()...)
+	require.True(t, IsCallPermitted(state, allowlist.TestNoRoleAddr, testTarget, calldata, 0))
+	require.False(t, IsCallPermitted(state, allowlist.TestNoRoleAddr, testTarget, []byte{0x01, 0x02, 0x03, 0x04}, 0))
+	// call data shorter than a selector calls the zero selector
+	require.False(t, IsCallPermitted(state, allowlist.TestNoRoleAddr, testTarget, nil, 0))
+	// enabled senders may call any function
+	require.True(t, IsCallPermitted(state, allowlist.TestEnabledAddr, testTarget, nil, 0))
+}
@@ -12,10 +12,10 @@ import (
 // Singleton StatefulPrecompiledContract for W/R access to the contract deployer allow list.
 var ContractDeployerAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
 
-// GetContractDeployerAllowListStatus returns the role of [address] for the contract deployer
+// GetContractDeployerAllowListStatus returns the role of [address] as of [timestamp] for the contract deployer
 // allow list.
-func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+func GetContractDeployerAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // SetContractDeployerAllowListStatus sets the permissions of [address] to [role] for the
@@ -32,10 +32,16 @@ const (
 
 	// [numFeeConfigField] fields in FeeConfig struct
 	feeConfigInputLen = common.HashLength * numFeeConfigField
+	// [numFeeConfigField] fields in FeeConfig struct plus the activation timestamp
+	pendingFeeConfigInputLen = feeConfigInputLen + common.HashLength
 
 	SetFeeConfigGasCost     = contract.WriteGasCostPerSlot * (numFeeConfigField + 1) // plus one for setting last changed at
 	GetFeeConfigGasCost     = contract.ReadGasCostPerSlot * numFeeConfigField
 	GetLastChangedAtGasCost = contract.ReadGasCostPerSlot
+
+	SetPendingFeeConfigGasCost    = contract.WriteGasCostPerSlot*(numFeeConfigField+1) + allowlist.ReadAllowListGasCost // plus one for setting the activation timestamp
+	GetPendingFeeConfigGasCost    = contract.ReadGasCostPerSlot * (numFeeConfigField + 1)
+	CancelPendingFeeConfigGasCost = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost
 )
 
 var (
@@ -46,15 +52,26 @@ var (
 	setFeeConfigSignature              = contract.CalculateFunctionSelector("setFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
 	getFeeConfigSignature              = contract.CalculateFunctionSelector("getFeeConfig()")
 	getFeeConfigLastChangedAtSignature = contract.CalculateFunctionSelector("getFeeConfigLastChangedAt()")
+	setPendingFeeConfigSignature       = contract.CalculateFunctionSelector("setPendingFeeConfig(uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256,uint256)")
+	getPendingFeeConfigSignature       = contract.CalculateFunctionSelector("getPendingFeeConfig()")
+	cancelPendingFeeConfigSignature    = contract.CalculateFunctionSelector("cancelPendingFeeConfig()")
 
 	feeConfigLastChangedAtKey = common.Hash{'l', 'c', 'a'}
 
-	ErrCannotChangeFee = errors.New("non-enabled cannot change fee config")
+	// The pending fee config is stored under its own prefix, its activation timestamp
+	// is zero if no fee config change is scheduled.
+	pendingFeeConfigKeyPrefix     = byte('p')
+	pendingFeeConfigActivationKey = common.Hash{'p', 'a', 't'}
+
+	ErrCannotChangeFee             = errors.New("non-enabled cannot change fee config")
+	ErrCannotSchedulePendingFee    = errors.New("non-admin cannot schedule fee config")
+	ErrInvalidPendingFeeActivation = errors.New("pending fee config activation timestamp must be after the current block timestamp")
+	ErrNoPendingFeeConfig          = errors.New("no pending fee config")
 )
 
-// GetFeeManagerStatus returns the role of [address] for the fee config manager list.
-func GetFeeManagerStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetFeeManagerStatus returns the role of [address] as of [timestamp] for the fee config manager list.
+func GetFeeManagerStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // SetFeeManagerStatus sets the permissions of [address] to [role] for the
@@ -86,7 +103,22 @@ func PackSetFeeConfig(feeConfig commontype.FeeConfig) ([]byte, error) {
 }
 
 func packFeeConfigHelper(feeConfig commontype.FeeConfig, useSelector bool) ([]byte, error) {
-	hashes := []common.Hash{
+	hashes := feeConfigHashes(feeConfig)
+
+	if useSelector {
+		res := make([]byte, len(setFeeConfigSignature)+feeConfigInputLen)
+		err := contract.PackOrderedHashesWithSelector(res, setFeeConfigSignature, hashes)
+		return res, err
+	}
+
+	res := make([]byte, len(hashes)*common.HashLength)
+	err := contract.PackOrderedHashes(res, hashes)
+	return res, err
+}
+
+// feeConfigHashes returns the fields of [feeConfig] in the order they are packed.
+func feeConfigHashes(feeConfig commontype.FeeConfig) []common.Hash {
+	return []common.Hash{
 		common.BigToHash(feeConfig.GasLimit),
 		common.BigToHash(new(big.Int).SetUint64(feeConfig.TargetBlockRate)),
 		common.BigToHash(feeConfig.MinBaseFee),
@@ -96,18 +128,54 @@ func packFeeConfigHelper(feeConfig commontype.FeeConfig, useSelector bool) ([]by
 		common.BigToHash(feeConfig.MaxBlockGasCost),
 		common.BigToHash(feeConfig.BlockGasCostStep),
 	}
+}
 
-	if useSelector {
-		res := make([]byte, len(setFeeConfigSignature)+feeConfigInputLen)
-		err := contract.PackOrderedHashesWithSelector(res, setFeeConfigSignature, hashes)
-		return res, err
-	}
+// PackGetPendingFeeConfigInput packs the getPendingFeeConfig signature
+func PackGetPendingFeeConfigInput() []byte {
+	return getPendingFeeConfigSignature
+}
 
-	res := make([]byte, len(hashes)*common.HashLength)
+// PackCancelPendingFeeConfigInput packs the cancelPendingFeeConfig signature
+func PackCancelPendingFeeConfigInput() []byte {
+	return cancelPendingFeeConfigSignature
+}
+
+// PackPendingFeeConfig packs [feeConfig] and [activationTimestamp] without the selector
+// into the appropriate arguments for pending fee config operations.
+func PackPendingFeeConfig(feeConfig commontype.FeeConfig, activationTimestamp uint64) ([]byte, error) {
+	hashes := append(feeConfigHashes(feeConfig), common.BigToHash(new(big.Int).SetUint64(activationTimestamp)))
+	res := make([]byte, pendingFeeConfigInputLen)
 	err := contract.PackOrderedHashes(res, hashes)
 	return res, err
 }
 
+// PackSetPendingFeeConfig packs [feeConfig] and [activationTimestamp] with the selector
+// into the appropriate arguments for scheduling a fee config.
+func PackSetPendingFeeConfig(feeConfig commontype.FeeConfig, activationTimestamp uint64) ([]byte, error) {
+	hashes := append(feeConfigHashes(feeConfig), common.BigToHash(new(big.Int).SetUint64(activationTimestamp)))
+	res := make([]byte, len(setPendingFeeConfigSignature)+pendingFeeConfigInputLen)
+	err := contract.PackOrderedHashesWithSelector(res, setPendingFeeConfigSignature, hashes)
+	return res, err
+}
+
+// UnpackPendingFeeConfigInput attempts to unpack [input] into the fee config and activation timestamp
+// arguments to the setPendingFeeConfig function.
+// assumes that [input] does not include selector (omits first 4 bytes in PackSetPendingFeeConfig)
+func UnpackPendingFeeConfigInput(input []byte) (commontype.FeeConfig, uint64, error) {
+	if len(input) != pendingFeeConfigInputLen {
+		return commontype.FeeConfig{}, 0, fmt.Errorf("invalid input length for pending fee config Input: %d", len(input))
+	}
+	feeConfig, err := UnpackFeeConfigInput(input[:feeConfigInputLen])
+	if err != nil {
+		return commontype.FeeConfig{}, 0, err
+	}
+	activationTimestamp := new(big.Int).SetBytes(contract.PackedHash(input, numFeeConfigField))
+	if !activationTimestamp.IsUint64() {
+		return commontype.FeeConfig{}, 0, fmt.Errorf("%w: %s", ErrInvalidPendingFeeActivation, activationTimestamp)
+	}
+	return feeConfig, activationTimestamp.Uint64(), nil
+}
+
 // UnpackFeeConfigInput attempts to unpack [input] into the arguments to the fee config precompile
 // assumes that [input] does not include selector (omits first 4 bytes in PackSetFeeConfigInput)
 func UnpackFeeConfigInput(input []byte) (commontype.FeeConfig, error) {
@@ -145,9 +213,31 @@ func UnpackFeeConfigInput(input []byte) (commontype.FeeConfig, error) {
 
 // GetStoredFeeConfig returns fee config from contract storage in given state
 func GetStoredFeeConfig(stateDB contract.StateDB) commontype.FeeConfig {
+	return readFeeConfig(stateDB, feeConfigKey)
+}
+
+// GetPendingFeeConfig returns the fee config scheduled in the given state and the timestamp
+// it takes effect at. The returned timestamp is 0 if no fee config change is scheduled.
+func GetPendingFeeConfig(stateDB contract.StateDB) (commontype.FeeConfig, uint64) {
+	activationTimestamp := stateDB.GetState(ContractAddress, pendingFeeConfigActivationKey).Big().Uint64()
+	return readFeeConfig(stateDB, pendingFeeConfigKey), activationTimestamp
+}
+
+// feeConfigKey returns the storage key of the fee config field [field].
+func feeConfigKey(field int) common.Hash {
+	return common.Hash{byte(field)}
+}
+
+// pendingFeeConfigKey returns the storage key of the pending fee config field [field].
+func pendingFeeConfigKey(field int) common.Hash {
+	return common.Hash{pendingFeeConfigKeyPrefix, byte(field)}
+}
+
+// readFeeConfig reads the fee config fields stored at the keys returned by [keyFn].
+func readFeeConfig(stateDB contract.StateDB, keyFn func(field int) common.Hash) commontype.FeeConfig {
 	feeConfig := commontype.FeeConfig{}
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
-		val := stateDB.GetState(ContractAddress, common.Hash{byte(i)})
+		val := stateDB.GetState(ContractAddress, keyFn(i))
 		switch i {
 		case gasLimitKey:
 			feeConfig.GasLimit = new(big.Int).Set(val.Big())
@@ -185,6 +275,42 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 		return fmt.Errorf("cannot verify fee config: %w", err)
 	}
 
+	writeFeeConfig(stateDB, feeConfig, feeConfigKey)
+
+	blockNumber := blockContext.Number()
+	if blockNumber == nil {
+		return fmt.Errorf("blockNumber cannot be nil")
+	}
+	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
+	return nil
+}
+
+// StorePendingFeeConfig schedules [feeConfig] to take effect at [activationTimestamp], replacing
+// any previously scheduled fee config. A validation on [feeConfig] is done before storing.
+func StorePendingFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, activationTimestamp uint64) error {
+	if err := feeConfig.Verify(); err != nil {
+		return fmt.Errorf("cannot verify fee config: %w", err)
+	}
+
+	writeFeeConfig(stateDB, feeConfig, pendingFeeConfigKey)
+	stateDB.SetState(ContractAddress, pendingFeeConfigActivationKey, common.BigToHash(new(big.Int).SetUint64(activationTimestamp)))
+	return nil
+}
+
+// ApplyPendingFeeConfig stores the pending fee config as the fee config if its activation
+// timestamp has been reached by the block in [blockContext]. Scheduled fee config changes
+// take effect from the first block with a timestamp at or after the activation timestamp.
+func ApplyPendingFeeConfig(stateDB contract.StateDB, blockContext contract.ConfigurationBlockContext) error {
+	pendingFeeConfig, activationTimestamp := GetPendingFeeConfig(stateDB)
+	if activationTimestamp == 0 || blockContext.Timestamp() < activationTimestamp {
+		return nil
+	}
+	stateDB.SetState(ContractAddress, pendingFeeConfigActivationKey, common.Hash{})
+	return StoreFeeConfig(stateDB, pendingFeeConfig, blockContext)
+}
+
+// writeFeeConfig writes the fields of [feeConfig] at the keys returned by [keyFn].
+func writeFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, keyFn func(field int) common.Hash) {
 	for i := minFeeConfigFieldKey; i <= numFeeConfigField; i++ {
 		var input common.Hash
 		switch i {
@@ -208,15 +334,8 @@ func StoreFeeConfig(stateDB contract.StateDB, feeConfig commontype.FeeConfig, bl
 			// This should never encounter an unknown fee config key
 			panic(fmt.Sprintf("unknown fee config key: %d", i))
 		}
-		stateDB.SetState(ContractAddress, common.Hash{byte(i)}, input)
-	}
-
-	blockNumber := blockContext.Number()
-	if blockNumber == nil {
-		return fmt.Errorf("blockNumber cannot be nil")
+		stateDB.SetState(ContractAddress, keyFn(i), input)
 	}
-	stateDB.SetState(ContractAddress, feeConfigLastChangedAtKey, common.BigToHash(blockNumber))
-	return nil
 }
 
 // setFeeConfig checks if the caller has permissions to set the fee config.
@@ -237,10 +356,17 @@ func setFeeConfig(accessibleState contract.AccessibleState, caller common.Addres
 
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := GetFeeManagerStatus(stateDB, caller)
+	callerStatus := GetFeeManagerStatus(stateDB, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotChangeFee, caller)
 	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, setFeeConfigSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
 
 	if err := StoreFeeConfig(stateDB, feeConfig, accessibleState.GetBlockContext()); err != nil {
 		return nil, remainingGas, err
@@ -281,6 +407,100 @@ func getFeeConfigLastChangedAt(accessibleState contract.AccessibleState, caller
 	return common.BigToHash(lastChangedAt).Bytes(), remainingGas, err
 }
 
+// setPendingFeeConfig checks if the caller has permissions to schedule a fee config.
+// The execution function parses [input] into a FeeConfig structure and an activation timestamp
+// and stores them as the pending fee config, replacing any previously scheduled fee config.
+func setPendingFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetPendingFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	feeConfig, activationTimestamp, err := UnpackPendingFeeConfigInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	// Verify that the caller is an admin and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatus(stateDB, caller, timestamp)
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSchedulePendingFee, caller)
+	}
+	if activationTimestamp <= timestamp {
+		return nil, remainingGas, fmt.Errorf("%w: activation: %d, current: %d", ErrInvalidPendingFeeActivation, activationTimestamp, timestamp)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, setPendingFeeConfigSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	if err := StorePendingFeeConfig(stateDB, feeConfig, activationTimestamp); err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// getPendingFeeConfig returns the pending fee config and its activation timestamp as an output.
+// All fields are zero if no fee config change is scheduled.
+func getPendingFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetPendingFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	feeConfig, activationTimestamp := GetPendingFeeConfig(accessibleState.GetStateDB())
+
+	output, err := PackPendingFeeConfig(feeConfig, activationTimestamp)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the pending fee config as output and the remaining gas
+	return output, remainingGas, err
+}
+
+// cancelPendingFeeConfig checks if the caller has permissions to cancel the pending fee config
+// and unschedules it.
+func cancelPendingFeeConfig(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CancelPendingFeeConfigGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is an admin and therefore has the right to call this function.
+	callerStatus := GetFeeManagerStatus(stateDB, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSchedulePendingFee, caller)
+	}
+	if _, activationTimestamp := GetPendingFeeConfig(stateDB); activationTimestamp == 0 {
+		return nil, remainingGas, ErrNoPendingFeeConfig
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, cancelPendingFeeConfigSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	stateDB.SetState(ContractAddress, pendingFeeConfigActivationKey, common.Hash{})
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
 // createFeeManagerPrecompile returns a StatefulPrecompiledContract
 // with getters and setters for the chain's fee config. Access to the getters/setters
 // is controlled by an allow list for ContractAddress.
@@ -291,7 +511,11 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 	getFeeConfigFunc := contract.NewStatefulPrecompileFunction(getFeeConfigSignature, getFeeConfig)
 	getFeeConfigLastChangedAtFunc := contract.NewStatefulPrecompileFunction(getFeeConfigLastChangedAtSignature, getFeeConfigLastChangedAt)
 
-	feeManagerFunctions = append(feeManagerFunctions, setFeeConfigFunc, getFeeConfigFunc, getFeeConfigLastChangedAtFunc)
+	setPendingFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(setPendingFeeConfigSignature, setPendingFeeConfig, isPendingFeeConfigActivated)
+	getPendingFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(getPendingFeeConfigSignature, getPendingFeeConfig, isPendingFeeConfigActivated)
+	cancelPendingFeeConfigFunc := contract.NewStatefulPrecompileFunctionWithActivator(cancelPendingFeeConfigSignature, cancelPendingFeeConfig, isPendingFeeConfigActivated)
+
+	feeManagerFunctions = append(feeManagerFunctions, setFeeConfigFunc, getFeeConfigFunc, getFeeConfigLastChangedAtFunc, setPendingFeeConfigFunc, getPendingFeeConfigFunc, cancelPendingFeeConfigFunc)
 	// Construct the contract with no fallback function.
 	contract, err := contract.NewStatefulPrecompileContract(nil, feeManagerFunctions)
 	// TODO Change this to be returned as an error after refactoring this precompile
@@ -301,3 +525,8 @@ func createFeeManagerPrecompile() contract.StatefulPrecompiledContract {
 	}
 	return contract
 }
+
+// isPendingFeeConfigActivated returns true if the pending fee config functions are available (after DUpgrade).
+func isPendingFeeConfigActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
@@ -116,6 +116,7 @@ var (
 			ExpectedRes: []byte{},
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
 			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				feeConfig := GetStoredFeeConfig(state)
@@ -124,6 +125,44 @@ var (
 				require.EqualValues(t, testBlockNumber, lastChangedAt)
 			},
 		},
+		"set config from admin address below admin threshold": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				allowlist.SetAdminThreshold(state, Module.Address, 2)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeConfig(testFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeConfigGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, GetFeeConfigLastChangedAt(state).Sign())
+			},
+		},
+		"set config from enabled address below admin threshold": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				allowlist.SetAdminThreshold(state, Module.Address, 2)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeConfig(testFeeConfig)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeConfigGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Zero(t, GetFeeConfigLastChangedAt(state).Sign())
+			},
+		},
 		"get fee config from non-enabled address": {
 			Caller: allowlist.TestNoRoleAddr,
 			BeforeHook: func(t testing.TB, state contract.StateDB) {
@@ -168,6 +207,7 @@ var (
 			}(),
 			SetupBlockContext: func(mbc *contract.MockBlockContext) {
 				mbc.EXPECT().Number().Return(testBlockNumber)
+				mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
 			},
 			AfterHook: func(t testing.TB, state contract.StateDB) {
 				feeConfig := GetStoredFeeConfig(state)
@@ -248,9 +288,195 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"set pending config from admin address": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetPendingFeeConfig(testFeeConfig, testActivationTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas:       SetPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				feeConfig, activationTimestamp := GetPendingFeeConfig(state)
+				require.Equal(t, testFeeConfig, feeConfig)
+				require.Equal(t, testActivationTimestamp, activationTimestamp)
+			},
+		},
+		"set pending config from enabled address fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetPendingFeeConfig(testFeeConfig, testActivationTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas:       SetPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			ExpectedErr:       ErrCannotSchedulePendingFee.Error(),
+		},
+		"set pending config with past activation fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetPendingFeeConfig(testFeeConfig, testTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas:       SetPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			ExpectedErr:       ErrInvalidPendingFeeActivation.Error(),
+		},
+		"set invalid pending config from admin address fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				feeConfig := testFeeConfig
+				feeConfig.MinBlockGasCost = new(big.Int).Mul(feeConfig.MaxBlockGasCost, common.Big2)
+				input, err := PackSetPendingFeeConfig(feeConfig, testActivationTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas:       SetPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			ExpectedErr:       "cannot be greater than maxBlockGasCost",
+		},
+		"readOnly setPendingFeeConfig with admin role fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetPendingFeeConfig(testFeeConfig, testActivationTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetPendingFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"insufficient gas setPendingFeeConfig from admin": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetPendingFeeConfig(testFeeConfig, testActivationTimestamp)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetPendingFeeConfigGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"get pending config from non-enabled address": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StorePendingFeeConfig(state, testFeeConfig, testActivationTimestamp))
+			},
+			Input:       PackGetPendingFeeConfigInput(),
+			SuppliedGas: GetPendingFeeConfigGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackPendingFeeConfig(testFeeConfig, testActivationTimestamp)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"cancel pending config from admin address": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StorePendingFeeConfig(state, testFeeConfig, testActivationTimestamp))
+			},
+			Input:             PackCancelPendingFeeConfigInput(),
+			SuppliedGas:       CancelPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			ExpectedRes:       []byte{},
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				_, activationTimestamp := GetPendingFeeConfig(state)
+				require.Zero(t, activationTimestamp)
+			},
+		},
+		"cancel pending config from manager fails": {
+			Caller: allowlist.TestManagerAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StorePendingFeeConfig(state, testFeeConfig, testActivationTimestamp))
+			},
+			Input:             PackCancelPendingFeeConfigInput(),
+			SuppliedGas:       CancelPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			ExpectedErr:       ErrCannotSchedulePendingFee.Error(),
+		},
+		"cancel without pending config fails": {
+			Caller:            allowlist.TestAdminAddr,
+			BeforeHook:        allowlist.SetDefaultRoles(Module.Address),
+			Input:             PackCancelPendingFeeConfigInput(),
+			SuppliedGas:       CancelPendingFeeConfigGasCost,
+			ReadOnly:          false,
+			SetupBlockContext: setTestBlockContext(testTimestamp),
+			ExpectedErr:       ErrNoPendingFeeConfig.Error(),
+		},
 	}
 )
 
+const (
+	testTimestamp           = uint64(100)
+	testActivationTimestamp = uint64(200)
+)
+
+// setTestBlockContext returns a SetupBlockContext that executes at [testBlockNumber] and [timestamp].
+func setTestBlockContext(timestamp uint64) func(*contract.MockBlockContext) {
+	return func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+		mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+	}
+}
+
+func TestApplyPendingFeeConfig(t *testing.T) {
+	state := state.NewTestStateDB(t)
+	ctrl := gomock.NewController(t)
+	blockContext := contract.NewMockBlockContext(ctrl)
+	blockContext.EXPECT().Number().Return(big.NewInt(6)).AnyTimes()
+	require.NoError(t, StoreFeeConfig(state, testFeeConfig, blockContext))
+
+	pendingFeeConfig := testFeeConfig
+	pendingFeeConfig.GasLimit = big.NewInt(10_000_000)
+	require.NoError(t, StorePendingFeeConfig(state, pendingFeeConfig, testActivationTimestamp))
+
+	// Before the activation timestamp the fee config is left untouched.
+	before := contract.NewMockBlockContext(ctrl)
+	before.EXPECT().Timestamp().Return(testActivationTimestamp - 1).AnyTimes()
+	require.NoError(t, ApplyPendingFeeConfig(state, before))
+	require.Equal(t, testFeeConfig, GetStoredFeeConfig(state))
+	_, activationTimestamp := GetPendingFeeConfig(state)
+	require.Equal(t, testActivationTimestamp, activationTimestamp)
+
+	// Once the activation timestamp is reached the pending fee config is applied.
+	at := contract.NewMockBlockContext(ctrl)
+	at.EXPECT().Timestamp().Return(testActivationTimestamp).AnyTimes()
+	at.EXPECT().Number().Return(testBlockNumber).AnyTimes()
+	require.NoError(t, ApplyPendingFeeConfig(state, at))
+	require.Equal(t, pendingFeeConfig, GetStoredFeeConfig(state))
+	require.Equal(t, testBlockNumber, GetFeeConfigLastChangedAt(state))
+	_, activationTimestamp = GetPendingFeeConfig(state)
+	require.Zero(t, activationTimestamp)
+}
+
 func TestFeeManager(t *testing.T) {
 	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
 }
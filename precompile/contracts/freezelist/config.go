@@ -0,0 +1,90 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezelist
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Config implements the StatefulPrecompileConfig interface while adding in the
+// FreezeList specific precompile config.
+type Config struct {
+	allowlist.AllowListConfig
+	precompileconfig.Upgrade
+	InitialFrozenAddresses []common.Address `json:"initialFrozenAddresses,omitempty"` // addresses to freeze when the upgrade activates
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
+// FreezeList with the given [admins], [enableds] and [managers] as members of the allowlist.
+// Also freezes [initialFrozen] when the upgrade activates.
+func NewConfig(blockTimestamp *uint64, admins []common.Address, enableds []common.Address, managers []common.Address, initialFrozen []common.Address) *Config {
+	return &Config{
+		AllowListConfig: allowlist.AllowListConfig{
+			AdminAddresses:   admins,
+			EnabledAddresses: enableds,
+			ManagerAddresses: managers,
+		},
+		Upgrade:                precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+		InitialFrozenAddresses: initialFrozen,
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables FreezeList.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+func (*Config) Key() string { return ConfigKey }
+
+// Equal returns true if [cfg] is a [*FreezeListConfig] and it has been configured identical to [c].
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (cfg).(*Config)
+	if !ok {
+		return false
+	}
+	eq := c.Upgrade.Equal(&other.Upgrade) && c.AllowListConfig.Equal(&other.AllowListConfig)
+	if !eq {
+		return false
+	}
+
+	if len(c.InitialFrozenAddresses) != len(other.InitialFrozenAddresses) {
+		return false
+	}
+	for i, addr := range c.InitialFrozenAddresses {
+		if addr != other.InitialFrozenAddresses[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	frozen := make(map[common.Address]struct{}, len(c.InitialFrozenAddresses))
+	for _, addr := range c.InitialFrozenAddresses {
+		if _, ok := frozen[addr]; ok {
+			return fmt.Errorf("duplicate address in initial frozen list: %s", addr)
+		}
+		frozen[addr] = struct{}{}
+	}
+	// Freezing an admin would lock the allow list out of its own precompile.
+	for _, adminAddr := range c.AdminAddresses {
+		if _, ok := frozen[adminAddr]; ok {
+			return fmt.Errorf("%w: %s", ErrCannotFreezeAdmin, adminAddr)
+		}
+	}
+	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
+}
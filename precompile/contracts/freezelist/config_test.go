@@ -0,0 +1,67 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezelist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	tests := map[string]testutils.ConfigVerifyTest{
+		"duplicate initial frozen address": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{allowlist.TestNoRoleAddr, allowlist.TestNoRoleAddr}),
+			ExpectedError: "duplicate address in initial frozen list",
+		},
+		"initial frozen admin": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{allowlist.TestAdminAddr}),
+			ExpectedError: ErrCannotFreezeAdmin.Error(),
+		},
+		"valid initial frozen addresses": {
+			Config:        NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{allowlist.TestNoRoleAddr, allowlist.TestEnabledAddr}),
+			ExpectedError: "",
+		},
+	}
+	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
+}
+
+func TestEqual(t *testing.T) {
+	admins := []common.Address{allowlist.TestAdminAddr}
+	frozen := []common.Address{allowlist.TestNoRoleAddr}
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Other:    NewConfig(utils.NewUint64(4), admins, nil, nil, frozen),
+			Expected: false,
+		},
+		"different initial frozen addresses": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, []common.Address{allowlist.TestEnabledAddr}),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Other:    NewConfig(utils.NewUint64(3), admins, nil, nil, frozen),
+			Expected: true,
+		},
+	}
+	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
+}
@@ -0,0 +1,218 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezelist
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	FreezeAccountGasCost   uint64 = contract.WriteGasCostPerSlot + 2*allowlist.ReadAllowListGasCost // write 1 slot + read caller and target roles
+	UnfreezeAccountGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost   // write 1 slot + read caller role
+	IsFrozenGasCost        uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	ErrCannotFreezeAccount   = errors.New("non-enabled cannot call freezeAccount")
+	ErrCannotUnfreezeAccount = errors.New("non-enabled cannot call unfreezeAccount")
+	ErrCannotFreezeAdmin     = errors.New("cannot freeze an admin of the freeze list")
+
+	// FreezeListRawABI contains the raw ABI of FreezeList contract.
+	//go:embed contract.abi
+	FreezeListRawABI string
+
+	FreezeListABI        = contract.ParseABI(FreezeListRawABI)
+	FreezeListPrecompile = createFreezeListPrecompile()
+
+	// frozenAccountKeyPrefix is written over the leading (zero) bytes of an address hash
+	// so the frozen flag of an address never collides with its allow list role slot.
+	frozenAccountKeyPrefix = []byte("frz")
+	frozenValue            = common.BigToHash(common.Big1)
+)
+
+// GetFreezeListAllowListStatus returns the role of [address] as of [timestamp] for the FreezeList list.
+func GetFreezeListAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
+}
+
+// SetFreezeListAllowListStatus sets the permissions of [address] to [role] for the
+// FreezeList list. Assumes [role] has already been verified as valid.
+func SetFreezeListAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// frozenAccountKey returns the storage key holding the frozen flag of [address].
+func frozenAccountKey(address common.Address) common.Hash {
+	key := address.Hash()
+	copy(key[:], frozenAccountKeyPrefix)
+	return key
+}
+
+// IsAccountFrozen returns true if [address] has been frozen.
+func IsAccountFrozen(stateDB contract.StateDB, address common.Address) bool {
+	return stateDB.GetState(ContractAddress, frozenAccountKey(address)) == frozenValue
+}
+
+// SetAccountFrozen freezes [address] if [frozen] is true, otherwise unfreezes it.
+func SetAccountFrozen(stateDB contract.StateDB, address common.Address, frozen bool) {
+	value := common.Hash{}
+	if frozen {
+		value = frozenValue
+	}
+	stateDB.SetState(ContractAddress, frozenAccountKey(address), value)
+}
+
+// PackFreezeAccount packs [addr] of type common.Address into the appropriate arguments for freezeAccount.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackFreezeAccount(addr common.Address) ([]byte, error) {
+	return FreezeListABI.Pack("freezeAccount", addr)
+}
+
+// PackUnfreezeAccount packs [addr] of type common.Address into the appropriate arguments for unfreezeAccount.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackUnfreezeAccount(addr common.Address) ([]byte, error) {
+	return FreezeListABI.Pack("unfreezeAccount", addr)
+}
+
+// PackIsFrozen packs [addr] of type common.Address into the appropriate arguments for isFrozen.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackIsFrozen(addr common.Address) ([]byte, error) {
+	return FreezeListABI.Pack("isFrozen", addr)
+}
+
+// PackIsFrozenOutput attempts to pack given frozen of type bool
+// to conform the ABI outputs.
+func PackIsFrozenOutput(frozen bool) ([]byte, error) {
+	return FreezeListABI.PackOutput("isFrozen", frozen)
+}
+
+// unpackAddressInput attempts to unpack [input] into the single common.Address argument of [method].
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func unpackAddressInput(method string, input []byte) (common.Address, error) {
+	res, err := FreezeListABI.UnpackInput(method, input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+func freezeAccount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, FreezeAccountGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, err := unpackAddressInput("freezeAccount", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, timestamp)
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotFreezeAccount, caller)
+	}
+	// Admins must stay able to manage the list, so they cannot be frozen.
+	if GetFreezeListAllowListStatus(stateDB, target, timestamp).IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotFreezeAdmin, target)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, FreezeListABI.Methods["freezeAccount"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	SetAccountFrozen(stateDB, target, true)
+	return []byte{}, remainingGas, nil
+}
+
+func unfreezeAccount(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, UnfreezeAccountGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, err := unpackAddressInput("unfreezeAccount", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotUnfreezeAccount, caller)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, FreezeListABI.Methods["unfreezeAccount"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	SetAccountFrozen(stateDB, target, false)
+	return []byte{}, remainingGas, nil
+}
+
+func isFrozen(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsFrozenGasCost); err != nil {
+		return nil, 0, err
+	}
+	target, err := unpackAddressInput("isFrozen", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	packedOutput, err := PackIsFrozenOutput(IsAccountFrozen(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createFreezeListPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress].
+func createFreezeListPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"freezeAccount":   freezeAccount,
+		"unfreezeAccount": unfreezeAccount,
+		"isFrozen":        isFrozen,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := FreezeListABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
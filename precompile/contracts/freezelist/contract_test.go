@@ -0,0 +1,164 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package freezelist
+
+import (
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var tests = map[string]testutils.PrecompileTest{
+	"freeze account from no role fails": {
+		Caller:     allowlist.TestNoRoleAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackFreezeAccount(allowlist.TestEnabledAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: FreezeAccountGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotFreezeAccount.Error(),
+	},
+	"freeze account from enabled address": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackFreezeAccount(allowlist.TestNoRoleAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: FreezeAccountGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.True(t, IsAccountFrozen(state, allowlist.TestNoRoleAddr))
+			// freezing must not clobber the allow list role of the frozen address
+			require.Equal(t, allowlist.NoRole, GetFreezeListAllowListStatus(state, allowlist.TestNoRoleAddr, 0))
+		},
+	},
+	"freeze admin fails": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackFreezeAccount(allowlist.TestAdminAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: FreezeAccountGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotFreezeAdmin.Error(),
+	},
+	"freeze account readOnly": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackFreezeAccount(allowlist.TestNoRoleAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: FreezeAccountGasCost,
+		ReadOnly:    true,
+		ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+	},
+	"freeze account insufficient gas": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackFreezeAccount(allowlist.TestNoRoleAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: FreezeAccountGasCost - 1,
+		ReadOnly:    false,
+		ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+	},
+	"unfreeze account from manager": {
+		Caller: allowlist.TestManagerAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			SetAccountFrozen(state, allowlist.TestNoRoleAddr, true)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackUnfreezeAccount(allowlist.TestNoRoleAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: UnfreezeAccountGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.False(t, IsAccountFrozen(state, allowlist.TestNoRoleAddr))
+		},
+	},
+	"unfreeze account from no role fails": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			SetAccountFrozen(state, allowlist.TestNoRoleAddr, true)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackUnfreezeAccount(allowlist.TestNoRoleAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: UnfreezeAccountGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotUnfreezeAccount.Error(),
+	},
+	"is frozen readOnly": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			SetAccountFrozen(state, allowlist.TestEnabledAddr, true)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackIsFrozen(allowlist.TestEnabledAddr)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: IsFrozenGasCost,
+		ReadOnly:    true,
+		ExpectedRes: func() []byte {
+			res, err := PackIsFrozenOutput(true)
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	"initial frozen addresses": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			InitialFrozenAddresses: []common.Address{allowlist.TestNoRoleAddr},
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.True(t, IsAccountFrozen(state, allowlist.TestNoRoleAddr))
+			require.False(t, IsAccountFrozen(state, allowlist.TestEnabledAddr))
+		},
+	},
+}
+
+func TestFreezeListRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkFreezeList(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,447 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gassponsor
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// SponsorshipPeriod is the length in seconds of the window the daily budget of a sponsor applies to.
+	SponsorshipPeriod uint64 = 24 * 60 * 60
+
+	SetSponsorshipPolicyGasCost uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost                               // write 1 slot + read caller role
+	SetSponsoredTargetGasCost   uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost // read and write 1 slot + read caller role
+	SetTargetSponsorGasCost     uint64 = contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost                               // write 1 slot + read caller role
+	GetSponsorshipPolicyGasCost uint64 = contract.ReadGasCostPerSlot
+	GetTargetSponsorGasCost     uint64 = contract.ReadGasCostPerSlot
+	GetSponsoredSpendGasCost    uint64 = contract.ReadGasCostPerSlot
+)
+
+var (
+	ErrCannotSetSponsorshipPolicy = errors.New("non-enabled cannot call setSponsorshipPolicy")
+	ErrCannotSetSponsoredTarget   = errors.New("non-enabled cannot call setSponsoredTarget")
+	ErrCannotSetTargetSponsor     = errors.New("non-admin cannot call setTargetSponsor")
+	ErrTargetAlreadySponsored     = errors.New("target is already sponsored by another sponsor")
+	ErrNotTargetSponsor           = errors.New("caller is not the sponsor of target")
+	ErrDailyBudgetTooLarge        = errors.New("daily budget does not fit in 192 bits")
+
+	// GasSponsorRawABI contains the raw ABI of GasSponsor contract.
+	//go:embed contract.abi
+	GasSponsorRawABI string
+
+	GasSponsorABI        = contract.ParseABI(GasSponsorRawABI)
+	GasSponsorPrecompile = createGasSponsorPrecompile()
+
+	setTargetSponsorSignature = contract.CalculateFunctionSelector("setTargetSponsor(address,address)")
+
+	// Per-address keys are written over the leading (zero) bytes of an address hash
+	// so they never collide with the allow list role slot of the address.
+	dailyBudgetKeyPrefix   = []byte("gsb")
+	targetSponsorKeyPrefix = []byte("gst")
+	// sponsoredSpendKeyPrefix is hashed together with a sponsor and a user to key their spend.
+	sponsoredSpendKeyPrefix = []byte("gss")
+
+	// maxSpend is the largest amount the 24 byte spend field of a spend slot can hold.
+	maxSpend = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 192), common.Big1)
+)
+
+// GetGasSponsorAllowListStatus returns the role of [address] as of [timestamp] for the GasSponsor list.
+func GetGasSponsorAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
+}
+
+// SetGasSponsorAllowListStatus sets the permissions of [address] to [role] for the
+// GasSponsor list. Assumes [role] has already been verified as valid.
+func SetGasSponsorAllowListStatus(stateDB contract.StateDB, address common.Address, role allowlist.Role) {
+	allowlist.SetAllowListRole(stateDB, ContractAddress, address, role)
+}
+
+// addressKey returns the storage key of [address] under [prefix].
+func addressKey(prefix []byte, address common.Address) common.Hash {
+	key := address.Hash()
+	copy(key[:], prefix)
+	return key
+}
+
+// sponsoredSpendKey returns the storage key holding the spend of [sponsor] on behalf of [user].
+func sponsoredSpendKey(sponsor common.Address, user common.Address) common.Hash {
+	return crypto.Keccak256Hash(sponsoredSpendKeyPrefix, sponsor.Bytes(), user.Bytes())
+}
+
+// sponsorshipPeriod returns the index of the sponsorship period [timestamp] falls in.
+func sponsorshipPeriod(timestamp uint64) uint64 {
+	return timestamp / SponsorshipPeriod
+}
+
+// GetDailyBudget returns the amount [sponsor] pays at most on behalf of each user per sponsorship period.
+func GetDailyBudget(stateDB contract.StateDB, sponsor common.Address) *big.Int {
+	return stateDB.GetState(ContractAddress, addressKey(dailyBudgetKeyPrefix, sponsor)).Big()
+}
+
+// SetDailyBudget sets the amount [sponsor] pays at most on behalf of each user per sponsorship period.
+// A zero budget stops [sponsor] from paying for any transaction.
+func SetDailyBudget(stateDB contract.StateDB, sponsor common.Address, dailyBudget *big.Int) {
+	stateDB.SetState(ContractAddress, addressKey(dailyBudgetKeyPrefix, sponsor), common.BigToHash(dailyBudget))
+}
+
+// GetTargetSponsor returns the sponsor paying for transactions sent to [target], or the
+// empty address if [target] is not sponsored.
+func GetTargetSponsor(stateDB contract.StateDB, target common.Address) common.Address {
+	return common.BytesToAddress(stateDB.GetState(ContractAddress, addressKey(targetSponsorKeyPrefix, target)).Bytes())
+}
+
+// SetTargetSponsor sets [sponsor] to pay for transactions sent to [target].
+// Setting the empty address stops sponsoring [target].
+func SetTargetSponsor(stateDB contract.StateDB, target common.Address, sponsor common.Address) {
+	stateDB.SetState(ContractAddress, addressKey(targetSponsorKeyPrefix, target), common.BytesToHash(sponsor.Bytes()))
+}
+
+// GetSponsoredSpend returns the amount [sponsor] paid on behalf of [user] in the sponsorship period of [timestamp].
+func GetSponsoredSpend(stateDB contract.StateDB, sponsor common.Address, user common.Address, timestamp uint64) *big.Int {
+	// The spend slot holds the period it was last written in followed by the spend in that period.
+	val := stateDB.GetState(ContractAddress, sponsoredSpendKey(sponsor, user))
+	if new(big.Int).SetBytes(val[:8]).Uint64() != sponsorshipPeriod(timestamp) {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(val[8:])
+}
+
+// AddSponsoredSpend adds [amount] to the spend of [sponsor] on behalf of [user] in the sponsorship period of [timestamp].
+func AddSponsoredSpend(stateDB contract.StateDB, sponsor common.Address, user common.Address, amount *big.Int, timestamp uint64) {
+	spent := GetSponsoredSpend(stateDB, sponsor, user, timestamp)
+	spent.Add(spent, amount)
+	if spent.Cmp(maxSpend) > 0 {
+		spent.Set(maxSpend)
+	}
+	val := common.Hash{}
+	new(big.Int).SetUint64(sponsorshipPeriod(timestamp)).FillBytes(val[:8])
+	spent.FillBytes(val[8:])
+	stateDB.SetState(ContractAddress, sponsoredSpendKey(sponsor, user), val)
+}
+
+// SponsorOf returns the sponsor paying [cost] in transaction fees on behalf of [user] for a
+// transaction sent to [to] at [timestamp]. Returns false if the transaction is not sponsored:
+// [to] has no sponsor, the sponsor is no longer enabled, [cost] would exceed the daily budget
+// of the sponsor for [user] or the sponsor cannot afford [cost].
+func SponsorOf(stateDB contract.StateDB, to *common.Address, user common.Address, cost *big.Int, timestamp uint64) (common.Address, bool) {
+	if to == nil {
+		return common.Address{}, false
+	}
+	sponsor := GetTargetSponsor(stateDB, *to)
+	if sponsor == (common.Address{}) {
+		return common.Address{}, false
+	}
+	if !GetGasSponsorAllowListStatus(stateDB, sponsor, timestamp).IsEnabled() {
+		return common.Address{}, false
+	}
+	spent := GetSponsoredSpend(stateDB, sponsor, user, timestamp)
+	if spent.Add(spent, cost).Cmp(GetDailyBudget(stateDB, sponsor)) > 0 {
+		return common.Address{}, false
+	}
+	if stateDB.GetBalance(sponsor).Cmp(cost) < 0 {
+		return common.Address{}, false
+	}
+	return sponsor, true
+}
+
+// PackSetSponsorshipPolicy packs [dailyBudget] of type *big.Int into the appropriate arguments for setSponsorshipPolicy.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetSponsorshipPolicy(dailyBudget *big.Int) ([]byte, error) {
+	return GasSponsorABI.Pack("setSponsorshipPolicy", dailyBudget)
+}
+
+// UnpackSetSponsorshipPolicyInput attempts to unpack [input] into the *big.Int type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetSponsorshipPolicyInput(input []byte) (*big.Int, error) {
+	res, err := GasSponsorABI.UnpackInput("setSponsorshipPolicy", input)
+	if err != nil {
+		return nil, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(*big.Int)).(**big.Int)
+	return unpacked, nil
+}
+
+// PackSetSponsoredTarget packs [target] and [sponsored] into the appropriate arguments for setSponsoredTarget.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetSponsoredTarget(target common.Address, sponsored bool) ([]byte, error) {
+	return GasSponsorABI.Pack("setSponsoredTarget", target, sponsored)
+}
+
+// UnpackSetSponsoredTargetInput attempts to unpack [input] into the target and sponsored arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetSponsoredTargetInput(input []byte) (common.Address, bool, error) {
+	res, err := GasSponsorABI.UnpackInput("setSponsoredTarget", input)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	target := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	sponsored := *abi.ConvertType(res[1], new(bool)).(*bool)
+	return target, sponsored, nil
+}
+
+// PackSetTargetSponsor packs [target] and [sponsor] into the appropriate arguments for setTargetSponsor.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetTargetSponsor(target common.Address, sponsor common.Address) ([]byte, error) {
+	return GasSponsorABI.Pack("setTargetSponsor", target, sponsor)
+}
+
+// UnpackSetTargetSponsorInput attempts to unpack [input] into the target and sponsor arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetTargetSponsorInput(input []byte) (common.Address, common.Address, error) {
+	res, err := GasSponsorABI.UnpackInput("setTargetSponsor", input)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	target := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	sponsor := *abi.ConvertType(res[1], new(common.Address)).(*common.Address)
+	return target, sponsor, nil
+}
+
+// PackGetSponsorshipPolicy packs [sponsor] of type common.Address into the appropriate arguments for getSponsorshipPolicy.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetSponsorshipPolicy(sponsor common.Address) ([]byte, error) {
+	return GasSponsorABI.Pack("getSponsorshipPolicy", sponsor)
+}
+
+// PackGetSponsorshipPolicyOutput attempts to pack given dailyBudget of type *big.Int
+// to conform the ABI outputs.
+func PackGetSponsorshipPolicyOutput(dailyBudget *big.Int) ([]byte, error) {
+	return GasSponsorABI.PackOutput("getSponsorshipPolicy", dailyBudget)
+}
+
+// PackGetTargetSponsor packs [target] of type common.Address into the appropriate arguments for getTargetSponsor.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetTargetSponsor(target common.Address) ([]byte, error) {
+	return GasSponsorABI.Pack("getTargetSponsor", target)
+}
+
+// PackGetTargetSponsorOutput attempts to pack given sponsor of type common.Address
+// to conform the ABI outputs.
+func PackGetTargetSponsorOutput(sponsor common.Address) ([]byte, error) {
+	return GasSponsorABI.PackOutput("getTargetSponsor", sponsor)
+}
+
+// PackGetSponsoredSpend packs [sponsor] and [user] into the appropriate arguments for getSponsoredSpend.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetSponsoredSpend(sponsor common.Address, user common.Address) ([]byte, error) {
+	return GasSponsorABI.Pack("getSponsoredSpend", sponsor, user)
+}
+
+// UnpackGetSponsoredSpendInput attempts to unpack [input] into the sponsor and user arguments
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetSponsoredSpendInput(input []byte) (common.Address, common.Address, error) {
+	res, err := GasSponsorABI.UnpackInput("getSponsoredSpend", input)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	sponsor := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	user := *abi.ConvertType(res[1], new(common.Address)).(*common.Address)
+	return sponsor, user, nil
+}
+
+// PackGetSponsoredSpendOutput attempts to pack given spent of type *big.Int
+// to conform the ABI outputs.
+func PackGetSponsoredSpendOutput(spent *big.Int) ([]byte, error) {
+	return GasSponsorABI.PackOutput("getSponsoredSpend", spent)
+}
+
+// unpackAddressInput attempts to unpack [input] into the single common.Address argument of [method].
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func unpackAddressInput(method string, input []byte) (common.Address, error) {
+	res, err := GasSponsorABI.UnpackInput(method, input)
+	if err != nil {
+		return common.Address{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(common.Address)).(*common.Address)
+	return unpacked, nil
+}
+
+func setSponsorshipPolicy(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetSponsorshipPolicyGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	dailyBudget, err := UnpackSetSponsorshipPolicyInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if dailyBudget.Cmp(maxSpend) > 0 {
+		return nil, remainingGas, fmt.Errorf("%w: %d", ErrDailyBudgetTooLarge, dailyBudget)
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is a whitelisted sponsor and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetSponsorshipPolicy, caller)
+	}
+
+	SetDailyBudget(stateDB, caller, dailyBudget)
+	return []byte{}, remainingGas, nil
+}
+
+func setSponsoredTarget(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetSponsoredTargetGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, sponsored, err := UnpackSetSponsoredTargetInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is a whitelisted sponsor and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetSponsoredTarget, caller)
+	}
+
+	// A target is paid for by at most one sponsor, and only that sponsor can stop paying for it.
+	currentSponsor := GetTargetSponsor(stateDB, target)
+	if sponsored {
+		if currentSponsor != (common.Address{}) && currentSponsor != caller {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrTargetAlreadySponsored, target)
+		}
+		SetTargetSponsor(stateDB, target, caller)
+	} else {
+		if currentSponsor != caller {
+			return nil, remainingGas, fmt.Errorf("%w: %s", ErrNotTargetSponsor, target)
+		}
+		SetTargetSponsor(stateDB, target, common.Address{})
+	}
+	return []byte{}, remainingGas, nil
+}
+
+// setTargetSponsor lets admins assign the sponsor of a target, overriding the sponsor that claimed it
+// with setSponsoredTarget. Setting the empty address stops sponsoring the target.
+func setTargetSponsor(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetTargetSponsorGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	target, sponsor, err := UnpackSetTargetSponsorInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetTargetSponsor, caller)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, setTargetSponsorSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	SetTargetSponsor(stateDB, target, sponsor)
+	return []byte{}, remainingGas, nil
+}
+
+func getSponsorshipPolicy(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSponsorshipPolicyGasCost); err != nil {
+		return nil, 0, err
+	}
+	sponsor, err := unpackAddressInput("getSponsorshipPolicy", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	packedOutput, err := PackGetSponsorshipPolicyOutput(GetDailyBudget(accessibleState.GetStateDB(), sponsor))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+func getTargetSponsor(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetTargetSponsorGasCost); err != nil {
+		return nil, 0, err
+	}
+	target, err := unpackAddressInput("getTargetSponsor", input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	packedOutput, err := PackGetTargetSponsorOutput(GetTargetSponsor(accessibleState.GetStateDB(), target))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+func getSponsoredSpend(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetSponsoredSpendGasCost); err != nil {
+		return nil, 0, err
+	}
+	sponsor, user, err := UnpackGetSponsoredSpendInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	spent := GetSponsoredSpend(accessibleState.GetStateDB(), sponsor, user, accessibleState.GetBlockContext().Timestamp())
+	packedOutput, err := PackGetSponsoredSpendOutput(spent)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createGasSponsorPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
+// Access to the setters is controlled by an allow list for [ContractAddress], whose enabled
+// addresses are the whitelisted sponsors.
+func createGasSponsorPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+	functions = append(functions, allowlist.CreateAllowListFunctions(ContractAddress)...)
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"setSponsorshipPolicy": setSponsorshipPolicy,
+		"setSponsoredTarget":   setSponsoredTarget,
+		"setTargetSponsor":     setTargetSponsor,
+		"getSponsorshipPolicy": getSponsorshipPolicy,
+		"getTargetSponsor":     getTargetSponsor,
+		"getSponsoredSpend":    getSponsoredSpend,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := GasSponsorABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
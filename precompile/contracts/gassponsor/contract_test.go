@@ -0,0 +1,413 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gassponsor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTarget      = common.HexToAddress("0x0123")
+	testDailyBudget = big.NewInt(1_000_000)
+	testTimestamp   = 3 * SponsorshipPeriod
+
+	tests = map[string]testutils.PrecompileTest{
+		"set sponsorship policy from no role fails": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsorshipPolicy(testDailyBudget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsorshipPolicyGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetSponsorshipPolicy.Error(),
+		},
+		"set sponsorship policy from enabled succeeds": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsorshipPolicy(testDailyBudget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsorshipPolicyGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, testDailyBudget, GetDailyBudget(state, allowlist.TestEnabledAddr))
+				// the budget must not clobber the allow list role of the sponsor
+				require.Equal(t, allowlist.EnabledRole, GetGasSponsorAllowListStatus(state, allowlist.TestEnabledAddr, 0))
+			},
+		},
+		"set too large sponsorship policy fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsorshipPolicy(new(big.Int).Lsh(common.Big1, 192))
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsorshipPolicyGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrDailyBudgetTooLarge.Error(),
+		},
+		"set sponsorship policy readOnly": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsorshipPolicy(testDailyBudget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsorshipPolicyGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"set sponsorship policy insufficient gas": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsorshipPolicy(testDailyBudget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsorshipPolicyGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"set sponsored target from no role fails": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsoredTarget(testTarget, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsoredTargetGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetSponsoredTarget.Error(),
+		},
+		"set sponsored target from enabled succeeds": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsoredTarget(testTarget, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsoredTargetGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, allowlist.TestEnabledAddr, GetTargetSponsor(state, testTarget))
+			},
+		},
+		"set target sponsored by another sponsor fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetSponsor(state, testTarget, allowlist.TestManagerAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsoredTarget(testTarget, true)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsoredTargetGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrTargetAlreadySponsored.Error(),
+		},
+		"unset sponsored target from its sponsor succeeds": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetSponsor(state, testTarget, allowlist.TestEnabledAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsoredTarget(testTarget, false)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsoredTargetGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, common.Address{}, GetTargetSponsor(state, testTarget))
+			},
+		},
+		"unset sponsored target from another sponsor fails": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetSponsor(state, testTarget, allowlist.TestManagerAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetSponsoredTarget(testTarget, false)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetSponsoredTargetGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrNotTargetSponsor.Error(),
+		},
+		"set target sponsor from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetSponsor(testTarget, allowlist.TestEnabledAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetTargetSponsorGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetTargetSponsor.Error(),
+		},
+		"set target sponsor from admin overrides claim": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetSponsor(state, testTarget, allowlist.TestManagerAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetSponsor(testTarget, allowlist.TestEnabledAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetTargetSponsorGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, allowlist.TestEnabledAddr, GetTargetSponsor(state, testTarget))
+			},
+		},
+		"unset target sponsor from admin succeeds": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				SetTargetSponsor(state, testTarget, allowlist.TestManagerAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetSponsor(testTarget, common.Address{})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetTargetSponsorGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, common.Address{}, GetTargetSponsor(state, testTarget))
+			},
+		},
+		"set target sponsor readOnly": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetTargetSponsor(testTarget, allowlist.TestEnabledAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetTargetSponsorGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"get sponsorship policy from no role succeeds": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetDailyBudget(state, allowlist.TestEnabledAddr, testDailyBudget)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetSponsorshipPolicy(allowlist.TestEnabledAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetSponsorshipPolicyGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetSponsorshipPolicyOutput(testDailyBudget)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get target sponsor from no role succeeds": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetTargetSponsor(state, testTarget, allowlist.TestEnabledAddr)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetTargetSponsor(testTarget)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetTargetSponsorGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetTargetSponsorOutput(allowlist.TestEnabledAddr)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get sponsored spend from no role succeeds": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				AddSponsoredSpend(state, allowlist.TestEnabledAddr, allowlist.TestNoRoleAddr, big.NewInt(100), testTimestamp)
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Timestamp().Return(testTimestamp + 1).AnyTimes()
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetSponsoredSpend(allowlist.TestEnabledAddr, allowlist.TestNoRoleAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetSponsoredSpendGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetSponsoredSpendOutput(big.NewInt(100))
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get sponsored spend resets every period": {
+			Caller: allowlist.TestNoRoleAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				AddSponsoredSpend(state, allowlist.TestEnabledAddr, allowlist.TestNoRoleAddr, big.NewInt(100), testTimestamp)
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().Timestamp().Return(testTimestamp + SponsorshipPeriod).AnyTimes()
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetSponsoredSpend(allowlist.TestEnabledAddr, allowlist.TestNoRoleAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: GetSponsoredSpendGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetSponsoredSpendOutput(common.Big0)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+	}
+)
+
+func TestGasSponsorRun(t *testing.T) {
+	allowlist.RunPrecompileWithAllowListTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func BenchmarkGasSponsor(b *testing.B) {
+	allowlist.BenchPrecompileWithAllowList(b, Module, state.NewTestStateDB, tests)
+}
+
+func TestSponsorOf(t *testing.T) {
+	var (
+		sponsor = allowlist.TestEnabledAddr
+		user    = allowlist.TestNoRoleAddr
+		cost    = big.NewInt(400_000)
+	)
+	tests := map[string]struct {
+		setup    func(state contract.StateDB)
+		to       *common.Address
+		expected bool
+	}{
+		"contract creation": {
+			to:       nil,
+			expected: false,
+		},
+		"target not sponsored": {
+			setup: func(state contract.StateDB) {
+				SetTargetSponsor(state, testTarget, common.Address{})
+			},
+			to:       &testTarget,
+			expected: false,
+		},
+		"sponsor no longer enabled": {
+			setup: func(state contract.StateDB) {
+				SetGasSponsorAllowListStatus(state, sponsor, allowlist.NoRole)
+			},
+			to:       &testTarget,
+			expected: false,
+		},
+		"cost exceeds remaining budget": {
+			setup: func(state contract.StateDB) {
+				AddSponsoredSpend(state, sponsor, user, big.NewInt(600_001), testTimestamp)
+			},
+			to:       &testTarget,
+			expected: false,
+		},
+		"budget spent in previous period": {
+			setup: func(state contract.StateDB) {
+				AddSponsoredSpend(state, sponsor, user, testDailyBudget, testTimestamp-SponsorshipPeriod)
+			},
+			to:       &testTarget,
+			expected: true,
+		},
+		"sponsor cannot afford cost": {
+			setup: func(state contract.StateDB) {
+				state.SubBalance(sponsor, big.NewInt(1))
+			},
+			to:       &testTarget,
+			expected: false,
+		},
+		"sponsored": {
+			to:       &testTarget,
+			expected: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := state.NewTestStateDB(t)
+			SetGasSponsorAllowListStatus(state, sponsor, allowlist.EnabledRole)
+			SetDailyBudget(state, sponsor, testDailyBudget)
+			state.AddBalance(sponsor, cost)
+			SetTargetSponsor(state, testTarget, sponsor)
+			if test.setup != nil {
+				test.setup(state)
+			}
+
+			gotSponsor, ok := SponsorOf(state, test.to, user, cost, testTimestamp)
+			require.Equal(t, test.expected, ok)
+			if ok {
+				require.Equal(t, sponsor, gotSponsor)
+			}
+		})
+	}
+}
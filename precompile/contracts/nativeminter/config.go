@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -16,12 +17,77 @@ import (
 
 var _ precompileconfig.Config = &Config{}
 
+// MintLimits specifies the maximum amounts that can be minted per [Window] seconds.
+// A zero or missing cap leaves the corresponding amount uncapped.
+type MintLimits struct {
+	Window     uint64                                   `json:"window"`               // length of the mint window in seconds
+	GlobalCap  *math.HexOrDecimal256                    `json:"globalCap,omitempty"`  // maximum amount all minters combined can mint per window
+	MinterCaps map[common.Address]*math.HexOrDecimal256 `json:"minterCaps,omitempty"` // maximum amount each minter can mint per window
+}
+
+// Equal returns true iff [other] specifies the same limits as [m].
+func (m *MintLimits) Equal(other *MintLimits) bool {
+	if other == nil {
+		return false
+	}
+	if m.Window != other.Window || !utils.BigNumEqual((*big.Int)(m.GlobalCap), (*big.Int)(other.GlobalCap)) {
+		return false
+	}
+	if len(m.MinterCaps) != len(other.MinterCaps) {
+		return false
+	}
+	for minter, minterCap := range m.MinterCaps {
+		otherCap, ok := other.MinterCaps[minter]
+		if !ok || !utils.BigNumEqual((*big.Int)(minterCap), (*big.Int)(otherCap)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify returns an error if a cap is invalid or caps are specified without a window.
+func (m *MintLimits) Verify() error {
+	hasCap := false
+	if m.GlobalCap != nil {
+		if (*big.Int)(m.GlobalCap).Sign() < 0 {
+			return fmt.Errorf("mint limits cannot contain negative global cap %v", (*big.Int)(m.GlobalCap))
+		}
+		hasCap = true
+	}
+	for minter, minterCap := range m.MinterCaps {
+		if minterCap == nil {
+			return fmt.Errorf("mint limits cannot contain nil cap for minter %s", minter)
+		}
+		if (*big.Int)(minterCap).Sign() < 0 {
+			return fmt.Errorf("mint limits cannot contain negative cap %v for minter %s", (*big.Int)(minterCap), minter)
+		}
+		hasCap = true
+	}
+	if hasCap && m.Window == 0 {
+		return ErrMintCapWithoutWindow
+	}
+	return nil
+}
+
+// Configure stores the mint limits in [state].
+func (m *MintLimits) Configure(state contract.StateDB) {
+	globalCap := new(big.Int)
+	if m.GlobalCap != nil {
+		globalCap = (*big.Int)(m.GlobalCap)
+	}
+	StoreMintLimits(state, m.Window, globalCap)
+	for minter, minterCap := range m.MinterCaps {
+		StoreMinterCap(state, minter, (*big.Int)(minterCap))
+	}
+}
+
 // Config implements the StatefulPrecompileConfig interface while adding in the
 // ContractNativeMinter specific precompile config.
 type Config struct {
 	allowlist.AllowListConfig
 	precompileconfig.Upgrade
 	InitialMint map[common.Address]*math.HexOrDecimal256 `json:"initialMint,omitempty"` // addresses to receive the initial mint mapped to the amount to mint
+	MintLimits  *MintLimits                              `json:"mintLimits,omitempty"`  // limits on the amounts minted per window
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -79,7 +145,10 @@ func (c *Config) Equal(cfg precompileconfig.Config) bool {
 		}
 	}
 
-	return true
+	if c.MintLimits == nil || other.MintLimits == nil {
+		return c.MintLimits == other.MintLimits
+	}
+	return c.MintLimits.Equal(other.MintLimits)
 }
 
 func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
@@ -93,5 +162,13 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 			return fmt.Errorf("initial mint cannot contain invalid amount %v for address %s", bigIntAmount, addr)
 		}
 	}
+	if c.MintLimits != nil {
+		if c.Upgrade.Timestamp() != nil && !chainConfig.IsDUpgrade(*c.Upgrade.Timestamp()) {
+			return ErrMintLimitsBeforeDUpgrade
+		}
+		if err := c.MintLimits.Verify(); err != nil {
+			return err
+		}
+	}
 	return c.AllowListConfig.Verify(chainConfig, c.Upgrade)
 }
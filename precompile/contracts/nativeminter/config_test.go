@@ -48,6 +48,50 @@ func TestVerify(t *testing.T) {
 				}),
 			ExpectedError: "initial mint cannot contain invalid amount",
 		},
+		"mint caps without window in native minter config": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				GlobalCap: math.NewHexOrDecimal256(1),
+			}),
+			ExpectedError: ErrMintCapWithoutWindow.Error(),
+		},
+		"negative global cap in native minter config": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window:    100,
+				GlobalCap: math.NewHexOrDecimal256(-1),
+			}),
+			ExpectedError: "mint limits cannot contain negative global cap",
+		},
+		"nil minter cap in native minter config": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window: 100,
+				MinterCaps: map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): nil,
+				},
+			}),
+			ExpectedError: "mint limits cannot contain nil cap",
+		},
+		"mint limits before DUpgrade in native minter config": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window:    100,
+				GlobalCap: math.NewHexOrDecimal256(10),
+			}),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDUpgrade(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: ErrMintLimitsBeforeDUpgrade.Error(),
+		},
+		"valid mint limits in native minter config": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window:    100,
+				GlobalCap: math.NewHexOrDecimal256(10),
+				MinterCaps: map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				},
+			}),
+			ExpectedError: "",
+		},
 	}
 	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -105,6 +149,44 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: true,
 		},
+		"different mint limits": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window:    100,
+				GlobalCap: math.NewHexOrDecimal256(1),
+			}),
+			Other: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window:    100,
+				GlobalCap: math.NewHexOrDecimal256(2),
+			}),
+			Expected: false,
+		},
+		"nil and non-nil mint limits": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, nil),
+			Other: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window: 100,
+			}),
+			Expected: false,
+		},
+		"same mint limits": {
+			Config: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window: 100,
+				MinterCaps: map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				},
+			}),
+			Other: withMintLimits(NewConfig(utils.NewUint64(3), admins, nil, nil, nil), &MintLimits{
+				Window: 100,
+				MinterCaps: map[common.Address]*math.HexOrDecimal256{
+					common.HexToAddress("0x01"): math.NewHexOrDecimal256(1),
+				},
+			}),
+			Expected: true,
+		},
 	}
 	allowlist.EqualPrecompileWithAllowListTests(t, Module, tests)
 }
+
+func withMintLimits(config *Config, limits *MintLimits) *Config {
+	config.MintLimits = limits
+	return config
+}
@@ -19,21 +19,77 @@ const (
 	mintInputAmountSlot
 
 	mintInputLen = common.HashLength + common.HashLength
+)
+
+const (
+	setMintLimitsInputWindowSlot = iota
+	setMintLimitsInputGlobalCapSlot
+
+	setMintLimitsInputLen = common.HashLength + common.HashLength
+	setMinterCapInputLen  = mintInputLen
+	readMinterCapInputLen = common.HashLength
+	burnInputLen          = common.HashLength
+
+	// mintWindowBuckets is the number of buckets each mint window is divided into. The amount minted
+	// in the rolling window is tracked in one more bucket, so that every mint of the last window is counted.
+	mintWindowBuckets = 4
+	// windowMintedReadGasCost is the cost of reading the amount minted in a rolling window.
+	windowMintedReadGasCost = 2 * (mintWindowBuckets + 1) * contract.ReadGasCostPerSlot
 
 	MintGasCost = 30_000
+	// MintLimitsGasCost is charged in addition to MintGasCost when mint limits are active.
+	MintLimitsGasCost     = 3*contract.ReadGasCostPerSlot + 2*windowMintedReadGasCost + 4*contract.WriteGasCostPerSlot // read and update global and minter windows
+	SetMintLimitsGasCost  = 2*contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost
+	SetMinterCapGasCost   = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot + allowlist.ReadAllowListGasCost
+	ReadMintLimitsGasCost = 2*contract.ReadGasCostPerSlot + windowMintedReadGasCost
+	ReadMinterCapGasCost  = 2*contract.ReadGasCostPerSlot + windowMintedReadGasCost
+	// SupplyTrackingGasCost is charged in addition to MintGasCost to update the total minted counter.
+	SupplyTrackingGasCost     = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+	BurnGasCost               = MintGasCost + SupplyTrackingGasCost
+	ReadSupplyCountersGasCost = 2 * contract.ReadGasCostPerSlot
 )
 
 var (
 	// Singleton StatefulPrecompiledContract for minting native assets by permissioned callers.
 	ContractNativeMinterPrecompile contract.StatefulPrecompiledContract = createNativeMinterPrecompile()
 
-	mintSignature = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
-	ErrCannotMint = errors.New("non-enabled cannot mint")
+	mintSignature           = contract.CalculateFunctionSelector("mintNativeCoin(address,uint256)") // address, amount
+	setMintLimitsSignature  = contract.CalculateFunctionSelector("setMintLimits(uint256,uint256)")  // window, globalCap
+	setMinterCapSignature   = contract.CalculateFunctionSelector("setMinterCap(address,uint256)")   // minter, cap
+	readMintLimitsSignature = contract.CalculateFunctionSelector("readMintLimits()")
+	readMinterCapSignature  = contract.CalculateFunctionSelector("readMinterCap(address)")  // minter
+	burnSignature           = contract.CalculateFunctionSelector("burnNativeCoin(uint256)") // amount
+	readSupplySignature     = contract.CalculateFunctionSelector("readSupplyCounters()")
+
+	ErrCannotMint               = errors.New("non-enabled cannot mint")
+	ErrCannotSetMintLimits      = errors.New("non-admin cannot set mint limits")
+	ErrGlobalMintCapExceeded    = errors.New("global mint cap exceeded for the rolling window")
+	ErrMinterMintCapExceeded    = errors.New("minter mint cap exceeded for the rolling window")
+	ErrMintCapWithoutWindow     = errors.New("mint caps require a non-zero mint window")
+	ErrMintLimitsBeforeDUpgrade = errors.New("cannot set mint limits before DUpgrade")
+	ErrInvalidMintLimitsInput   = errors.New("invalid input for mint limits")
+	ErrCannotBurn               = errors.New("non-enabled cannot burn")
+	ErrInsufficientBurnFunds    = errors.New("insufficient funds to burn")
+
+	// Storage keys for the mint limits. Per minter keys are derived by writing the
+	// prefix over the leading (zero) bytes of the minter's address hash. Keys of the
+	// buckets of a window are derived by writing the bucket slot after the prefix.
+	mintWindowKey            = common.Hash{'m', 'w'}
+	globalMintCapKey         = common.Hash{'g', 'm', 'c'}
+	globalWindowBucketKey    = common.Hash{'g', 'w', 'b'}
+	globalWindowMintedKey    = common.Hash{'g', 'w', 'm'}
+	minterCapKeyPrefix       = []byte("mcap")
+	minterWindowBucketPrefix = []byte("mwb")
+	minterWindowMintedPrefix = []byte("mwm")
+
+	// Storage keys for the supply counters.
+	totalMintedKey = common.Hash{'t', 'm'}
+	totalBurnedKey = common.Hash{'t', 'b'}
 )
 
-// GetContractNativeMinterStatus returns the role of [address] for the minter list.
-func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetContractNativeMinterStatus returns the role of [address] as of [timestamp] for the minter list.
+func GetContractNativeMinterStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // SetContractNativeMinterStatus sets the permissions of [address] to [role] for the
@@ -83,11 +139,37 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 	}
 
 	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, timestamp)
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotMint, caller)
 	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, mintSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	// Mint limits are only enforced (and charged for) once a mint window has been configured.
+	if window := GetMintWindow(stateDB); window != 0 {
+		if remainingGas, err = contract.DeductGas(remainingGas, MintLimitsGasCost); err != nil {
+			return nil, 0, err
+		}
+		if err := consumeMintAllowance(stateDB, caller, amount, window, timestamp); err != nil {
+			return nil, remainingGas, err
+		}
+	}
+
+	// Supply counters are only maintained (and charged for) once supply tracking is activated.
+	if isSupplyTrackingActivated(accessibleState) {
+		if remainingGas, err = contract.DeductGas(remainingGas, SupplyTrackingGasCost); err != nil {
+			return nil, 0, err
+		}
+		addTotalMinted(stateDB, amount)
+	}
 
 	// if there is no address in the state, create one.
 	if !stateDB.Exist(to) {
@@ -99,14 +181,443 @@ func mintNativeCoin(accessibleState contract.AccessibleState, caller common.Addr
 	return []byte{}, remainingGas, nil
 }
 
+// PackBurnInput packs [amount] into the appropriate arguments for burning operation.
+func PackBurnInput(amount *big.Int) ([]byte, error) {
+	res := make([]byte, contract.SelectorLen+burnInputLen)
+	err := contract.PackOrderedHashesWithSelector(res, burnSignature, []common.Hash{
+		common.BigToHash(amount),
+	})
+	return res, err
+}
+
+// UnpackBurnInput attempts to unpack [input] into the amount argument to the burn function.
+// assumes that [input] does not include selector (omits first 4 bytes in PackBurnInput)
+func UnpackBurnInput(input []byte) (*big.Int, error) {
+	if len(input) != burnInputLen {
+		return nil, fmt.Errorf("invalid input length for burning: %d", len(input))
+	}
+	return new(big.Int).SetBytes(input), nil
+}
+
+// burnNativeCoin checks if the caller is permissioned for burning operation.
+// The execution function parses the [input] into the native coin amount to burn from the caller's balance.
+func burnNativeCoin(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, BurnGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	amount, err := UnpackBurnInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is in the allow list and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsEnabled() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotBurn, caller)
+	}
+
+	if balance := stateDB.GetBalance(caller); balance.Cmp(amount) < 0 {
+		return nil, remainingGas, fmt.Errorf("%w: address %s have %s want %s", ErrInsufficientBurnFunds, caller, balance, amount)
+	}
+
+	stateDB.SubBalance(caller, amount)
+	addTotalBurned(stateDB, amount)
+	// Return an empty output and the remaining gas
+	return []byte{}, remainingGas, nil
+}
+
+// GetTotalMinted returns the total amount minted through the precompile since supply tracking was activated.
+func GetTotalMinted(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalMintedKey).Big()
+}
+
+// GetTotalBurned returns the total amount burned through the precompile.
+func GetTotalBurned(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, totalBurnedKey).Big()
+}
+
+func addTotalMinted(stateDB contract.StateDB, amount *big.Int) {
+	stateDB.SetState(ContractAddress, totalMintedKey, common.BigToHash(new(big.Int).Add(GetTotalMinted(stateDB), amount)))
+}
+
+func addTotalBurned(stateDB contract.StateDB, amount *big.Int) {
+	stateDB.SetState(ContractAddress, totalBurnedKey, common.BigToHash(new(big.Int).Add(GetTotalBurned(stateDB), amount)))
+}
+
+// PackReadSupplyCountersInput packs the input data to the read supply counters function.
+func PackReadSupplyCountersInput() []byte {
+	return common.CopyBytes(readSupplySignature)
+}
+
+// PackReadSupplyCountersOutput packs the [totalMinted] and [totalBurned] amounts returned by readSupplyCounters.
+func PackReadSupplyCountersOutput(totalMinted *big.Int, totalBurned *big.Int) ([]byte, error) {
+	res := make([]byte, 2*common.HashLength)
+	err := contract.PackOrderedHashes(res, []common.Hash{
+		common.BigToHash(totalMinted),
+		common.BigToHash(totalBurned),
+	})
+	return res, err
+}
+
+// readSupplyCounters returns the total amounts minted and burned through the precompile.
+func readSupplyCounters(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ReadSupplyCountersGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if len(input) != 0 {
+		return nil, remainingGas, fmt.Errorf("invalid input length for reading supply counters: %d", len(input))
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	output, err := PackReadSupplyCountersOutput(GetTotalMinted(stateDB), GetTotalBurned(stateDB))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// minterKey returns the storage key for the per minter value identified by [prefix] for [minter].
+func minterKey(prefix []byte, minter common.Address) common.Hash {
+	key := minter.Hash()
+	copy(key[:], prefix)
+	return key
+}
+
+// GetMintWindow returns the length in seconds of the mint limit window, or 0 if mint limits are disabled.
+func GetMintWindow(stateDB contract.StateDB) uint64 {
+	return stateDB.GetState(ContractAddress, mintWindowKey).Big().Uint64()
+}
+
+// GetGlobalMintCap returns the maximum amount all minters combined may mint per window, or 0 if uncapped.
+func GetGlobalMintCap(stateDB contract.StateDB) *big.Int {
+	return stateDB.GetState(ContractAddress, globalMintCapKey).Big()
+}
+
+// GetMinterCap returns the maximum amount [minter] may mint per window, or 0 if uncapped.
+func GetMinterCap(stateDB contract.StateDB, minter common.Address) *big.Int {
+	return stateDB.GetState(ContractAddress, minterKey(minterCapKeyPrefix, minter)).Big()
+}
+
+// StoreMintLimits stores the mint [window] in seconds and the [globalCap] per window.
+func StoreMintLimits(stateDB contract.StateDB, window uint64, globalCap *big.Int) {
+	stateDB.SetState(ContractAddress, mintWindowKey, common.BigToHash(new(big.Int).SetUint64(window)))
+	stateDB.SetState(ContractAddress, globalMintCapKey, common.BigToHash(globalCap))
+}
+
+// StoreMinterCap stores the per window [minterCap] of [minter].
+func StoreMinterCap(stateDB contract.StateDB, minter common.Address, minterCap *big.Int) {
+	stateDB.SetState(ContractAddress, minterKey(minterCapKeyPrefix, minter), common.BigToHash(minterCap))
+}
+
+// windowBucketKey returns the storage key of the bucket in [slot] of the window tracked under [key].
+func windowBucketKey(key common.Hash, slot uint64) common.Hash {
+	key[3] = byte(slot)
+	return key
+}
+
+// windowBucket returns the index of the bucket of [window] containing [timestamp]. Buckets are
+// [window] / [mintWindowBuckets] seconds long (rounded up), so that the last [mintWindowBuckets]
+// buckets and the current one cover the whole rolling window ending at [timestamp].
+func windowBucket(window, timestamp uint64) uint64 {
+	return timestamp / ((window + mintWindowBuckets - 1) / mintWindowBuckets)
+}
+
+// getWindowMinted returns the amount minted in the rolling [window] ending at [timestamp], tracked in
+// buckets under [indexKey] and [mintedKey]. Mints are counted until the end of the bucket following
+// the window they were made in, so that no more than the cap is minted over any [window] seconds.
+func getWindowMinted(stateDB contract.StateDB, indexKey, mintedKey common.Hash, window, timestamp uint64) *big.Int {
+	var (
+		current = windowBucket(window, timestamp)
+		minted  = new(big.Int)
+	)
+	for slot := uint64(0); slot <= mintWindowBuckets; slot++ {
+		index := stateDB.GetState(ContractAddress, windowBucketKey(indexKey, slot)).Big().Uint64()
+		if index > current || index+mintWindowBuckets < current {
+			continue
+		}
+		minted.Add(minted, stateDB.GetState(ContractAddress, windowBucketKey(mintedKey, slot)).Big())
+	}
+	return minted
+}
+
+// addWindowMinted records [amount] minted at [timestamp] in the buckets under [indexKey] and [mintedKey].
+func addWindowMinted(stateDB contract.StateDB, indexKey, mintedKey common.Hash, window, timestamp uint64, amount *big.Int) {
+	var (
+		current = windowBucket(window, timestamp)
+		slot    = current % (mintWindowBuckets + 1)
+		minted  = new(big.Int).Set(amount)
+	)
+	indexKey, mintedKey = windowBucketKey(indexKey, slot), windowBucketKey(mintedKey, slot)
+	// Reuse the slot of an elapsed bucket, or add to the current bucket
+	if stateDB.GetState(ContractAddress, indexKey).Big().Uint64() == current {
+		minted.Add(minted, stateDB.GetState(ContractAddress, mintedKey).Big())
+	}
+	stateDB.SetState(ContractAddress, indexKey, common.BigToHash(new(big.Int).SetUint64(current)))
+	stateDB.SetState(ContractAddress, mintedKey, common.BigToHash(minted))
+}
+
+// GetGlobalWindowMinted returns the amount minted by all minters in the rolling window ending at [timestamp].
+func GetGlobalWindowMinted(stateDB contract.StateDB, timestamp uint64) *big.Int {
+	window := GetMintWindow(stateDB)
+	if window == 0 {
+		return new(big.Int)
+	}
+	return getWindowMinted(stateDB, globalWindowBucketKey, globalWindowMintedKey, window, timestamp)
+}
+
+// GetMinterWindowMinted returns the amount minted by [minter] in the rolling window ending at [timestamp].
+func GetMinterWindowMinted(stateDB contract.StateDB, minter common.Address, timestamp uint64) *big.Int {
+	window := GetMintWindow(stateDB)
+	if window == 0 {
+		return new(big.Int)
+	}
+	indexKey, mintedKey := minterKey(minterWindowBucketPrefix, minter), minterKey(minterWindowMintedPrefix, minter)
+	return getWindowMinted(stateDB, indexKey, mintedKey, window, timestamp)
+}
+
+// consumeMintAllowance verifies that minting [amount] by [minter] at [timestamp] stays within the global
+// and per minter caps for the rolling window and records the mint against both.
+func consumeMintAllowance(stateDB contract.StateDB, minter common.Address, amount *big.Int, window, timestamp uint64) error {
+	type windowUsage struct {
+		indexKey, mintedKey common.Hash
+		limit               *big.Int
+		err                 error
+	}
+	usages := []windowUsage{
+		{globalWindowBucketKey, globalWindowMintedKey, GetGlobalMintCap(stateDB), ErrGlobalMintCapExceeded},
+		{minterKey(minterWindowBucketPrefix, minter), minterKey(minterWindowMintedPrefix, minter), GetMinterCap(stateDB, minter), ErrMinterMintCapExceeded},
+	}
+
+	// Check every cap before writing anything so a rejected mint leaves no trace.
+	for _, usage := range usages {
+		if usage.limit.Sign() == 0 {
+			continue
+		}
+		minted := getWindowMinted(stateDB, usage.indexKey, usage.mintedKey, window, timestamp)
+		if new(big.Int).Add(minted, amount).Cmp(usage.limit) > 0 {
+			return fmt.Errorf("%w: minter: %s, minted: %s, amount: %s, cap: %s", usage.err, minter, minted, amount, usage.limit)
+		}
+	}
+	for _, usage := range usages {
+		if usage.limit.Sign() == 0 {
+			continue
+		}
+		addWindowMinted(stateDB, usage.indexKey, usage.mintedKey, window, timestamp, amount)
+	}
+	return nil
+}
+
+// PackSetMintLimitsInput packs [window] and [globalCap] into the appropriate arguments for setting the mint limits.
+func PackSetMintLimitsInput(window uint64, globalCap *big.Int) ([]byte, error) {
+	res := make([]byte, contract.SelectorLen+setMintLimitsInputLen)
+	err := contract.PackOrderedHashesWithSelector(res, setMintLimitsSignature, []common.Hash{
+		common.BigToHash(new(big.Int).SetUint64(window)),
+		common.BigToHash(globalCap),
+	})
+	return res, err
+}
+
+// UnpackSetMintLimitsInput attempts to unpack [input] into the window and global cap arguments.
+// assumes that [input] does not include selector (omits first 4 bytes in PackSetMintLimitsInput)
+func UnpackSetMintLimitsInput(input []byte) (uint64, *big.Int, error) {
+	if len(input) != setMintLimitsInputLen {
+		return 0, nil, fmt.Errorf("%w: invalid input length for setting mint limits: %d", ErrInvalidMintLimitsInput, len(input))
+	}
+	window := new(big.Int).SetBytes(contract.PackedHash(input, setMintLimitsInputWindowSlot))
+	if !window.IsUint64() {
+		return 0, nil, fmt.Errorf("%w: window %s does not fit in uint64", ErrInvalidMintLimitsInput, window)
+	}
+	globalCap := new(big.Int).SetBytes(contract.PackedHash(input, setMintLimitsInputGlobalCapSlot))
+	return window.Uint64(), globalCap, nil
+}
+
+// PackSetMinterCapInput packs [minter] and [minterCap] into the appropriate arguments for setting a minter cap.
+func PackSetMinterCapInput(minter common.Address, minterCap *big.Int) ([]byte, error) {
+	res := make([]byte, contract.SelectorLen+setMinterCapInputLen)
+	err := contract.PackOrderedHashesWithSelector(res, setMinterCapSignature, []common.Hash{
+		minter.Hash(),
+		common.BigToHash(minterCap),
+	})
+	return res, err
+}
+
+// UnpackSetMinterCapInput attempts to unpack [input] into the minter and minterCap arguments.
+// assumes that [input] does not include selector (omits first 4 bytes in PackSetMinterCapInput)
+func UnpackSetMinterCapInput(input []byte) (common.Address, *big.Int, error) {
+	if len(input) != setMinterCapInputLen {
+		return common.Address{}, nil, fmt.Errorf("%w: invalid input length for setting minter cap: %d", ErrInvalidMintLimitsInput, len(input))
+	}
+	// setMinterCap shares the (address, amount) layout of mintNativeCoin
+	minter := common.BytesToAddress(contract.PackedHash(input, mintInputAddressSlot))
+	minterCap := new(big.Int).SetBytes(contract.PackedHash(input, mintInputAmountSlot))
+	return minter, minterCap, nil
+}
+
+// PackReadMintLimitsInput packs the input data to the read mint limits function.
+func PackReadMintLimitsInput() []byte {
+	return common.CopyBytes(readMintLimitsSignature)
+}
+
+// PackReadMintLimitsOutput packs the [window], [globalCap] and [globalMinted] returned by readMintLimits.
+func PackReadMintLimitsOutput(window uint64, globalCap *big.Int, globalMinted *big.Int) ([]byte, error) {
+	res := make([]byte, 3*common.HashLength)
+	err := contract.PackOrderedHashes(res, []common.Hash{
+		common.BigToHash(new(big.Int).SetUint64(window)),
+		common.BigToHash(globalCap),
+		common.BigToHash(globalMinted),
+	})
+	return res, err
+}
+
+// PackReadMinterCapInput packs [minter] into the input data to the read minter cap function.
+func PackReadMinterCapInput(minter common.Address) []byte {
+	input := make([]byte, 0, contract.SelectorLen+readMinterCapInputLen)
+	input = append(input, readMinterCapSignature...)
+	input = append(input, minter.Hash().Bytes()...)
+	return input
+}
+
+// PackReadMinterCapOutput packs the [minterCap] and [minted] amount returned by readMinterCap.
+func PackReadMinterCapOutput(minterCap *big.Int, minted *big.Int) ([]byte, error) {
+	res := make([]byte, 2*common.HashLength)
+	err := contract.PackOrderedHashes(res, []common.Hash{
+		common.BigToHash(minterCap),
+		common.BigToHash(minted),
+	})
+	return res, err
+}
+
+// setMintLimits sets the mint window and the global cap per window. Only admins can call this function.
+func setMintLimits(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetMintLimitsGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	window, globalCap, err := UnpackSetMintLimitsInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetMintLimits, caller)
+	}
+	if window == 0 && globalCap.Sign() != 0 {
+		return nil, remainingGas, ErrMintCapWithoutWindow
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, setMintLimitsSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	StoreMintLimits(stateDB, window, globalCap)
+	return []byte{}, remainingGas, nil
+}
+
+// setMinterCap sets the per window cap of a minter. Only admins can call this function.
+func setMinterCap(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetMinterCapGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+
+	minter, minterCap, err := UnpackSetMinterCapInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetMintLimits, caller)
+	}
+	if minterCap.Sign() != 0 && GetMintWindow(stateDB) == 0 {
+		return nil, remainingGas, ErrMintCapWithoutWindow
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, setMinterCapSignature, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	StoreMinterCap(stateDB, minter, minterCap)
+	return []byte{}, remainingGas, nil
+}
+
+// readMintLimits returns the mint window, the global cap per window and the amount minted in the rolling window.
+func readMintLimits(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ReadMintLimitsGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if len(input) != 0 {
+		return nil, remainingGas, fmt.Errorf("%w: invalid input length for reading mint limits: %d", ErrInvalidMintLimitsInput, len(input))
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	output, err := PackReadMintLimitsOutput(GetMintWindow(stateDB), GetGlobalMintCap(stateDB), GetGlobalWindowMinted(stateDB, timestamp))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
+// readMinterCap returns the per window cap of a minter and the amount it minted in the rolling window.
+func readMinterCap(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ReadMinterCapGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	if len(input) != readMinterCapInputLen {
+		return nil, remainingGas, fmt.Errorf("%w: invalid input length for reading minter cap: %d", ErrInvalidMintLimitsInput, len(input))
+	}
+
+	minter := common.BytesToAddress(input)
+	stateDB := accessibleState.GetStateDB()
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	output, err := PackReadMinterCapOutput(GetMinterCap(stateDB, minter), GetMinterWindowMinted(stateDB, minter, timestamp))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return output, remainingGas, nil
+}
+
 // createNativeMinterPrecompile returns a StatefulPrecompiledContract for native coin minting. The precompile
 // is accessed controlled by an allow list at [precompileAddr].
 func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 	enabledFuncs := allowlist.CreateAllowListFunctions(ContractAddress)
 
 	mintFunc := contract.NewStatefulPrecompileFunction(mintSignature, mintNativeCoin)
+	setMintLimitsFunc := contract.NewStatefulPrecompileFunctionWithActivator(setMintLimitsSignature, setMintLimits, isMintLimitsActivated)
+	setMinterCapFunc := contract.NewStatefulPrecompileFunctionWithActivator(setMinterCapSignature, setMinterCap, isMintLimitsActivated)
+	readMintLimitsFunc := contract.NewStatefulPrecompileFunctionWithActivator(readMintLimitsSignature, readMintLimits, isMintLimitsActivated)
+	readMinterCapFunc := contract.NewStatefulPrecompileFunctionWithActivator(readMinterCapSignature, readMinterCap, isMintLimitsActivated)
+	burnFunc := contract.NewStatefulPrecompileFunctionWithActivator(burnSignature, burnNativeCoin, isSupplyTrackingActivated)
+	readSupplyFunc := contract.NewStatefulPrecompileFunctionWithActivator(readSupplySignature, readSupplyCounters, isSupplyTrackingActivated)
 
-	enabledFuncs = append(enabledFuncs, mintFunc)
+	enabledFuncs = append(enabledFuncs, mintFunc, setMintLimitsFunc, setMinterCapFunc, readMintLimitsFunc, readMinterCapFunc, burnFunc, readSupplyFunc)
 	// Construct the contract with no fallback function.
 	contract, err := contract.NewStatefulPrecompileContract(nil, enabledFuncs)
 	// TODO: Change this to be returned as an error after refactoring this precompile
@@ -116,3 +627,13 @@ func createNativeMinterPrecompile() contract.StatefulPrecompiledContract {
 	}
 	return contract
 }
+
+// isMintLimitsActivated returns true if the mint limit functions are available (after DUpgrade).
+func isMintLimitsActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
+
+// isSupplyTrackingActivated returns true if the burn function and the supply counters are available (after DUpgrade).
+func isSupplyTrackingActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
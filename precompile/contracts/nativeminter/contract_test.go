@@ -4,6 +4,7 @@
 package nativeminter
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/subnet-evm/core/state"
@@ -39,7 +40,7 @@ var tests = map[string]testutils.PrecompileTest{
 
 			return input
 		},
-		SuppliedGas: MintGasCost,
+		SuppliedGas: MintGasCost + SupplyTrackingGasCost,
 		ReadOnly:    false,
 		ExpectedRes: []byte{},
 		AfterHook: func(t testing.TB, state contract.StateDB) {
@@ -67,7 +68,7 @@ var tests = map[string]testutils.PrecompileTest{
 
 			return input
 		},
-		SuppliedGas: MintGasCost,
+		SuppliedGas: MintGasCost + SupplyTrackingGasCost,
 		ReadOnly:    false,
 		ExpectedRes: []byte{},
 		AfterHook: func(t testing.TB, state contract.StateDB) {
@@ -83,13 +84,51 @@ var tests = map[string]testutils.PrecompileTest{
 
 			return input
 		},
-		SuppliedGas: MintGasCost,
+		SuppliedGas: MintGasCost + SupplyTrackingGasCost,
 		ReadOnly:    false,
 		ExpectedRes: []byte{},
 		AfterHook: func(t testing.TB, state contract.StateDB) {
 			require.Equal(t, common.Big1, state.GetBalance(allowlist.TestAdminAddr), "expected minted funds")
 		},
 	},
+	"mint funds from admin address below admin threshold": {
+		Caller: allowlist.TestAdminAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			allowlist.SetAdminThreshold(state, Module.Address, 2)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestAdminAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Zero(t, state.GetBalance(allowlist.TestAdminAddr).Sign(), "expected no minted funds")
+		},
+	},
+	"mint funds from enabled address below admin threshold": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			allowlist.SetAdminThreshold(state, Module.Address, 2)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Zero(t, state.GetBalance(allowlist.TestEnabledAddr).Sign(), "expected no minted funds")
+		},
+	},
 	"mint max big funds": {
 		Caller:     allowlist.TestAdminAddr,
 		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -99,7 +138,7 @@ var tests = map[string]testutils.PrecompileTest{
 
 			return input
 		},
-		SuppliedGas: MintGasCost,
+		SuppliedGas: MintGasCost + SupplyTrackingGasCost,
 		ReadOnly:    false,
 		ExpectedRes: []byte{},
 		AfterHook: func(t testing.TB, state contract.StateDB) {
@@ -158,6 +197,431 @@ var tests = map[string]testutils.PrecompileTest{
 		ReadOnly:    false,
 		ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 	},
+	"set mint limits from admin": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintLimitsInput(testMintWindow, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMintLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, testMintWindow, GetMintWindow(state))
+			require.Equal(t, common.Big2, GetGlobalMintCap(state))
+		},
+	},
+	"set mint limits from enabled fails": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintLimitsInput(testMintWindow, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMintLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotSetMintLimits.Error(),
+	},
+	"readOnly set mint limits with admin role fails": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintLimitsInput(testMintWindow, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMintLimitsGasCost,
+		ReadOnly:    true,
+		ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+	},
+	"set minter cap from admin": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: setTestMintLimits(nil, nil),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMinterCapInput(allowlist.TestEnabledAddr, common.Big3)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMinterCapGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big3, GetMinterCap(state, allowlist.TestEnabledAddr))
+		},
+	},
+	"set minter cap without mint window fails": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMinterCapInput(allowlist.TestEnabledAddr, common.Big3)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMinterCapGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrMintCapWithoutWindow.Error(),
+	},
+	"set global cap without mint window fails": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMintLimitsInput(0, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMintLimitsGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrMintCapWithoutWindow.Error(),
+	},
+	"set minter cap from manager fails": {
+		Caller:     allowlist.TestManagerAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackSetMinterCapInput(allowlist.TestEnabledAddr, common.Big3)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: SetMinterCapGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotSetMintLimits.Error(),
+	},
+	"mint within global cap": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setTestMintLimits(common.Big2, nil),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost + SupplyTrackingGasCost,
+		ReadOnly:          false,
+		ExpectedRes:       []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big2, state.GetBalance(allowlist.TestEnabledAddr), "expected minted funds")
+			require.Equal(t, common.Big2, GetGlobalWindowMinted(state, testMintWindow))
+		},
+	},
+	"mint over global cap fails": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setTestMintLimits(common.Big2, nil),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big3)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost,
+		ReadOnly:          false,
+		ExpectedErr:       ErrGlobalMintCapExceeded.Error(),
+	},
+	"mint over minter cap fails": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setTestMintLimits(nil, common.Big1),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost,
+		ReadOnly:          false,
+		ExpectedErr:       ErrMinterMintCapExceeded.Error(),
+	},
+	"mint over cap in same window fails": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setTestMintLimits(common.Big2, nil)(t, state)
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big2, testMintWindow, testMintWindow))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(2*testMintWindow - 1),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost,
+		ReadOnly:          false,
+		ExpectedErr:       ErrGlobalMintCapExceeded.Error(),
+	},
+	"mint over cap in rolling window fails": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setTestMintLimits(common.Big2, nil)(t, state)
+			// Mint the cap at the end of a window, then try to mint again right after
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big2, testMintWindow, 2*testMintWindow-1))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(2*testMintWindow + 1),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost,
+		ReadOnly:          false,
+		ExpectedErr:       ErrGlobalMintCapExceeded.Error(),
+	},
+	"mint after rolling window elapsed": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setTestMintLimits(common.Big2, nil)(t, state)
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big1, testMintWindow, testMintWindow))
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big1, testMintWindow, 2*testMintWindow-1))
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(2*testMintWindow + testMintWindow/mintWindowBuckets),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost + SupplyTrackingGasCost,
+		ReadOnly:          false,
+		ExpectedRes:       []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big1, state.GetBalance(allowlist.TestEnabledAddr), "expected minted funds")
+			require.Equal(t, common.Big2, GetGlobalWindowMinted(state, 2*testMintWindow+testMintWindow/mintWindowBuckets))
+		},
+	},
+	"insufficient gas mint with mint limits": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: setTestMintLimits(common.Big2, nil),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestEnabledAddr, common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       MintGasCost + MintLimitsGasCost - 1,
+		ReadOnly:          false,
+		ExpectedErr:       vmerrs.ErrOutOfGas.Error(),
+	},
+	"read mint limits": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setTestMintLimits(common.Big2, nil)(t, state)
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big1, testMintWindow, testMintWindow))
+		},
+		InputFn: func(t testing.TB) []byte {
+			return PackReadMintLimitsInput()
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       ReadMintLimitsGasCost,
+		ReadOnly:          true,
+		ExpectedRes: func() []byte {
+			res, err := PackReadMintLimitsOutput(testMintWindow, common.Big2, common.Big1)
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	"read minter cap": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			setTestMintLimits(nil, common.Big3)(t, state)
+			require.NoError(t, consumeMintAllowance(state, allowlist.TestEnabledAddr, common.Big1, testMintWindow, testMintWindow))
+		},
+		InputFn: func(t testing.TB) []byte {
+			return PackReadMinterCapInput(allowlist.TestEnabledAddr)
+		},
+		SetupBlockContext: setTestMintTimestamp(testMintWindow),
+		SuppliedGas:       ReadMinterCapGasCost,
+		ReadOnly:          true,
+		ExpectedRes: func() []byte {
+			res, err := PackReadMinterCapOutput(common.Big3, common.Big1)
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	"mint limits from config": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			MintLimits: &MintLimits{
+				Window:    testMintWindow,
+				GlobalCap: math.NewHexOrDecimal256(2),
+				MinterCaps: map[common.Address]*math.HexOrDecimal256{
+					allowlist.TestEnabledAddr: math.NewHexOrDecimal256(1),
+				},
+			},
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, testMintWindow, GetMintWindow(state))
+			require.Equal(t, common.Big2, GetGlobalMintCap(state))
+			require.Equal(t, common.Big1, GetMinterCap(state, allowlist.TestEnabledAddr))
+		},
+	},
+	"mint funds tracks total minted": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackMintInput(allowlist.TestNoRoleAddr, common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: MintGasCost + SupplyTrackingGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big2, GetTotalMinted(state))
+		},
+	},
+	"burn funds from enabled address": {
+		Caller: allowlist.TestEnabledAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			state.AddBalance(allowlist.TestEnabledAddr, common.Big3)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnInput(common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    false,
+		ExpectedRes: []byte{},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, common.Big1, state.GetBalance(allowlist.TestEnabledAddr), "expected burned funds")
+			require.Equal(t, common.Big2, GetTotalBurned(state))
+		},
+	},
+	"burn funds from no role fails": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			state.AddBalance(allowlist.TestNoRoleAddr, common.Big3)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnInput(common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrCannotBurn.Error(),
+	},
+	"burn more than balance fails": {
+		Caller: allowlist.TestAdminAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			state.AddBalance(allowlist.TestAdminAddr, common.Big1)
+		},
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnInput(common.Big2)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    false,
+		ExpectedErr: ErrInsufficientBurnFunds.Error(),
+	},
+	"readOnly burn with admin role fails": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnInput(common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: BurnGasCost,
+		ReadOnly:    true,
+		ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+	},
+	"insufficient gas burn from admin": {
+		Caller:     allowlist.TestAdminAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		InputFn: func(t testing.TB) []byte {
+			input, err := PackBurnInput(common.Big1)
+			require.NoError(t, err)
+
+			return input
+		},
+		SuppliedGas: BurnGasCost - 1,
+		ReadOnly:    false,
+		ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+	},
+	"read supply counters": {
+		Caller: allowlist.TestNoRoleAddr,
+		BeforeHook: func(t testing.TB, state contract.StateDB) {
+			allowlist.SetDefaultRoles(Module.Address)(t, state)
+			addTotalMinted(state, common.Big3)
+			addTotalBurned(state, common.Big1)
+		},
+		InputFn: func(t testing.TB) []byte {
+			return PackReadSupplyCountersInput()
+		},
+		SuppliedGas: ReadSupplyCountersGasCost,
+		ReadOnly:    true,
+		ExpectedRes: func() []byte {
+			res, err := PackReadSupplyCountersOutput(common.Big3, common.Big1)
+			if err != nil {
+				panic(err)
+			}
+			return res
+		}(),
+	},
+	"initial mint tracks total minted": {
+		Caller:     allowlist.TestEnabledAddr,
+		BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+		Config: &Config{
+			InitialMint: map[common.Address]*math.HexOrDecimal256{
+				allowlist.TestEnabledAddr: math.NewHexOrDecimal256(2),
+				allowlist.TestNoRoleAddr:  math.NewHexOrDecimal256(3),
+			},
+		},
+		AfterHook: func(t testing.TB, state contract.StateDB) {
+			require.Equal(t, big.NewInt(5), GetTotalMinted(state))
+		},
+	},
+}
+
+const testMintWindow = uint64(100)
+
+// setTestMintLimits returns a BeforeHook that sets the default roles and configures
+// mint limits of [testMintWindow] with [globalCap] and a cap of [minterCap] for the enabled address.
+func setTestMintLimits(globalCap *big.Int, minterCap *big.Int) func(t testing.TB, state contract.StateDB) {
+	return func(t testing.TB, state contract.StateDB) {
+		allowlist.SetDefaultRoles(Module.Address)(t, state)
+		if globalCap == nil {
+			globalCap = new(big.Int)
+		}
+		StoreMintLimits(state, testMintWindow, globalCap)
+		if minterCap != nil {
+			StoreMinterCap(state, allowlist.TestEnabledAddr, minterCap)
+		}
+	}
+}
+
+// setTestMintTimestamp returns a SetupBlockContext that executes at [timestamp].
+func setTestMintTimestamp(timestamp uint64) func(*contract.MockBlockContext) {
+	return func(mbc *contract.MockBlockContext) {
+		mbc.EXPECT().Number().Return(big.NewInt(0)).AnyTimes()
+		mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+	}
 }
 
 func TestContractNativeMinterRun(t *testing.T) {
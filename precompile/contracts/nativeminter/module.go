@@ -46,12 +46,20 @@ func (*configurator) Configure(chainConfig precompileconfig.ChainConfig, cfg pre
 	if !ok {
 		return fmt.Errorf("expected config type %T, got %T: %v", &Config{}, cfg, cfg)
 	}
+	// Initial mints only count towards the supply counters once supply tracking is activated.
+	trackSupply := chainConfig.IsDUpgrade(blockContext.Timestamp())
 	for to, amount := range config.InitialMint {
 		if amount != nil {
 			bigIntAmount := (*big.Int)(amount)
 			state.AddBalance(to, bigIntAmount)
+			if trackSupply {
+				addTotalMinted(state, bigIntAmount)
+			}
 		}
 	}
+	if config.MintLimits != nil {
+		config.MintLimits.Configure(state)
+	}
 
 	return config.AllowListConfig.Configure(chainConfig, ContractAddress, state, blockContext)
 }
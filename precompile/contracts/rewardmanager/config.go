@@ -7,6 +7,7 @@
 package rewardmanager
 
 import (
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
@@ -18,6 +19,11 @@ var _ precompileconfig.Config = &Config{}
 type InitialRewardConfig struct {
 	AllowFeeRecipients bool           `json:"allowFeeRecipients"`
 	RewardAddress      common.Address `json:"rewardAddress,omitempty"`
+	// FeeSplit splits the fees of each block across its recipients, paying the rest to the coinbase.
+	// The shares are paid from the coinbase balance at the end of the block, in the order of the
+	// recipients. If the coinbase spent some of the fees within the block, the share of each
+	// recipient is capped at the remaining balance, so later recipients may be underpaid.
+	FeeSplit []commontype.FeeSplitRecipient `json:"feeSplit,omitempty"`
 }
 
 func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
@@ -25,6 +31,15 @@ func (i *InitialRewardConfig) Equal(other *InitialRewardConfig) bool {
 		return false
 	}
 
+	if len(i.FeeSplit) != len(other.FeeSplit) {
+		return false
+	}
+	for j, recipient := range i.FeeSplit {
+		if recipient != other.FeeSplit[j] {
+			return false
+		}
+	}
+
 	return i.AllowFeeRecipients == other.AllowFeeRecipients && i.RewardAddress == other.RewardAddress
 }
 
@@ -32,12 +47,20 @@ func (i *InitialRewardConfig) Verify() error {
 	switch {
 	case i.AllowFeeRecipients && i.RewardAddress != (common.Address{}):
 		return ErrCannotEnableBothRewards
+	case len(i.FeeSplit) > 0 && (i.AllowFeeRecipients || i.RewardAddress != (common.Address{})):
+		return ErrCannotCombineFeeSplit
+	case len(i.FeeSplit) > 0:
+		return commontype.VerifyFeeSplit(i.FeeSplit)
 	default:
 		return nil
 	}
 }
 
 func (i *InitialRewardConfig) Configure(state contract.StateDB) error {
+	// split fees across the recipients
+	if len(i.FeeSplit) > 0 {
+		return StoreFeeSplit(state, i.FeeSplit)
+	}
 	// enable allow fee recipients
 	if i.AllowFeeRecipients {
 		EnableAllowFeeRecipients(state)
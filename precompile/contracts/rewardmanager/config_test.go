@@ -6,6 +6,7 @@ package rewardmanager
 import (
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
@@ -26,6 +27,25 @@ func TestVerify(t *testing.T) {
 			}),
 			ExpectedError: ErrCannotEnableBothRewards.Error(),
 		},
+		"fee split should not be combined with a reward address": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				RewardAddress: common.HexToAddress("0x01"),
+				FeeSplit:      []commontype.FeeSplitRecipient{{Address: common.HexToAddress("0x02"), BasisPoints: 100}},
+			}),
+			ExpectedError: ErrCannotCombineFeeSplit.Error(),
+		},
+		"invalid fee split": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				FeeSplit: []commontype.FeeSplitRecipient{{Address: common.HexToAddress("0x02"), BasisPoints: 0}},
+			}),
+			ExpectedError: commontype.ErrZeroFeeSplitShare.Error(),
+		},
+		"valid fee split": {
+			Config: NewConfig(utils.NewUint64(3), admins, enableds, managers, &InitialRewardConfig{
+				FeeSplit: []commontype.FeeSplitRecipient{{Address: common.HexToAddress("0x02"), BasisPoints: 100}},
+			}),
+			ExpectedError: "",
+		},
 	}
 	allowlist.VerifyPrecompileWithAllowListTests(t, Module, tests)
 }
@@ -67,6 +87,15 @@ func TestEqual(t *testing.T) {
 				}),
 			Expected: false,
 		},
+		"different fee split": {
+			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				FeeSplit: []commontype.FeeSplitRecipient{{Address: common.HexToAddress("0x01"), BasisPoints: 100}},
+			}),
+			Other: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
+				FeeSplit: []commontype.FeeSplitRecipient{{Address: common.HexToAddress("0x01"), BasisPoints: 200}},
+			}),
+			Expected: false,
+		},
 		"same config": {
 			Config: NewConfig(utils.NewUint64(3), admins, nil, nil, &InitialRewardConfig{
 				RewardAddress: common.HexToAddress("0x01"),
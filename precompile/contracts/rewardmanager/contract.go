@@ -8,10 +8,13 @@ package rewardmanager
 
 import (
 	_ "embed"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
@@ -26,6 +29,11 @@ const (
 	CurrentRewardAddressGasCost    uint64 = allowlist.ReadAllowListGasCost
 	DisableRewardsGasCost          uint64 = (contract.WriteGasCostPerSlot) + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
 	SetRewardAddressGasCost        uint64 = (contract.WriteGasCostPerSlot) + allowlist.ReadAllowListGasCost // write 1 slot + read allow list
+
+	SetFeeSplitGasCost                 uint64 = (2 * contract.WriteGasCostPerSlot) + allowlist.ReadAllowListGasCost // write mode and count slots + read allow list
+	SetFeeSplitPerRecipientGasCost     uint64 = contract.WriteGasCostPerSlot                                        // write 1 slot per recipient
+	CurrentFeeSplitGasCost             uint64 = 2 * contract.ReadGasCostPerSlot                                     // read mode and count slots
+	CurrentFeeSplitPerRecipientGasCost uint64 = contract.ReadGasCostPerSlot                                         // read 1 slot per recipient
 )
 
 // Singleton StatefulPrecompiledContract and signatures.
@@ -35,9 +43,12 @@ var (
 	ErrCannotCurrentRewardAddress    = errors.New("non-enabled cannot call currentRewardAddress")
 	ErrCannotDisableRewards          = errors.New("non-enabled cannot call disableRewards")
 	ErrCannotSetRewardAddress        = errors.New("non-enabled cannot call setRewardAddress")
+	ErrCannotSetFeeSplit             = errors.New("non-admin cannot call setFeeSplit")
 
 	ErrCannotEnableBothRewards = errors.New("cannot enable both fee recipients and reward address at the same time")
 	ErrEmptyRewardAddress      = errors.New("reward address cannot be empty")
+	ErrCannotCombineFeeSplit   = errors.New("cannot combine fee split with fee recipients or a reward address")
+	ErrFeeSplitLengthMismatch  = errors.New("fee split recipients and basis points must have the same length")
 
 	// RewardManagerRawABI contains the raw ABI of RewardManager contract.
 	//go:embed contract.abi
@@ -48,11 +59,17 @@ var (
 
 	rewardAddressStorageKey        = common.Hash{'r', 'a', 's', 'k'}
 	allowFeeRecipientsAddressValue = common.Hash{'a', 'f', 'r', 'a', 'v'}
+	feeSplitAddressValue           = common.Hash{'f', 's', 'a', 'v'}
+
+	// The fee split is stored as its number of recipients followed by one slot per recipient
+	// holding its share in basis points next to its address.
+	feeSplitCountStorageKey           = common.Hash{'f', 's', 'c'}
+	feeSplitRecipientStorageKeyPrefix = []byte{'f', 's', 'r'}
 )
 
-// GetRewardManagerAllowListStatus returns the role of [address] for the RewardManager list.
-func GetRewardManagerAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetRewardManagerAllowListStatus returns the role of [address] as of [timestamp] for the RewardManager list.
+func GetRewardManagerAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // SetRewardManagerAllowListStatus sets the permissions of [address] to [role] for the
@@ -91,11 +108,18 @@ func allowFeeRecipients(accessibleState contract.AccessibleState, caller common.
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotAllowFeeRecipients, caller)
 	}
 	// allow list code ends here.
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, RewardManagerABI.Methods["allowFeeRecipients"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
 
 	// this function does not return an output, leave this one as is
 	EnableAllowFeeRecipients(stateDB)
@@ -150,9 +174,52 @@ func PackCurrentRewardAddressOutput(rewardAddress common.Address) ([]byte, error
 
 // GetStoredRewardAddress returns the current value of the address stored under rewardAddressStorageKey.
 // Returns an empty address and true if allow fee recipients is enabled, otherwise returns current reward address and false.
+// Fee recipients are allowed when fees are split, since the coinbase of a block receives the share of the fees
+// not assigned to any recipient of the split.
 func GetStoredRewardAddress(stateDB contract.StateDB) (common.Address, bool) {
 	val := stateDB.GetState(ContractAddress, rewardAddressStorageKey)
-	return common.BytesToAddress(val.Bytes()), val == allowFeeRecipientsAddressValue
+	return common.BytesToAddress(val.Bytes()), val == allowFeeRecipientsAddressValue || val == feeSplitAddressValue
+}
+
+// feeSplitRecipientStorageKey returns the storage key of the [i]th recipient of the fee split.
+func feeSplitRecipientStorageKey(i int) common.Hash {
+	key := common.Hash{}
+	copy(key[:], feeSplitRecipientStorageKeyPrefix)
+	key[common.HashLength-1] = byte(i)
+	return key
+}
+
+// GetStoredFeeSplit returns the stored fee split, or nil if fees are not split.
+func GetStoredFeeSplit(stateDB contract.StateDB) []commontype.FeeSplitRecipient {
+	if stateDB.GetState(ContractAddress, rewardAddressStorageKey) != feeSplitAddressValue {
+		return nil
+	}
+	count := stateDB.GetState(ContractAddress, feeSplitCountStorageKey).Big().Uint64()
+	recipients := make([]commontype.FeeSplitRecipient, 0, count)
+	for i := 0; i < int(count); i++ {
+		val := stateDB.GetState(ContractAddress, feeSplitRecipientStorageKey(i))
+		recipients = append(recipients, commontype.FeeSplitRecipient{
+			Address:     common.BytesToAddress(val[common.HashLength-common.AddressLength:]),
+			BasisPoints: binary.BigEndian.Uint16(val[common.HashLength-common.AddressLength-2:]),
+		})
+	}
+	return recipients
+}
+
+// StoreFeeSplit verifies and stores [recipients] and starts splitting fees across them.
+func StoreFeeSplit(stateDB contract.StateDB, recipients []commontype.FeeSplitRecipient) error {
+	if err := commontype.VerifyFeeSplit(recipients); err != nil {
+		return err
+	}
+	stateDB.SetState(ContractAddress, feeSplitCountStorageKey, common.BigToHash(big.NewInt(int64(len(recipients)))))
+	for i, recipient := range recipients {
+		val := common.Hash{}
+		copy(val[common.HashLength-common.AddressLength:], recipient.Address.Bytes())
+		binary.BigEndian.PutUint16(val[common.HashLength-common.AddressLength-2:], recipient.BasisPoints)
+		stateDB.SetState(ContractAddress, feeSplitRecipientStorageKey(i), val)
+	}
+	stateDB.SetState(ContractAddress, rewardAddressStorageKey, feeSplitAddressValue)
+	return nil
 }
 
 // StoredRewardAddress stores the given [val] under rewardAddressStorageKey.
@@ -203,11 +270,18 @@ func setRewardAddress(accessibleState contract.AccessibleState, caller common.Ad
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetRewardAddress, caller)
 	}
 	// allow list code ends here.
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, RewardManagerABI.Methods["setRewardAddress"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
 
 	if err := StoreRewardAddress(stateDB, inputStruct); err != nil {
 		return nil, remainingGas, err
@@ -256,11 +330,18 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	// You can modify/delete this code if you don't want this function to be restricted by the allow list.
 	stateDB := accessibleState.GetStateDB()
 	// Verify that the caller is in the allow list and therefore has the right to call this function.
-	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller)
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
 	if !callerStatus.IsEnabled() {
 		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotDisableRewards, caller)
 	}
 	// allow list code ends here.
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, RewardManagerABI.Methods["disableRewards"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
 	DisableFeeRewards(stateDB)
 	// this function does not return an output, leave this one as is
 	packedOutput := []byte{}
@@ -269,6 +350,118 @@ func disableRewards(accessibleState contract.AccessibleState, caller common.Addr
 	return packedOutput, remainingGas, nil
 }
 
+// PackSetFeeSplit packs [recipients] into the appropriate arguments for setFeeSplit.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackSetFeeSplit(recipients []commontype.FeeSplitRecipient) ([]byte, error) {
+	addresses, basisPoints := splitFeeSplitRecipients(recipients)
+	return RewardManagerABI.Pack("setFeeSplit", addresses, basisPoints)
+}
+
+// UnpackSetFeeSplitInput attempts to unpack [input] into the fee split recipients.
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackSetFeeSplitInput(input []byte) ([]commontype.FeeSplitRecipient, error) {
+	res, err := RewardManagerABI.UnpackInput("setFeeSplit", input)
+	if err != nil {
+		return nil, err
+	}
+	addresses := *abi.ConvertType(res[0], new([]common.Address)).(*[]common.Address)
+	basisPoints := *abi.ConvertType(res[1], new([]uint16)).(*[]uint16)
+	if len(addresses) != len(basisPoints) {
+		return nil, fmt.Errorf("%w: %d != %d", ErrFeeSplitLengthMismatch, len(addresses), len(basisPoints))
+	}
+	recipients := make([]commontype.FeeSplitRecipient, len(addresses))
+	for i := range addresses {
+		recipients[i] = commontype.FeeSplitRecipient{Address: addresses[i], BasisPoints: basisPoints[i]}
+	}
+	return recipients, nil
+}
+
+// PackCurrentFeeSplit packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackCurrentFeeSplit() ([]byte, error) {
+	return RewardManagerABI.Pack("currentFeeSplit")
+}
+
+// PackCurrentFeeSplitOutput attempts to pack given [recipients] to conform the ABI outputs.
+func PackCurrentFeeSplitOutput(recipients []commontype.FeeSplitRecipient) ([]byte, error) {
+	addresses, basisPoints := splitFeeSplitRecipients(recipients)
+	return RewardManagerABI.PackOutput("currentFeeSplit", addresses, basisPoints)
+}
+
+// splitFeeSplitRecipients returns the addresses and the shares of [recipients] as separate slices.
+func splitFeeSplitRecipients(recipients []commontype.FeeSplitRecipient) ([]common.Address, []uint16) {
+	addresses := make([]common.Address, len(recipients))
+	basisPoints := make([]uint16, len(recipients))
+	for i, recipient := range recipients {
+		addresses[i] = recipient.Address
+		basisPoints[i] = recipient.BasisPoints
+	}
+	return addresses, basisPoints
+}
+
+func setFeeSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, SetFeeSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	recipients, err := UnpackSetFeeSplitInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	// Verify before charging per recipient so an oversized input cannot overflow the gas cost.
+	if err := commontype.VerifyFeeSplit(recipients); err != nil {
+		return nil, remainingGas, err
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, uint64(len(recipients))*SetFeeSplitPerRecipientGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	stateDB := accessibleState.GetStateDB()
+	// Verify that the caller is an admin and therefore has the right to call this function.
+	callerStatus := allowlist.GetAllowListStatus(stateDB, ContractAddress, caller, accessibleState.GetBlockContext().Timestamp())
+	if !callerStatus.IsAdmin() {
+		return nil, remainingGas, fmt.Errorf("%w: %s", ErrCannotSetFeeSplit, caller)
+	}
+	execute, remainingGas, err := allowlist.ApproveAdminAction(accessibleState, ContractAddress, caller, callerStatus, RewardManagerABI.Methods["setFeeSplit"].ID, input, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if !execute {
+		return []byte{}, remainingGas, nil
+	}
+
+	if err := StoreFeeSplit(stateDB, recipients); err != nil {
+		return nil, remainingGas, err
+	}
+	// this function does not return an output, leave this one as is
+	packedOutput := []byte{}
+
+	// Return the packed output and the remaining gas
+	return packedOutput, remainingGas, nil
+}
+
+func currentFeeSplit(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, CurrentFeeSplitGasCost); err != nil {
+		return nil, 0, err
+	}
+
+	// no input provided for this function
+	recipients := GetStoredFeeSplit(accessibleState.GetStateDB())
+	if remainingGas, err = contract.DeductGas(remainingGas, uint64(len(recipients))*CurrentFeeSplitPerRecipientGasCost); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackCurrentFeeSplitOutput(recipients)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	// Return the packed output and the remaining gas
+	return packedOutput, remainingGas, nil
+}
+
 // createRewardManagerPrecompile returns a StatefulPrecompiledContract with getters and setters for the precompile.
 // Access to the getters/setters is controlled by an allow list for [precompileAddr].
 func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
@@ -290,6 +483,19 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
 
+	// Fee split functions are only available after DUpgrade.
+	feeSplitFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"currentFeeSplit": currentFeeSplit,
+		"setFeeSplit":     setFeeSplit,
+	}
+	for name, function := range feeSplitFunctionMap {
+		method, ok := RewardManagerABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, isFeeSplitActivated))
+	}
+
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -297,3 +503,8 @@ func createRewardManagerPrecompile() contract.StatefulPrecompiledContract {
 	}
 	return statefulContract
 }
+
+// isFeeSplitActivated returns true if the fee split functions are available (after DUpgrade).
+func isFeeSplitActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
@@ -6,6 +6,7 @@ package rewardmanager
 import (
 	"testing"
 
+	"github.com/ava-labs/subnet-evm/commontype"
 	"github.com/ava-labs/subnet-evm/constants"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/precompile/allowlist"
@@ -17,8 +18,14 @@ import (
 )
 
 var (
-	testAddr = common.HexToAddress("0x0123")
-	tests    = map[string]testutils.PrecompileTest{
+	testAddr      = common.HexToAddress("0x0123")
+	testSplitAddr = common.HexToAddress("0x0456")
+	testFeeSplit  = []commontype.FeeSplitRecipient{
+		{Address: testAddr, BasisPoints: 2_500},
+		{Address: testSplitAddr, BasisPoints: 1_000},
+	}
+
+	tests = map[string]testutils.PrecompileTest{
 		"set allow fee recipients from no role fails": {
 			Caller:     allowlist.TestNoRoleAddr,
 			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -93,6 +100,46 @@ var (
 				require.False(t, isFeeRecipients)
 			},
 		},
+		"set reward address from admin below admin threshold": {
+			Caller: allowlist.TestAdminAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				allowlist.SetAdminThreshold(state, Module.Address, 2)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardAddress(testAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardAddressGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				address, _ := GetStoredRewardAddress(state)
+				require.NotEqual(t, testAddr, address)
+			},
+		},
+		"set reward address from enabled below admin threshold": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				allowlist.SetAdminThreshold(state, Module.Address, 2)
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetRewardAddress(testAddr)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetRewardAddressGasCost + allowlist.ApproveAdminActionGasCost + allowlist.ProposalApproverGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				address, _ := GetStoredRewardAddress(state)
+				require.NotEqual(t, testAddr, address)
+			},
+		},
 		"set allow fee recipients from manager succeeds": {
 			Caller:     allowlist.TestManagerAddr,
 			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
@@ -331,6 +378,139 @@ var (
 			ReadOnly:    false,
 			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
 		},
+		"set fee split from enabled fails": {
+			Caller:     allowlist.TestEnabledAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeSplit(testFeeSplit)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeSplitGasCost + 2*SetFeeSplitPerRecipientGasCost,
+			ReadOnly:    false,
+			ExpectedErr: ErrCannotSetFeeSplit.Error(),
+		},
+		"set fee split from admin succeeds": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeSplit(testFeeSplit)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeSplitGasCost + 2*SetFeeSplitPerRecipientGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Equal(t, testFeeSplit, GetStoredFeeSplit(state))
+				_, isFeeRecipients := GetStoredRewardAddress(state)
+				require.True(t, isFeeRecipients)
+			},
+		},
+		"set invalid fee split from admin fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeSplit([]commontype.FeeSplitRecipient{
+					{Address: testAddr, BasisPoints: 6_000},
+					{Address: testSplitAddr, BasisPoints: 5_000},
+				})
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeSplitGasCost,
+			ReadOnly:    false,
+			ExpectedErr: commontype.ErrFeeSplitSharesExceedTotal.Error(),
+		},
+		"readOnly set fee split with admin role fails": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeSplit(testFeeSplit)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeSplitGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"insufficient gas set fee split from admin role": {
+			Caller:     allowlist.TestAdminAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackSetFeeSplit(testFeeSplit)
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: SetFeeSplitGasCost + 2*SetFeeSplitPerRecipientGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"disable rewards clears fee split": {
+			Caller: allowlist.TestEnabledAddr,
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				allowlist.SetDefaultRoles(Module.Address)(t, state)
+				require.NoError(t, StoreFeeSplit(state, testFeeSplit))
+			},
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackDisableRewards()
+				require.NoError(t, err)
+
+				return input
+			},
+			SuppliedGas: DisableRewardsGasCost,
+			ReadOnly:    false,
+			ExpectedRes: []byte{},
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.Nil(t, GetStoredFeeSplit(state))
+			},
+		},
+		"get current fee split from no role succeeds": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentFeeSplit()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: CurrentFeeSplitGasCost + 2*CurrentFeeSplitPerRecipientGasCost,
+			Config: &Config{
+				InitialRewardConfig: &InitialRewardConfig{
+					FeeSplit: testFeeSplit,
+				},
+			},
+			ReadOnly: false,
+			ExpectedRes: func() []byte {
+				res, err := PackCurrentFeeSplitOutput(testFeeSplit)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get empty fee split from no role succeeds": {
+			Caller:     allowlist.TestNoRoleAddr,
+			BeforeHook: allowlist.SetDefaultRoles(Module.Address),
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackCurrentFeeSplit()
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: CurrentFeeSplitGasCost,
+			ReadOnly:    false,
+			ExpectedRes: func() []byte {
+				res, err := PackCurrentFeeSplitOutput(nil)
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
 	}
 )
 
@@ -12,9 +12,9 @@ import (
 // Singleton StatefulPrecompiledContract for W/R access to the tx allow list.
 var TxAllowListPrecompile contract.StatefulPrecompiledContract = allowlist.CreateAllowListPrecompile(ContractAddress)
 
-// GetTxAllowListStatus returns the role of [address] for the tx allow list.
-func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address) allowlist.Role {
-	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address)
+// GetTxAllowListStatus returns the role of [address] as of [timestamp] for the tx allow list.
+func GetTxAllowListStatus(stateDB contract.StateDB, address common.Address, timestamp uint64) allowlist.Role {
+	return allowlist.GetAllowListStatus(stateDB, ContractAddress, address, timestamp)
 }
 
 // SetTxAllowListStatus sets the permissions of [address] to [role] for the
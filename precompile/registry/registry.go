@@ -18,6 +18,12 @@ import (
 	_ "github.com/ava-labs/subnet-evm/precompile/contracts/rewardmanager"
 
 	_ "github.com/ava-labs/subnet-evm/x/warp"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/freezelist"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/gassponsor"
+
+	_ "github.com/ava-labs/subnet-evm/precompile/contracts/callallowlist"
 	// ADD YOUR PRECOMPILE HERE
 	// _ "github.com/ava-labs/subnet-evm/precompile/contracts/yourprecompile"
 )
@@ -40,5 +46,8 @@ import (
 // FeeManagerAddress                = common.HexToAddress("0x0200000000000000000000000000000000000003")
 // RewardManagerAddress             = common.HexToAddress("0x0200000000000000000000000000000000000004")
 // WarpAddress                      = common.HexToAddress("0x0200000000000000000000000000000000000005")
+// FreezeListAddress                = common.HexToAddress("0x0300000000000000000000000000000000000000")
+// GasSponsorAddress                = common.HexToAddress("0x0300000000000000000000000000000000000001")
+// ContractCallAllowListAddress     = common.HexToAddress("0x0300000000000000000000000000000000000002")
 // ADD YOUR PRECOMPILE HERE
 // {YourPrecompile}Address          = common.HexToAddress("0x03000000000000000000000000000000000000??")
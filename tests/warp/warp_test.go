@@ -303,14 +303,14 @@ var _ = ginkgo.Describe("[Warp]", ginkgo.Ordered, func() {
 
 		log.Info("Aggregating signatures from validator set", "numValidators", len(warpValidators), "totalWeight", totalWeight)
 		apiSignatureGetter := warpBackend.NewAPIFetcher(warpAPIs)
-		signatureResult, err := aggregator.New(apiSignatureGetter, warpValidators, totalWeight).AggregateSignatures(ctx, unsignedWarpMsg, 100)
+		signatureResult, err := aggregator.New(apiSignatureGetter, warpValidators, totalWeight, nil).AggregateSignatures(ctx, unsignedWarpMsg, 100)
 		gomega.Expect(err).Should(gomega.BeNil())
 		gomega.Expect(signatureResult.SignatureWeight).Should(gomega.Equal(signatureResult.TotalWeight))
 		gomega.Expect(signatureResult.SignatureWeight).Should(gomega.Equal(totalWeight))
 
 		signedWarpMsg = signatureResult.Message
 
-		signatureResult, err = aggregator.New(apiSignatureGetter, warpValidators, totalWeight).AggregateSignatures(ctx, warpBlockHashUnsignedMsg, 100)
+		signatureResult, err = aggregator.New(apiSignatureGetter, warpValidators, totalWeight, nil).AggregateSignatures(ctx, warpBlockHashUnsignedMsg, 100)
 		gomega.Expect(err).Should(gomega.BeNil())
 		gomega.Expect(signatureResult.SignatureWeight).Should(gomega.Equal(signatureResult.TotalWeight))
 		gomega.Expect(signatureResult.SignatureWeight).Should(gomega.Equal(totalWeight))
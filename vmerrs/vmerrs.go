@@ -49,4 +49,8 @@ var (
 	ErrAddrProhibited              = errors.New("prohibited address cannot be sender or created contract address")
 	ErrInvalidCoinbase             = errors.New("invalid coinbase")
 	ErrSenderAddressNotAllowListed = errors.New("cannot issue transaction from non-allow listed address")
+	ErrSenderAddressFrozen         = errors.New("cannot issue transaction from frozen address")
+	ErrFrozenValueTransfer         = errors.New("cannot transfer value from frozen address")
+	ErrContractCallNotAllowed      = errors.New("cannot call contract function not allowed by the contract call allow list")
+	ErrContractCreationNotAllowed  = errors.New("cannot deploy contract from address not enabled on the contract call allow list")
 )
@@ -37,31 +37,71 @@ type Aggregator struct {
 	validators  []*avalancheWarp.Validator
 	totalWeight uint64
 	client      SignatureGetter
+	store       SignatureStore
 }
 
 // New returns a signature aggregator for the chain with the given [state] on the
 // given [subnetID], and where [client] can be used to fetch signatures from validators.
-func New(client SignatureGetter, validators []*avalancheWarp.Validator, totalWeight uint64) *Aggregator {
+// If [store] is non-nil, signatures collected by earlier aggregations are reused and
+// newly fetched signatures are persisted to it.
+func New(client SignatureGetter, validators []*avalancheWarp.Validator, totalWeight uint64, store SignatureStore) *Aggregator {
 	return &Aggregator{
 		client:      client,
 		validators:  validators,
 		totalWeight: totalWeight,
+		store:       store,
 	}
 }
 
 // Returns an aggregate signature over [unsignedMessage].
 // The returned signature's weight exceeds the threshold given by [quorumNum].
 func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64) (*AggregateSignatureResult, error) {
+	var (
+		messageID                 = unsignedMessage.ID()
+		signatures                = make([]*bls.Signature, 0, len(a.validators))
+		signersBitset             = set.NewBits()
+		signaturesWeight          = uint64(0)
+		signaturesPassedThreshold = false
+		missingValidators         = make([]int, 0, len(a.validators))
+	)
+
+	// Start from the signatures collected by earlier aggregations of this message.
+	for i, validator := range a.validators {
+		if a.store == nil {
+			missingValidators = append(missingValidators, i)
+			continue
+		}
+		signature, ok := a.store.GetSignature(messageID, validator.PublicKey)
+		if !ok {
+			missingValidators = append(missingValidators, i)
+			continue
+		}
+		signatures = append(signatures, signature)
+		signersBitset.Add(i)
+		signaturesWeight += validator.Weight
+	}
+	if len(signatures) > 0 {
+		log.Debug("Reusing stored warp signatures",
+			"numSignatures", len(signatures),
+			"signatureWeight", signaturesWeight,
+			"msgID", messageID,
+		)
+	}
+	if err := avalancheWarp.VerifyWeight(signaturesWeight, a.totalWeight, quorumNum, params.WarpQuorumDenominator); err == nil {
+		return a.aggregate(unsignedMessage, signatures, signersBitset, signaturesWeight)
+	}
+
 	// Create a child context to cancel signature fetching if we reach signature threshold.
 	signatureFetchCtx, signatureFetchCancel := context.WithCancel(ctx)
 	defer signatureFetchCancel()
 
-	// Fetch signatures from validators concurrently.
-	signatureFetchResultChan := make(chan *signatureFetchResult)
-	for i, validator := range a.validators {
+	// Fetch the missing signatures from validators concurrently. The channel is buffered so
+	// fetches that complete after the threshold was reached do not block.
+	signatureFetchResultChan := make(chan *signatureFetchResult, len(missingValidators))
+	for _, i := range missingValidators {
 		var (
 			i         = i
-			validator = validator
+			validator = a.validators[i]
 			// TODO: update from a single nodeID to the original slice and use extra nodeIDs as backup.
 			nodeID = validator.NodeIDs[0]
 		)
@@ -100,6 +140,16 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 				return
 			}
 
+			if a.store != nil {
+				if err := a.store.PutSignature(messageID, validator.PublicKey, signature); err != nil {
+					log.Warn("Failed to store warp signature",
+						"nodeID", nodeID,
+						"msgID", unsignedMessage.ID(),
+						"err", err,
+					)
+				}
+			}
+
 			signatureFetchResultChan <- &signatureFetchResult{
 				sig:    signature,
 				index:  i,
@@ -108,14 +158,7 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 		}()
 	}
 
-	var (
-		signatures                = make([]*bls.Signature, 0, len(a.validators))
-		signersBitset             = set.NewBits()
-		signaturesWeight          = uint64(0)
-		signaturesPassedThreshold = false
-	)
-
-	for i := 0; i < len(a.validators); i++ {
+	for i := 0; i < len(missingValidators); i++ {
 		signatureFetchResult := <-signatureFetchResultChan
 		if signatureFetchResult == nil {
 			continue
@@ -150,6 +193,11 @@ func (a *Aggregator) AggregateSignatures(ctx context.Context, unsignedMessage *a
 	}
 
 	// Otherwise, return the aggregate signature
+	return a.aggregate(unsignedMessage, signatures, signersBitset, signaturesWeight)
+}
+
+// aggregate returns [unsignedMessage] signed by the aggregate of [signatures] from the validators in [signersBitset].
+func (a *Aggregator) aggregate(unsignedMessage *avalancheWarp.UnsignedMessage, signatures []*bls.Signature, signersBitset set.Bits, signaturesWeight uint64) (*AggregateSignatureResult, error) {
 	aggregateSignature, err := bls.AggregateSignatures(signatures)
 	if err != nil {
 		return nil, fmt.Errorf("failed to aggregate BLS signatures: %w", err)
@@ -12,6 +12,7 @@ import (
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -91,7 +92,7 @@ func TestAggregateSignatures(t *testing.T) {
 			aggregatorFunc: func(ctrl *gomock.Controller, _ context.CancelFunc) *Aggregator {
 				client := NewMockSignatureGetter(ctrl)
 				client.EXPECT().GetSignature(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errTest).Times(len(vdrs))
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   1,
@@ -107,7 +108,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nil, errTest).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   35, // Require >1/3 of weight
@@ -123,7 +124,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   69, // Require >2/3 of weight
@@ -139,7 +140,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nil, errTest).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       65, // Require <2/3 of weight
@@ -156,7 +157,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(sig1, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       100, // Require all weight
@@ -173,7 +174,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(sig2, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       64,
@@ -190,7 +191,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(nonVdrSig, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   1,
@@ -206,7 +207,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nonVdrSig, nil).Times(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg: unsignedMsg,
 			quorumNum:   40,
@@ -222,7 +223,7 @@ func TestAggregateSignatures(t *testing.T) {
 				client.EXPECT().GetSignature(gomock.Any(), nodeID1, gomock.Any()).Return(nonVdrSig, nil).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID2, gomock.Any()).Return(nil, errTest).MaxTimes(1)
 				client.EXPECT().GetSignature(gomock.Any(), nodeID3, gomock.Any()).Return(sig3, nil).Times(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       30,
@@ -264,7 +265,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       60, // Require 2/3 validators
@@ -304,7 +305,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       33, // 1/3 Should have gotten one signature before cancellation
@@ -330,7 +331,7 @@ func TestAggregateSignatures(t *testing.T) {
 						return nil, err
 					},
 				).MaxTimes(1)
-				return New(client, vdrs, vdrWeight*uint64(len(vdrs)))
+				return New(client, vdrs, vdrWeight*uint64(len(vdrs)), nil)
 			},
 			unsignedMsg:     unsignedMsg,
 			quorumNum:       60, // Require 2/3 validators
@@ -382,3 +383,64 @@ func TestAggregateSignatures(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateSignaturesReusesStoredSignatures(t *testing.T) {
+	require := require.New(t)
+
+	errTest := errors.New("test error")
+	unsignedMsg := &avalancheWarp.UnsignedMessage{
+		NetworkID:     1338,
+		SourceChainID: ids.ID{'y', 'e', 'e', 't'},
+		Payload:       []byte("hello world"),
+	}
+	require.NoError(unsignedMsg.Initialize())
+
+	vdrWeight := uint64(10001)
+	vdr1sk, vdr1 := newValidator(t, vdrWeight)
+	vdr2sk, vdr2 := newValidator(t, vdrWeight+1)
+	vdr3sk, vdr3 := newValidator(t, vdrWeight-1)
+	sig1 := bls.Sign(vdr1sk, unsignedMsg.Bytes())
+	sig2 := bls.Sign(vdr2sk, unsignedMsg.Bytes())
+	sig3 := bls.Sign(vdr3sk, unsignedMsg.Bytes())
+	vdrs := []*avalancheWarp.Validator{vdr1, vdr2, vdr3}
+	totalWeight := vdr1.Weight + vdr2.Weight + vdr3.Weight
+	store := NewSignatureStore(memdb.New())
+
+	// The first aggregation fails since only 2/3 validators reply, but their signatures are stored
+	ctrl := gomock.NewController(t)
+	client := NewMockSignatureGetter(ctrl)
+	client.EXPECT().GetSignature(gomock.Any(), vdr1.NodeIDs[0], gomock.Any()).Return(sig1, nil).Times(1)
+	client.EXPECT().GetSignature(gomock.Any(), vdr2.NodeIDs[0], gomock.Any()).Return(sig2, nil).Times(1)
+	client.EXPECT().GetSignature(gomock.Any(), vdr3.NodeIDs[0], gomock.Any()).Return(nil, errTest).Times(1)
+	_, err := New(client, vdrs, totalWeight, store).AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.ErrorIs(err, avalancheWarp.ErrInsufficientWeight)
+	ctrl.Finish()
+
+	storedSig, ok := store.GetSignature(unsignedMsg.ID(), vdr1.PublicKey)
+	require.True(ok)
+	require.Equal(bls.SignatureToBytes(sig1), bls.SignatureToBytes(storedSig))
+	_, ok = store.GetSignature(unsignedMsg.ID(), vdr3.PublicKey)
+	require.False(ok)
+
+	// Stored signatures meeting the quorum are used without fetching any signature
+	ctrl = gomock.NewController(t)
+	res, err := New(NewMockSignatureGetter(ctrl), vdrs, totalWeight, store).AggregateSignatures(context.Background(), unsignedMsg, 60)
+	require.NoError(err)
+	require.Equal(vdr1.Weight+vdr2.Weight, res.SignatureWeight)
+	ctrl.Finish()
+
+	// Raising the quorum only fetches the missing signature
+	ctrl = gomock.NewController(t)
+	client = NewMockSignatureGetter(ctrl)
+	client.EXPECT().GetSignature(gomock.Any(), vdr3.NodeIDs[0], gomock.Any()).Return(sig3, nil).Times(1)
+	res, err = New(client, vdrs, totalWeight, store).AggregateSignatures(context.Background(), unsignedMsg, 100)
+	require.NoError(err)
+	require.Equal(totalWeight, res.SignatureWeight)
+	ctrl.Finish()
+
+	expectedSig, err := bls.AggregateSignatures([]*bls.Signature{sig1, sig2, sig3})
+	require.NoError(err)
+	gotBLSSig, ok := res.Message.Signature.(*avalancheWarp.BitSetSignature)
+	require.True(ok)
+	require.Equal(bls.SignatureToBytes(expectedSig), gotBLSSig.Signature[:])
+}
@@ -0,0 +1,119 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var _ SignatureStore = (*dbSignatureStore)(nil)
+
+// SignatureStore persists the individual validator signatures collected during aggregation,
+// so that later aggregations of the same message only fetch the signatures still missing.
+type SignatureStore interface {
+	// GetSignature returns the stored signature of the validator with [publicKey] over the message
+	// with [messageID] if any.
+	GetSignature(messageID ids.ID, publicKey *bls.PublicKey) (*bls.Signature, bool)
+
+	// PutSignature stores the signature of the validator with [publicKey] over the message with [messageID].
+	// [signature] must have been verified against [publicKey].
+	PutSignature(messageID ids.ID, publicKey *bls.PublicKey, signature *bls.Signature) error
+
+	// DeleteSignatures removes all stored signatures over the message with [messageID].
+	DeleteSignatures(messageID ids.ID) error
+
+	// MessageIDs returns the IDs of the messages with stored signatures.
+	MessageIDs() ([]ids.ID, error)
+}
+
+// dbSignatureStore implements SignatureStore on top of a database, keying signatures by the message ID
+// followed by the compressed public key of the validator. Keying by public key rather than node ID
+// keeps collected signatures usable when a validator changes its node ID.
+type dbSignatureStore struct {
+	db database.Database
+}
+
+// NewSignatureStore returns a SignatureStore persisting signatures to [db].
+func NewSignatureStore(db database.Database) SignatureStore {
+	return &dbSignatureStore{db: db}
+}
+
+func (s *dbSignatureStore) GetSignature(messageID ids.ID, publicKey *bls.PublicKey) (*bls.Signature, bool) {
+	signatureBytes, err := s.db.Get(signatureKey(messageID, publicKey))
+	if err != nil {
+		if !errors.Is(err, database.ErrNotFound) {
+			log.Warn("Failed to get stored warp signature", "msgID", messageID, "err", err)
+		}
+		return nil, false
+	}
+	signature, err := bls.SignatureFromBytes(signatureBytes)
+	if err != nil {
+		log.Warn("Failed to parse stored warp signature", "msgID", messageID, "err", err)
+		return nil, false
+	}
+	return signature, true
+}
+
+func (s *dbSignatureStore) PutSignature(messageID ids.ID, publicKey *bls.PublicKey, signature *bls.Signature) error {
+	if err := s.db.Put(signatureKey(messageID, publicKey), bls.SignatureToBytes(signature)); err != nil {
+		return fmt.Errorf("failed to put warp signature of message %s: %w", messageID, err)
+	}
+	return nil
+}
+
+func (s *dbSignatureStore) DeleteSignatures(messageID ids.ID) error {
+	it := s.db.NewIteratorWithPrefix(messageID[:])
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to iterate warp signatures of message %s: %w", messageID, err)
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to delete warp signatures of message %s: %w", messageID, err)
+	}
+	return nil
+}
+
+func (s *dbSignatureStore) MessageIDs() ([]ids.ID, error) {
+	it := s.db.NewIterator()
+	defer it.Release()
+
+	messageIDs := make([]ids.ID, 0)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(ids.Empty)+bls.PublicKeyLen {
+			return nil, fmt.Errorf("unexpected warp signature key length %d", len(key))
+		}
+		messageID, err := ids.ToID(key[:len(ids.Empty)])
+		if err != nil {
+			return nil, err
+		}
+		// Signatures are keyed by message ID first, so signatures over the same message are adjacent
+		if len(messageIDs) == 0 || messageIDs[len(messageIDs)-1] != messageID {
+			messageIDs = append(messageIDs, messageID)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warp signatures: %w", err)
+	}
+	return messageIDs, nil
+}
+
+func signatureKey(messageID ids.ID, publicKey *bls.PublicKey) []byte {
+	key := make([]byte, 0, len(messageID)+bls.PublicKeyLen)
+	key = append(key, messageID[:]...)
+	return append(key, bls.PublicKeyToBytes(publicKey)...)
+}
@@ -6,16 +6,21 @@ package warp
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/ethdb"
+	"github.com/ava-labs/subnet-evm/warp/aggregator"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -30,8 +35,9 @@ type BlockClient interface {
 // Backend tracks signature-eligible warp messages and provides an interface to fetch them.
 // The backend is also used to query for warp message signatures by the signature request handler.
 type Backend interface {
-	// AddMessage signs [unsignedMessage] and adds it to the warp backend database
-	AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error
+	// AddMessage signs [unsignedMessage] accepted in block [blockNumber] with [blockTimestamp] and adds it to the
+	// warp backend database
+	AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error
 
 	// GetMessageSignature returns the signature of the requested message hash.
 	GetMessageSignature(messageID ids.ID) ([bls.SignatureLen]byte, error)
@@ -39,9 +45,27 @@ type Backend interface {
 	// GetBlockSignature returns the signature of the requested message hash.
 	GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, error)
 
+	// AddBlockHashMessage returns the block hash message of the accepted block [blockID] and indexes it, so
+	// that the signatures aggregated over it are pruned with the block
+	AddBlockHashMessage(ctx context.Context, blockID ids.ID) (*avalancheWarp.UnsignedMessage, error)
+
 	// GetMessage retrieves the [unsignedMessage] from the warp backend database if available
 	GetMessage(messageHash ids.ID) (*avalancheWarp.UnsignedMessage, error)
 
+	// GetMessagesBySender returns the messages sent by [sender] in blocks [fromBlock, toBlock] ordered by block number
+	GetMessagesBySender(sender common.Address, fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error)
+
+	// GetMessagesByDestination returns the messages with a typed payload addressed to [destinationChainID]
+	// in blocks [fromBlock, toBlock] ordered by block number
+	GetMessagesByDestination(destinationChainID common.Hash, fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error)
+
+	// GetMessagesInRange returns the messages accepted in blocks [fromBlock, toBlock] ordered by block number
+	GetMessagesInRange(fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error)
+
+	// Prune removes the messages outside of [policy], given the height of the last accepted block
+	// [lastAcceptedHeight], and returns the number of messages removed
+	Prune(policy RetentionPolicy, lastAcceptedHeight uint64) (int, error)
+
 	// Clear clears the entire db
 	Clear() error
 }
@@ -51,43 +75,82 @@ type backend struct {
 	networkID             uint32
 	sourceChainID         ids.ID
 	db                    database.Database
+	indexDB               database.Database
 	warpSigner            avalancheWarp.Signer
 	blockClient           BlockClient
 	messageSignatureCache *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	blockSignatureCache   *cache.LRU[ids.ID, [bls.SignatureLen]byte]
 	messageCache          *cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]
+	// signatureStore holds the aggregated signatures of messages, which are pruned with the messages.
+	// May be nil.
+	signatureStore aggregator.SignatureStore
+	stats          *backendStats
+	clock          mockable.Clock
+
+	// lock serializes adding messages with pruning them, so a message re-added in a later block
+	// is not pruned with its earlier occurrence.
+	lock sync.Mutex
+	// backfilled is set once the messages stored before the index was added have been indexed
+	backfilled bool
 }
 
 // NewBackend creates a new Backend, and initializes the signature cache and message tracking database.
-func NewBackend(networkID uint32, sourceChainID ids.ID, warpSigner avalancheWarp.Signer, blockClient BlockClient, db database.Database, cacheSize int) Backend {
-	return &backend{
+// Aggregated signatures in [signatureStore] are removed when their message is pruned.
+func NewBackend(networkID uint32, sourceChainID ids.ID, warpSigner avalancheWarp.Signer, blockClient BlockClient, db database.Database, signatureStore aggregator.SignatureStore, cacheSize int) Backend {
+	b := &backend{
 		networkID:             networkID,
 		sourceChainID:         sourceChainID,
 		db:                    db,
+		indexDB:               prefixdb.New(messageIndexPrefix, db),
 		warpSigner:            warpSigner,
 		blockClient:           blockClient,
 		messageSignatureCache: &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		blockSignatureCache:   &cache.LRU[ids.ID, [bls.SignatureLen]byte]{Size: cacheSize},
 		messageCache:          &cache.LRU[ids.ID, *avalancheWarp.UnsignedMessage]{Size: cacheSize},
+		signatureStore:        signatureStore,
+		stats:                 newBackendStats(),
+	}
+	if err := b.initStats(); err != nil {
+		log.Warn("Failed to compute warp backend database size", "err", err)
 	}
+	return b
 }
 
 func (b *backend) Clear() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	b.messageSignatureCache.Flush()
 	b.blockSignatureCache.Flush()
 	b.messageCache.Flush()
+	b.stats.clear()
 	return database.Clear(b.db, batchSize)
 }
 
-func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage) error {
+func (b *backend) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error {
 	messageID := unsignedMessage.ID()
+	messageBytes := unsignedMessage.Bytes()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	exists, err := b.db.Has(messageID[:])
+	if err != nil {
+		return fmt.Errorf("failed to check warp message in db: %w", err)
+	}
 
 	// In the case when a node restarts, and possibly changes its bls key, the cache gets emptied but the database does not.
 	// So to avoid having incorrect signatures saved in the database after a bls key change, we save the full message in the database.
 	// Whereas for the cache, after the node restart, the cache would be emptied so we can directly save the signatures.
-	if err := b.db.Put(messageID[:], unsignedMessage.Bytes()); err != nil {
+	if err := b.db.Put(messageID[:], messageBytes); err != nil {
 		return fmt.Errorf("failed to put warp signature in db: %w", err)
 	}
+	if err := b.indexMessage(unsignedMessage, blockNumber, blockTimestamp); err != nil {
+		return fmt.Errorf("failed to index warp message: %w", err)
+	}
+	if !exists {
+		b.stats.addMessage(len(messageBytes))
+	}
 
 	var signature [bls.SignatureLen]byte
 	sig, err := b.warpSigner.Sign(unsignedMessage)
@@ -138,13 +201,9 @@ func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, err
 	}
 
 	var signature [bls.SignatureLen]byte
-	blockHashPayload, err := payload.NewHash(blockID)
+	unsignedMessage, err := b.blockHashMessage(blockID)
 	if err != nil {
-		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to create new block hash payload: %w", err)
-	}
-	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(b.networkID, b.sourceChainID, blockHashPayload.Bytes())
-	if err != nil {
-		return [bls.SignatureLen]byte{}, fmt.Errorf("failed to create new unsigned warp message: %w", err)
+		return [bls.SignatureLen]byte{}, err
 	}
 	sig, err := b.warpSigner.Sign(unsignedMessage)
 	if err != nil {
@@ -156,6 +215,41 @@ func (b *backend) GetBlockSignature(blockID ids.ID) ([bls.SignatureLen]byte, err
 	return signature, nil
 }
 
+func (b *backend) AddBlockHashMessage(ctx context.Context, blockID ids.ID) (*avalancheWarp.UnsignedMessage, error) {
+	block, err := b.blockClient.GetBlock(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", blockID, err)
+	}
+	if block.Status() != choices.Accepted {
+		return nil, fmt.Errorf("block %s was not accepted", blockID)
+	}
+	unsignedMessage, err := b.blockHashMessage(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.indexBlockHashMessage(unsignedMessage.ID(), block.Height(), uint64(block.Timestamp().Unix())); err != nil {
+		return nil, fmt.Errorf("failed to index block hash message of block %s: %w", blockID, err)
+	}
+	return unsignedMessage, nil
+}
+
+// blockHashMessage returns the unsigned warp message with the hash payload of [blockID].
+func (b *backend) blockHashMessage(blockID ids.ID) (*avalancheWarp.UnsignedMessage, error) {
+	blockHashPayload, err := payload.NewHash(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new block hash payload: %w", err)
+	}
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(b.networkID, b.sourceChainID, blockHashPayload.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new unsigned warp message: %w", err)
+	}
+	return unsignedMessage, nil
+}
+
 func (b *backend) GetMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage, error) {
 	if message, ok := b.messageCache.Get(messageID); ok {
 		return message, nil
@@ -6,6 +6,7 @@ package warp
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -18,6 +19,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	warpcontract "github.com/ava-labs/subnet-evm/x/warp"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,7 +36,7 @@ func TestClearDB(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, db, nil, 500)
 	backend, ok := backendIntf.(*backend)
 	require.True(t, ok)
 
@@ -47,7 +50,7 @@ func TestClearDB(t *testing.T) {
 		require.NoError(t, err)
 		messageID := hashing.ComputeHash256Array(unsignedMsg.Bytes())
 		messageIDs = append(messageIDs, messageID)
-		err = backend.AddMessage(unsignedMsg)
+		err = backend.AddMessage(unsignedMsg, 0, 0)
 		require.NoError(t, err)
 		// ensure that the message was added
 		_, err = backend.GetMessageSignature(messageID)
@@ -76,12 +79,12 @@ func TestAddAndGetValidMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, nil, 500)
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
 	require.NoError(t, err)
-	err = backend.AddMessage(unsignedMsg)
+	err = backend.AddMessage(unsignedMsg, 0, 0)
 	require.NoError(t, err)
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
@@ -100,7 +103,7 @@ func TestAddAndGetUnknownMessage(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(t, err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, nil, 500)
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
 	require.NoError(t, err)
 
@@ -133,7 +136,7 @@ func TestGetBlockSignature(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(err)
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
-	backend := NewBackend(networkID, sourceChainID, warpSigner, testVM, db, 500)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, testVM, db, nil, 500)
 
 	blockHashPayload, err := payload.NewHash(blkID)
 	require.NoError(err)
@@ -158,12 +161,12 @@ func TestZeroSizedCache(t *testing.T) {
 	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
 
 	// Verify zero sized cache works normally, because the lru cache will be initialized to size 1 for any size parameter <= 0.
-	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, 0)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, nil, 0)
 
 	// Create a new unsigned message and add it to the warp backend.
 	unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
 	require.NoError(t, err)
-	err = backend.AddMessage(unsignedMsg)
+	err = backend.AddMessage(unsignedMsg, 0, 0)
 	require.NoError(t, err)
 
 	// Verify that a signature is returned successfully, and compare to expected signature.
@@ -175,3 +178,177 @@ func TestZeroSizedCache(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedSig, signature[:])
 }
+
+func TestMessageIndex(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), nil, 500)
+
+	senderA := ethcommon.HexToAddress("0x0100000000000000000000000000000000000001")
+	senderB := ethcommon.HexToAddress("0x0200000000000000000000000000000000000002")
+	addMessage := func(sender ethcommon.Address, blockNumber uint64, data string) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(sender.Bytes(), []byte(data))
+		require.NoError(err)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg, blockNumber, 0))
+		return unsignedMsg
+	}
+	// Add messages out of block order to check that results are ordered by block number
+	a5 := addMessage(senderA, 5, "a5")
+	b3 := addMessage(senderB, 3, "b3")
+	a1 := addMessage(senderA, 1, "a1")
+	b7 := addMessage(senderB, 7, "b7")
+	// Messages that are not addressed calls are only indexed by block number
+	rawMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(rawMsg, 4, 0))
+
+	requireMessages := func(expected []*avalancheWarp.UnsignedMessage, expectedSenders []ethcommon.Address, messages []*IndexedMessage) {
+		require.Len(messages, len(expected))
+		for i, message := range messages {
+			require.Equal(expected[i].ID(), message.Message.ID())
+			require.Equal(expectedSenders[i], message.SourceAddress)
+		}
+	}
+
+	messages, err := backend.GetMessagesBySender(senderA, 0, 10)
+	require.NoError(err)
+	requireMessages([]*avalancheWarp.UnsignedMessage{a1, a5}, []ethcommon.Address{senderA, senderA}, messages)
+	require.Equal(uint64(1), messages[0].BlockNumber)
+	require.Equal(uint64(5), messages[1].BlockNumber)
+
+	messages, err = backend.GetMessagesBySender(senderA, 2, 5)
+	require.NoError(err)
+	requireMessages([]*avalancheWarp.UnsignedMessage{a5}, []ethcommon.Address{senderA}, messages)
+
+	messages, err = backend.GetMessagesBySender(senderB, 0, 6)
+	require.NoError(err)
+	requireMessages([]*avalancheWarp.UnsignedMessage{b3}, []ethcommon.Address{senderB}, messages)
+
+	messages, err = backend.GetMessagesInRange(3, 7)
+	require.NoError(err)
+	requireMessages(
+		[]*avalancheWarp.UnsignedMessage{b3, rawMsg, a5, b7},
+		[]ethcommon.Address{senderB, {}, senderA, senderB},
+		messages,
+	)
+
+	messages, err = backend.GetMessagesInRange(8, 100)
+	require.NoError(err)
+	require.Empty(messages)
+
+	_, err = backend.GetMessagesInRange(5, 4)
+	require.ErrorContains(err, "invalid block range")
+}
+
+func TestMessageIndexSkipsMissingMessages(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), nil, 500)
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	sender := ethcommon.HexToAddress("0x0100000000000000000000000000000000000001")
+	addMessage := func(blockNumber uint64, data string) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(sender.Bytes(), []byte(data))
+		require.NoError(err)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg, blockNumber, 0))
+		return unsignedMsg
+	}
+	missing := addMessage(1, "missing")
+	stored := addMessage(2, "stored")
+
+	// Remove the message but not its index entries, as an interrupted prune would
+	missingID := missing.ID()
+	require.NoError(backend.db.Delete(missingID[:]))
+	backend.messageCache.Evict(missingID)
+
+	messages, err := backend.GetMessagesInRange(0, 10)
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(stored.ID(), messages[0].Message.ID())
+
+	messages, err = backend.GetMessagesBySender(sender, 0, 10)
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(stored.ID(), messages[0].Message.ID())
+}
+
+func TestMessageIndexByDestination(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), nil, 500)
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	sender := ethcommon.HexToAddress("0x0100000000000000000000000000000000000001")
+	destinationA := ethcommon.Hash{1}
+	destinationB := ethcommon.Hash{2}
+	addMessage := func(blockNumber uint64, addressedCallPayload []byte) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(sender.Bytes(), addressedCallPayload)
+		require.NoError(err)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		require.NoError(backend.AddMessage(unsignedMsg, blockNumber, 0))
+		return unsignedMsg
+	}
+	transferPayload, err := warpcontract.PackNativeTokenTransfer(warpcontract.NativeTokenTransfer{
+		DestinationChainID: destinationA,
+		Recipient:          sender,
+		Amount:             big.NewInt(1),
+	})
+	require.NoError(err)
+	callPayload, err := warpcontract.PackContractCall(warpcontract.ContractCall{
+		DestinationChainID: destinationB,
+		DestinationAddress: sender,
+		GasLimit:           100_000,
+	})
+	require.NoError(err)
+	transfer := addMessage(1, transferPayload)
+	call := addMessage(2, callPayload)
+	// Payloads that are not typed payloads are not indexed by destination
+	addMessage(3, destinationA.Bytes())
+
+	messages, err := backend.GetMessagesByDestination(destinationA, 0, 10)
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(transfer.ID(), messages[0].Message.ID())
+	require.Equal(sender, messages[0].SourceAddress)
+	require.Equal(uint64(1), messages[0].BlockNumber)
+
+	messages, err = backend.GetMessagesByDestination(destinationB, 0, 10)
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(call.ID(), messages[0].Message.ID())
+
+	// The source address is still returned by the block index
+	messages, err = backend.GetMessagesInRange(0, 10)
+	require.NoError(err)
+	require.Len(messages, 3)
+	for _, message := range messages {
+		require.Equal(sender, message.SourceAddress)
+	}
+
+	// Pruning removes the destination index entries
+	pruned, err := backend.Prune(RetentionPolicy{MaxBlockDepth: 1}, 3)
+	require.NoError(err)
+	require.Equal(2, pruned)
+	has, err := backend.indexDB.Has(indexKey(destinationIndexKeyPrefix(destinationA), 1, transfer.ID()))
+	require.NoError(err)
+	require.False(has)
+	messages, err = backend.GetMessagesByDestination(destinationB, 0, 10)
+	require.NoError(err)
+	require.Empty(messages)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -19,6 +20,9 @@ type Client interface {
 	GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64) ([]byte, error)
 	GetBlockSignature(ctx context.Context, blockID ids.ID) ([]byte, error)
 	GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, quorumNum uint64) ([]byte, error)
+	GetMessagesBySender(ctx context.Context, sender common.Address, fromBlock uint64, toBlock uint64) ([]MessageResult, error)
+	GetMessagesByDestination(ctx context.Context, destinationChainID common.Hash, fromBlock uint64, toBlock uint64) ([]MessageResult, error)
+	GetMessagesInRange(ctx context.Context, fromBlock uint64, toBlock uint64) ([]MessageResult, error)
 }
 
 // client implementation for interacting with EVM [chain]
@@ -68,3 +72,27 @@ func (c *client) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID,
 	}
 	return res, nil
 }
+
+func (c *client) GetMessagesBySender(ctx context.Context, sender common.Address, fromBlock uint64, toBlock uint64) ([]MessageResult, error) {
+	var res []MessageResult
+	if err := c.client.CallContext(ctx, &res, "warp_getMessagesBySender", sender, hexutil.Uint64(fromBlock), hexutil.Uint64(toBlock)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessagesBySender failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetMessagesByDestination(ctx context.Context, destinationChainID common.Hash, fromBlock uint64, toBlock uint64) ([]MessageResult, error) {
+	var res []MessageResult
+	if err := c.client.CallContext(ctx, &res, "warp_getMessagesByDestination", destinationChainID, hexutil.Uint64(fromBlock), hexutil.Uint64(toBlock)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessagesByDestination failed. err: %w", err)
+	}
+	return res, nil
+}
+
+func (c *client) GetMessagesInRange(ctx context.Context, fromBlock uint64, toBlock uint64) ([]MessageResult, error) {
+	var res []MessageResult
+	if err := c.client.CallContext(ctx, &res, "warp_getMessagesInRange", hexutil.Uint64(fromBlock), hexutil.Uint64(toBlock)); err != nil {
+		return nil, fmt.Errorf("call to warp_getMessagesInRange failed. err: %w", err)
+	}
+	return res, nil
+}
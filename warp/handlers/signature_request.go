@@ -9,24 +9,57 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/subnet-evm/plugin/evm/message"
 	"github.com/ava-labs/subnet-evm/warp"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/semaphore"
 )
 
 // SignatureRequestHandler serves warp signature requests. It is a peer.RequestHandler for message.MessageSignatureRequest.
 type SignatureRequestHandler struct {
-	backend warp.Backend
-	codec   codec.Manager
-	stats   *handlerStats
+	backend        warp.Backend
+	codec          codec.Manager
+	throttler      p2p.Throttler       // limits the rate of requests served per node, nil if unlimited
+	activeRequests *semaphore.Weighted // limits the number of requests served concurrently, nil if unlimited
+	stats          *handlerStats
 }
 
-func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager) *SignatureRequestHandler {
-	return &SignatureRequestHandler{
-		backend: backend,
-		codec:   codec,
-		stats:   newStats(),
+// NewSignatureRequestHandler returns a handler serving signatures from [backend].
+// Requests from a node are dropped if [throttler] is non-nil and rejects the node, or if
+// [maxActiveRequests] is positive and that many requests are already being served.
+func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager, throttler p2p.Throttler, maxActiveRequests int64) *SignatureRequestHandler {
+	handler := &SignatureRequestHandler{
+		backend:   backend,
+		codec:     codec,
+		throttler: throttler,
+		stats:     newStats(),
+	}
+	if maxActiveRequests > 0 {
+		handler.activeRequests = semaphore.NewWeighted(maxActiveRequests)
+	}
+	return handler
+}
+
+// acquire returns true if a request from [nodeID] should be served, in which case
+// release must be called once it has been served.
+func (s *SignatureRequestHandler) acquire(nodeID ids.NodeID) bool {
+	if s.throttler != nil && !s.throttler.Handle(nodeID) {
+		s.stats.IncSignatureRequestRateLimited()
+		return false
+	}
+	// Drop rather than wait for a slot so a flood of requests cannot queue up signing work
+	if s.activeRequests != nil && !s.activeRequests.TryAcquire(1) {
+		s.stats.IncSignatureRequestConcurrencyLimited()
+		return false
+	}
+	return true
+}
+
+func (s *SignatureRequestHandler) release() {
+	if s.activeRequests != nil {
+		s.activeRequests.Release(1)
 	}
 }
 
@@ -34,11 +67,18 @@ func NewSignatureRequestHandler(backend warp.Backend, codec codec.Manager) *Sign
 // Never returns an error
 // Expects returned errors to be treated as FATAL
 // Returns empty response if signature is not found
+// Returns no response if the request is dropped due to rate or concurrency limits
 // Assumes ctx is active
 func (s *SignatureRequestHandler) OnMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, signatureRequest message.MessageSignatureRequest) ([]byte, error) {
 	startTime := time.Now()
 	s.stats.IncMessageSignatureRequest()
 
+	if !s.acquire(nodeID) {
+		log.Debug("Dropping warp message signature request", "nodeID", nodeID, "requestID", requestID, "messageID", signatureRequest.MessageID)
+		return nil, nil
+	}
+	defer s.release()
+
 	// Always report signature request time
 	defer func() {
 		s.stats.UpdateMessageSignatureRequestTime(time.Since(startTime))
@@ -67,6 +107,12 @@ func (s *SignatureRequestHandler) OnBlockSignatureRequest(ctx context.Context, n
 	startTime := time.Now()
 	s.stats.IncBlockSignatureRequest()
 
+	if !s.acquire(nodeID) {
+		log.Debug("Dropping warp block signature request", "nodeID", nodeID, "requestID", requestID, "blockID", request.BlockID)
+		return nil, nil
+	}
+	defer s.release()
+
 	// Always report signature request time
 	defer func() {
 		s.stats.UpdateBlockSignatureRequestTime(time.Since(startTime))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -30,13 +31,13 @@ func TestMessageSignatureHandler(t *testing.T) {
 	require.NoError(t, err)
 
 	warpSigner := avalancheWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
-	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, &block.TestVM{TestVM: common.TestVM{T: t}}, database, 100)
+	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, &block.TestVM{TestVM: common.TestVM{T: t}}, database, nil, 100)
 
 	msg, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, []byte("test"))
 	require.NoError(t, err)
 
 	messageID := msg.ID()
-	require.NoError(t, backend.AddMessage(msg))
+	require.NoError(t, backend.AddMessage(msg, 0, 0))
 	signature, err := backend.GetMessageSignature(messageID)
 	require.NoError(t, err)
 	unknownMessageID := ids.GenerateTestID()
@@ -85,7 +86,7 @@ func TestMessageSignatureHandler(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			handler := NewSignatureRequestHandler(backend, message.Codec)
+			handler := NewSignatureRequestHandler(backend, message.Codec, nil, 0)
 			handler.stats.Clear()
 
 			request, expectedResponse := test.setup()
@@ -136,6 +137,7 @@ func TestBlockSignatureHandler(t *testing.T) {
 		warpSigner,
 		testVM,
 		database,
+		nil,
 		100,
 	)
 
@@ -187,7 +189,7 @@ func TestBlockSignatureHandler(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			handler := NewSignatureRequestHandler(backend, message.Codec)
+			handler := NewSignatureRequestHandler(backend, message.Codec, nil, 0)
 			handler.stats.Clear()
 
 			request, expectedResponse := test.setup()
@@ -209,3 +211,48 @@ func TestBlockSignatureHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSignatureHandlerLimits(t *testing.T) {
+	require := require.New(t)
+
+	database := memdb.New()
+	snowCtx := snow.DefaultContextTest()
+	blsSecretKey, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	warpSigner := avalancheWarp.NewSigner(blsSecretKey, snowCtx.NetworkID, snowCtx.ChainID)
+	backend := warp.NewBackend(snowCtx.NetworkID, snowCtx.ChainID, warpSigner, &block.TestVM{TestVM: common.TestVM{T: t}}, database, nil, 100)
+
+	msg, err := avalancheWarp.NewUnsignedMessage(snowCtx.NetworkID, snowCtx.ChainID, []byte("test"))
+	require.NoError(err)
+	require.NoError(backend.AddMessage(msg, 0, 0))
+	request := message.MessageSignatureRequest{MessageID: msg.ID()}
+
+	handler := NewSignatureRequestHandler(backend, message.Codec, p2p.NewSlidingWindowThrottler(time.Minute, 1), 1)
+	handler.stats.Clear()
+
+	// The first request from a node is served, the second is rate limited
+	nodeID := ids.GenerateTestNodeID()
+	responseBytes, err := handler.OnMessageSignatureRequest(context.Background(), nodeID, 1, request)
+	require.NoError(err)
+	require.NotEmpty(responseBytes)
+	responseBytes, err = handler.OnMessageSignatureRequest(context.Background(), nodeID, 2, request)
+	require.NoError(err)
+	require.Empty(responseBytes)
+	require.EqualValues(1, handler.stats.signatureRequestRateLimited.Count())
+
+	// Requests from another node are dropped while the maximum number of requests is being served
+	require.True(handler.activeRequests.TryAcquire(1))
+	otherNodeID := ids.GenerateTestNodeID()
+	responseBytes, err = handler.OnBlockSignatureRequest(context.Background(), otherNodeID, 1, message.BlockSignatureRequest{BlockID: ids.GenerateTestID()})
+	require.NoError(err)
+	require.Empty(responseBytes)
+	require.EqualValues(1, handler.stats.signatureRequestConcurrencyLimited.Count())
+	handler.activeRequests.Release(1)
+
+	responseBytes, err = handler.OnMessageSignatureRequest(context.Background(), ids.GenerateTestNodeID(), 1, request)
+	require.NoError(err)
+	require.NotEmpty(responseBytes)
+	require.EqualValues(3, handler.stats.messageSignatureRequest.Count())
+	require.EqualValues(2, handler.stats.messageSignatureHit.Count())
+}
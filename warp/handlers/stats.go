@@ -20,18 +20,23 @@ type handlerStats struct {
 	blockSignatureHit             metrics.Counter
 	blockSignatureMiss            metrics.Counter
 	blockSignatureRequestDuration metrics.Gauge
+	// Requests of either type dropped by the handler limits
+	signatureRequestRateLimited        metrics.Counter
+	signatureRequestConcurrencyLimited metrics.Counter
 }
 
 func newStats() *handlerStats {
 	return &handlerStats{
-		messageSignatureRequest:         metrics.GetOrRegisterCounter("message_signature_request_count", nil),
-		messageSignatureHit:             metrics.GetOrRegisterCounter("message_signature_request_hit", nil),
-		messageSignatureMiss:            metrics.GetOrRegisterCounter("message_signature_request_miss", nil),
-		messageSignatureRequestDuration: metrics.GetOrRegisterGauge("message_signature_request_duration", nil),
-		blockSignatureRequest:           metrics.GetOrRegisterCounter("block_signature_request_count", nil),
-		blockSignatureHit:               metrics.GetOrRegisterCounter("block_signature_request_hit", nil),
-		blockSignatureMiss:              metrics.GetOrRegisterCounter("block_signature_request_miss", nil),
-		blockSignatureRequestDuration:   metrics.GetOrRegisterGauge("block_signature_request_duration", nil),
+		messageSignatureRequest:            metrics.GetOrRegisterCounter("message_signature_request_count", nil),
+		messageSignatureHit:                metrics.GetOrRegisterCounter("message_signature_request_hit", nil),
+		messageSignatureMiss:               metrics.GetOrRegisterCounter("message_signature_request_miss", nil),
+		messageSignatureRequestDuration:    metrics.GetOrRegisterGauge("message_signature_request_duration", nil),
+		blockSignatureRequest:              metrics.GetOrRegisterCounter("block_signature_request_count", nil),
+		blockSignatureHit:                  metrics.GetOrRegisterCounter("block_signature_request_hit", nil),
+		blockSignatureMiss:                 metrics.GetOrRegisterCounter("block_signature_request_miss", nil),
+		blockSignatureRequestDuration:      metrics.GetOrRegisterGauge("block_signature_request_duration", nil),
+		signatureRequestRateLimited:        metrics.GetOrRegisterCounter("signature_request_rate_limited", nil),
+		signatureRequestConcurrencyLimited: metrics.GetOrRegisterCounter("signature_request_concurrency_limited", nil),
 	}
 }
 
@@ -47,6 +52,10 @@ func (h *handlerStats) IncBlockSignatureMiss()    { h.blockSignatureMiss.Inc(1)
 func (h *handlerStats) UpdateBlockSignatureRequestTime(duration time.Duration) {
 	h.blockSignatureRequestDuration.Inc(int64(duration))
 }
+func (h *handlerStats) IncSignatureRequestRateLimited() { h.signatureRequestRateLimited.Inc(1) }
+func (h *handlerStats) IncSignatureRequestConcurrencyLimited() {
+	h.signatureRequestConcurrencyLimited.Inc(1)
+}
 func (h *handlerStats) Clear() {
 	h.messageSignatureRequest.Clear()
 	h.messageSignatureHit.Clear()
@@ -56,4 +65,6 @@ func (h *handlerStats) Clear() {
 	h.blockSignatureHit.Clear()
 	h.blockSignatureMiss.Clear()
 	h.blockSignatureRequestDuration.Update(0)
+	h.signatureRequestRateLimited.Clear()
+	h.signatureRequestConcurrencyLimited.Clear()
 }
@@ -0,0 +1,236 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	warpcontract "github.com/ava-labs/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const blockNumberLen = 8
+
+var (
+	// Accepted messages are indexed in a prefixDB with the prefix [messageIndexPrefix], by block
+	// number, by source address and block number and, for addressed calls carrying a typed payload,
+	// by destination chain ID and block number, so all indices can be iterated in block order:
+	// [blockIndexPrefix] + blockNumber + messageID => sourceAddress [+ destinationChainID]
+	// [senderIndexPrefix] + sourceAddress + blockNumber + messageID => nil
+	// [destinationIndexPrefix] + destinationChainID + blockNumber + messageID => sourceAddress
+	// For pruning, the index also tracks the last block each message was accepted in, the timestamp
+	// of the indexed blocks and the block hash messages whose aggregated signatures may be stored:
+	// [messageBlockPrefix] + messageID => blockNumber
+	// [blockTimePrefix] + blockNumber => unix timestamp
+	// [blockHashMessagePrefix] + blockNumber + messageID => nil
+	// [backfilledKey] is set once the messages and signatures stored before the index was added
+	// have been indexed.
+	messageIndexPrefix     = []byte("messageIndex")
+	blockIndexPrefix       = []byte("block")
+	senderIndexPrefix      = []byte("sender")
+	destinationIndexPrefix = []byte("destination")
+	messageBlockPrefix     = []byte("messageBlock")
+	blockTimePrefix        = []byte("time")
+	blockHashMessagePrefix = []byte("hashMessage")
+	backfilledKey          = []byte("backfilled")
+)
+
+// IndexedMessage is a warp message together with where it was sent from.
+type IndexedMessage struct {
+	// Number of the block the message was accepted in
+	BlockNumber uint64
+	// Address that sent the message, or the zero address if the message is not an addressed call
+	SourceAddress common.Address
+	Message       *avalancheWarp.UnsignedMessage
+}
+
+func (b *backend) GetMessagesBySender(sender common.Address, fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error) {
+	return b.getIndexedMessages(senderIndexKeyPrefix(sender), fromBlock, toBlock, func([]byte) common.Address { return sender })
+}
+
+func (b *backend) GetMessagesByDestination(destinationChainID common.Hash, fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error) {
+	return b.getIndexedMessages(destinationIndexKeyPrefix(destinationChainID), fromBlock, toBlock, common.BytesToAddress)
+}
+
+func (b *backend) GetMessagesInRange(fromBlock uint64, toBlock uint64) ([]*IndexedMessage, error) {
+	return b.getIndexedMessages(blockIndexPrefix, fromBlock, toBlock, func(value []byte) common.Address {
+		sourceAddress, _, _ := parseBlockIndexValue(value)
+		return sourceAddress
+	})
+}
+
+// indexMessage adds [unsignedMessage] accepted in block [blockNumber] with [blockTimestamp] to the
+// block index and, if it is an addressed call, to the sender index and, if its payload is a typed
+// payload, to the destination index.
+func (b *backend) indexMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error {
+	messageID := unsignedMessage.ID()
+	var (
+		sourceAddress      common.Address
+		destinationChainID common.Hash
+		hasDestination     bool
+	)
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	isAddressedCall := err == nil
+	if isAddressedCall {
+		sourceAddress = common.BytesToAddress(addressedCall.SourceAddress)
+		destinationChainID, hasDestination = typedPayloadDestination(addressedCall.Payload)
+	}
+
+	blockIndexValue := sourceAddress.Bytes()
+	if hasDestination {
+		blockIndexValue = append(blockIndexValue, destinationChainID.Bytes()...)
+	}
+	batch := b.indexDB.NewBatch()
+	if err := batch.Put(indexKey(blockIndexPrefix, blockNumber, messageID), blockIndexValue); err != nil {
+		return err
+	}
+	if isAddressedCall {
+		if err := batch.Put(indexKey(senderIndexKeyPrefix(sourceAddress), blockNumber, messageID), nil); err != nil {
+			return err
+		}
+	}
+	if hasDestination {
+		if err := batch.Put(indexKey(destinationIndexKeyPrefix(destinationChainID), blockNumber, messageID), sourceAddress.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(messageBlockKey(messageID), binary.BigEndian.AppendUint64(nil, blockNumber)); err != nil {
+		return err
+	}
+	if err := batch.Put(blockTimeKey(blockNumber), binary.BigEndian.AppendUint64(nil, blockTimestamp)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// indexBlockHashMessage adds the block hash message with [messageID] of block [blockNumber] with
+// [blockTimestamp] to the index, so that the signatures aggregated over it are pruned with the block.
+func (b *backend) indexBlockHashMessage(messageID ids.ID, blockNumber uint64, blockTimestamp uint64) error {
+	batch := b.indexDB.NewBatch()
+	if err := batch.Put(indexKey(blockHashMessagePrefix, blockNumber, messageID), nil); err != nil {
+		return err
+	}
+	if err := batch.Put(blockTimeKey(blockNumber), binary.BigEndian.AppendUint64(nil, blockTimestamp)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// getIndexedMessages iterates the index entries under [prefix] for blocks [fromBlock, toBlock] and
+// returns the indexed messages, using [sourceAddress] to get the source address from an entry value.
+// Entries of messages that are no longer stored are skipped.
+func (b *backend) getIndexedMessages(prefix []byte, fromBlock uint64, toBlock uint64, sourceAddress func(value []byte) common.Address) ([]*IndexedMessage, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range: from block %d is after to block %d", fromBlock, toBlock)
+	}
+
+	start := make([]byte, 0, len(prefix)+blockNumberLen)
+	start = append(start, prefix...)
+	start = binary.BigEndian.AppendUint64(start, fromBlock)
+	it := b.indexDB.NewIteratorWithStartAndPrefix(start, prefix)
+	defer it.Release()
+
+	messages := make([]*IndexedMessage, 0)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+blockNumberLen+len(ids.Empty) {
+			return nil, fmt.Errorf("unexpected warp message index key length %d", len(key))
+		}
+		blockNumber := binary.BigEndian.Uint64(key[len(prefix):])
+		if blockNumber > toBlock {
+			break
+		}
+		messageID, err := ids.ToID(key[len(prefix)+blockNumberLen:])
+		if err != nil {
+			return nil, err
+		}
+		unsignedMessage, err := b.GetMessage(messageID)
+		if errors.Is(err, database.ErrNotFound) {
+			// The message may be pruned before its index entries
+			log.Debug("Skipping indexed warp message missing from db", "messageID", messageID, "blockNumber", blockNumber)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &IndexedMessage{
+			BlockNumber:   blockNumber,
+			SourceAddress: sourceAddress(it.Value()),
+			Message:       unsignedMessage,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate warp message index: %w", err)
+	}
+	return messages, nil
+}
+
+func indexKey(prefix []byte, blockNumber uint64, messageID ids.ID) []byte {
+	key := make([]byte, 0, len(prefix)+blockNumberLen+len(messageID))
+	key = append(key, prefix...)
+	key = binary.BigEndian.AppendUint64(key, blockNumber)
+	return append(key, messageID[:]...)
+}
+
+// typedPayloadDestination returns the destination chain ID of [addressedCallPayload] if it is one of
+// the typed payloads of the warp precompile.
+func typedPayloadDestination(addressedCallPayload []byte) (common.Hash, bool) {
+	payloadType, _, err := warpcontract.UnpackPayloadType(addressedCallPayload)
+	if err != nil {
+		return common.Hash{}, false
+	}
+	switch payloadType {
+	case warpcontract.NativeTokenTransferPayloadType:
+		transfer, err := warpcontract.UnpackNativeTokenTransfer(addressedCallPayload)
+		return transfer.DestinationChainID, err == nil
+	case warpcontract.ERC20TransferPayloadType:
+		transfer, err := warpcontract.UnpackERC20Transfer(addressedCallPayload)
+		return transfer.DestinationChainID, err == nil
+	case warpcontract.ContractCallPayloadType:
+		call, err := warpcontract.UnpackContractCall(addressedCallPayload)
+		return call.DestinationChainID, err == nil
+	default:
+		return common.Hash{}, false
+	}
+}
+
+// parseBlockIndexValue returns the source address of a block index entry value, and the destination
+// chain ID if the message was also indexed by destination.
+func parseBlockIndexValue(value []byte) (common.Address, common.Hash, bool) {
+	if len(value) < common.AddressLength+common.HashLength {
+		return common.BytesToAddress(value), common.Hash{}, false
+	}
+	return common.BytesToAddress(value[:common.AddressLength]), common.BytesToHash(value[common.AddressLength:]), true
+}
+
+func senderIndexKeyPrefix(sender common.Address) []byte {
+	prefix := make([]byte, 0, len(senderIndexPrefix)+common.AddressLength)
+	prefix = append(prefix, senderIndexPrefix...)
+	return append(prefix, sender.Bytes()...)
+}
+
+func destinationIndexKeyPrefix(destinationChainID common.Hash) []byte {
+	prefix := make([]byte, 0, len(destinationIndexPrefix)+common.HashLength)
+	prefix = append(prefix, destinationIndexPrefix...)
+	return append(prefix, destinationChainID.Bytes()...)
+}
+
+func messageBlockKey(messageID ids.ID) []byte {
+	key := make([]byte, 0, len(messageBlockPrefix)+len(messageID))
+	key = append(key, messageBlockPrefix...)
+	return append(key, messageID[:]...)
+}
+
+func blockTimeKey(blockNumber uint64) []byte {
+	key := make([]byte, 0, len(blockTimePrefix)+blockNumberLen)
+	key = append(key, blockTimePrefix...)
+	return binary.BigEndian.AppendUint64(key, blockNumber)
+}
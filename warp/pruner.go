@@ -0,0 +1,376 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RetentionPolicy determines which accepted warp messages are kept in the backend database.
+// Messages outside of the policy can no longer be signed on request. A zero limit is disabled.
+type RetentionPolicy struct {
+	// MaxBlockDepth keeps the messages accepted in the last MaxBlockDepth blocks.
+	MaxBlockDepth uint64
+	// MaxAge keeps the messages accepted in blocks with a timestamp in the last MaxAge.
+	MaxAge time.Duration
+}
+
+// Enabled returns true if [p] prunes any messages.
+func (p RetentionPolicy) Enabled() bool {
+	return p.MaxBlockDepth > 0 || p.MaxAge > 0
+}
+
+// RunPruning prunes the messages of [backend] outside of [policy] every [frequency] until [ctx]
+// is cancelled. [lastAcceptedHeight] returns the height of the last accepted block.
+func RunPruning(ctx context.Context, backend Backend, policy RetentionPolicy, frequency time.Duration, lastAcceptedHeight func() uint64) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			start := time.Now()
+			pruned, err := backend.Prune(policy, lastAcceptedHeight())
+			if err != nil {
+				log.Warn("Failed to prune warp messages", "pruned", pruned, "err", err)
+				continue
+			}
+			if pruned > 0 {
+				log.Info("Pruned warp messages", "pruned", pruned, "duration", time.Since(start))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *backend) Prune(policy RetentionPolicy, lastAcceptedHeight uint64) (int, error) {
+	if err := b.backfillIndex(lastAcceptedHeight); err != nil {
+		return 0, err
+	}
+	cutoff, err := b.pruneCutoff(policy, lastAcceptedHeight)
+	if err != nil {
+		return 0, err
+	}
+	if cutoff == 0 {
+		return 0, nil
+	}
+
+	// Prune in batches, releasing the lock in between so that pruning a large database
+	// does not hold up accepting messages.
+	pruned := 0
+	for {
+		batchPruned, done, err := b.pruneBatch(cutoff)
+		pruned += batchPruned
+		if err != nil {
+			b.stats.prunedMessages.Inc(int64(pruned))
+			return pruned, err
+		}
+		if done {
+			break
+		}
+	}
+	b.stats.prunedMessages.Inc(int64(pruned))
+
+	for {
+		done, err := b.pruneBlockHashBatch(cutoff)
+		if err != nil {
+			return pruned, err
+		}
+		if done {
+			break
+		}
+	}
+	return pruned, nil
+}
+
+// backfillIndex indexes the messages and aggregated signatures stored before the message index was
+// added, so that they are pruned like the messages accepted since. The blocks they belong to are
+// unknown, so they are indexed in block [lastAcceptedHeight], which keeps them for at least as long
+// as any [RetentionPolicy] requires. The backfill runs once per database.
+func (b *backend) backfillIndex(lastAcceptedHeight uint64) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.backfilled {
+		return nil
+	}
+	backfilled, err := b.indexDB.Has(backfilledKey)
+	if err != nil {
+		return fmt.Errorf("failed to check warp message index backfill: %w", err)
+	}
+	if backfilled {
+		b.backfilled = true
+		return nil
+	}
+
+	blockTimestamp := b.clock.Unix()
+	if timestampBytes, err := b.indexDB.Get(blockTimeKey(lastAcceptedHeight)); err == nil && len(timestampBytes) == 8 {
+		blockTimestamp = binary.BigEndian.Uint64(timestampBytes)
+	} else if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+
+	// Collect the messages before indexing them, so the database is not written while iterated.
+	unindexed := make([]*avalancheWarp.UnsignedMessage, 0)
+	it := b.db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		// Messages are the only entries keyed by their ID. The index and signatures are nested
+		// prefixDBs with longer keys.
+		if len(it.Key()) != len(ids.Empty) {
+			continue
+		}
+		messageID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		indexed, err := b.indexDB.Has(messageBlockKey(messageID))
+		if err != nil {
+			return err
+		}
+		if indexed {
+			continue
+		}
+		unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(it.Value())
+		if err != nil {
+			return fmt.Errorf("failed to parse unsigned message %s: %w", messageID, err)
+		}
+		unindexed = append(unindexed, unsignedMessage)
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to iterate warp messages: %w", err)
+	}
+	for _, unsignedMessage := range unindexed {
+		if err := b.indexMessage(unsignedMessage, lastAcceptedHeight, blockTimestamp); err != nil {
+			return fmt.Errorf("failed to index warp message %s: %w", unsignedMessage.ID(), err)
+		}
+	}
+
+	// Signatures over messages of the backend are pruned with the message, so the remaining
+	// signatures were aggregated over block hash messages.
+	if b.signatureStore != nil {
+		messageIDs, err := b.signatureStore.MessageIDs()
+		if err != nil {
+			return err
+		}
+		for _, messageID := range messageIDs {
+			isMessage, err := b.db.Has(messageID[:])
+			if err != nil {
+				return err
+			}
+			if isMessage {
+				continue
+			}
+			if err := b.indexBlockHashMessage(messageID, lastAcceptedHeight, blockTimestamp); err != nil {
+				return fmt.Errorf("failed to index block hash message %s: %w", messageID, err)
+			}
+		}
+	}
+
+	if err := b.indexDB.Put(backfilledKey, nil); err != nil {
+		return fmt.Errorf("failed to mark warp message index backfill: %w", err)
+	}
+	b.backfilled = true
+	log.Info("Backfilled warp message index", "messages", len(unindexed), "height", lastAcceptedHeight)
+	return nil
+}
+
+// pruneCutoff returns the first block whose messages are kept under [policy].
+func (b *backend) pruneCutoff(policy RetentionPolicy, lastAcceptedHeight uint64) (uint64, error) {
+	var cutoff uint64
+	if policy.MaxBlockDepth > 0 && lastAcceptedHeight >= policy.MaxBlockDepth {
+		cutoff = lastAcceptedHeight - policy.MaxBlockDepth + 1
+	}
+	if policy.MaxAge <= 0 {
+		return cutoff, nil
+	}
+
+	// Block timestamps increase with the block number, so the expired blocks are the first entries of
+	// the time index.
+	expiry := b.clock.Time().Add(-policy.MaxAge)
+	it := b.indexDB.NewIteratorWithPrefix(blockTimePrefix)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(blockTimePrefix)+blockNumberLen || len(it.Value()) != 8 {
+			return 0, fmt.Errorf("unexpected warp message time index entry length %d", len(key))
+		}
+		blockTime := time.Unix(int64(binary.BigEndian.Uint64(it.Value())), 0)
+		if !blockTime.Before(expiry) {
+			break
+		}
+		if blockNumber := binary.BigEndian.Uint64(key[len(blockTimePrefix):]); blockNumber >= cutoff {
+			cutoff = blockNumber + 1
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate warp message time index: %w", err)
+	}
+	return cutoff, nil
+}
+
+// pruneBatch removes up to a batch of messages accepted before block [cutoff], and returns the number of
+// messages removed and whether all messages before [cutoff] have been removed.
+func (b *backend) pruneBatch(cutoff uint64) (int, bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		batch       = b.db.NewBatch()
+		indexBatch  = b.indexDB.NewBatch()
+		prunedIDs   = make([]ids.ID, 0)
+		prunedBytes = make([]int, 0)
+		done        = true
+	)
+	it := b.indexDB.NewIteratorWithPrefix(blockIndexPrefix)
+	defer it.Release()
+	for it.Next() {
+		if batch.Size()+indexBatch.Size() >= batchSize {
+			done = false
+			break
+		}
+		key := it.Key()
+		if len(key) != len(blockIndexPrefix)+blockNumberLen+len(ids.Empty) {
+			return 0, false, fmt.Errorf("unexpected warp message index key length %d", len(key))
+		}
+		blockNumber := binary.BigEndian.Uint64(key[len(blockIndexPrefix):])
+		if blockNumber >= cutoff {
+			break
+		}
+		messageID, err := ids.ToID(key[len(blockIndexPrefix)+blockNumberLen:])
+		if err != nil {
+			return 0, false, err
+		}
+		sourceAddress, destinationChainID, hasDestination := parseBlockIndexValue(it.Value())
+		if err := indexBatch.Delete(key); err != nil {
+			return 0, false, err
+		}
+		if err := indexBatch.Delete(indexKey(senderIndexKeyPrefix(sourceAddress), blockNumber, messageID)); err != nil {
+			return 0, false, err
+		}
+		if hasDestination {
+			if err := indexBatch.Delete(indexKey(destinationIndexKeyPrefix(destinationChainID), blockNumber, messageID)); err != nil {
+				return 0, false, err
+			}
+		}
+		if err := indexBatch.Delete(blockTimeKey(blockNumber)); err != nil {
+			return 0, false, err
+		}
+
+		// Keep the message if it was accepted again in a block that is not pruned.
+		lastBlock, err := b.indexDB.Get(messageBlockKey(messageID))
+		switch {
+		case err == nil && len(lastBlock) == blockNumberLen && binary.BigEndian.Uint64(lastBlock) >= cutoff:
+			continue
+		case err != nil && !errors.Is(err, database.ErrNotFound):
+			return 0, false, err
+		}
+		messageBytes, err := b.db.Get(messageID[:])
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			// Already pruned with an earlier occurrence of the message
+			continue
+		case err != nil:
+			return 0, false, err
+		}
+		if err := batch.Delete(messageID[:]); err != nil {
+			return 0, false, err
+		}
+		if err := indexBatch.Delete(messageBlockKey(messageID)); err != nil {
+			return 0, false, err
+		}
+		prunedIDs = append(prunedIDs, messageID)
+		prunedBytes = append(prunedBytes, len(messageBytes))
+	}
+	if err := it.Error(); err != nil {
+		return 0, false, fmt.Errorf("failed to iterate warp message index: %w", err)
+	}
+
+	// Remove the signatures first, so that a failure leaves the messages to be pruned again.
+	if b.signatureStore != nil {
+		for _, messageID := range prunedIDs {
+			if err := b.signatureStore.DeleteSignatures(messageID); err != nil {
+				return 0, false, err
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, false, fmt.Errorf("failed to prune warp messages: %w", err)
+	}
+	if err := indexBatch.Write(); err != nil {
+		return 0, false, fmt.Errorf("failed to prune warp message index: %w", err)
+	}
+	for i, messageID := range prunedIDs {
+		b.messageCache.Evict(messageID)
+		b.messageSignatureCache.Evict(messageID)
+		b.stats.removeMessage(prunedBytes[i])
+	}
+	return len(prunedIDs), done, nil
+}
+
+// pruneBlockHashBatch removes up to a batch of the signatures aggregated over block hash messages of blocks
+// before [cutoff], and returns whether all of them have been removed.
+func (b *backend) pruneBlockHashBatch(cutoff uint64) (bool, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		indexBatch = b.indexDB.NewBatch()
+		prunedIDs  = make([]ids.ID, 0)
+		done       = true
+	)
+	it := b.indexDB.NewIteratorWithPrefix(blockHashMessagePrefix)
+	defer it.Release()
+	for it.Next() {
+		if indexBatch.Size() >= batchSize {
+			done = false
+			break
+		}
+		key := it.Key()
+		if len(key) != len(blockHashMessagePrefix)+blockNumberLen+len(ids.Empty) {
+			return false, fmt.Errorf("unexpected warp block hash message index key length %d", len(key))
+		}
+		blockNumber := binary.BigEndian.Uint64(key[len(blockHashMessagePrefix):])
+		if blockNumber >= cutoff {
+			break
+		}
+		messageID, err := ids.ToID(key[len(blockHashMessagePrefix)+blockNumberLen:])
+		if err != nil {
+			return false, err
+		}
+		if err := indexBatch.Delete(key); err != nil {
+			return false, err
+		}
+		if err := indexBatch.Delete(blockTimeKey(blockNumber)); err != nil {
+			return false, err
+		}
+		prunedIDs = append(prunedIDs, messageID)
+	}
+	if err := it.Error(); err != nil {
+		return false, fmt.Errorf("failed to iterate warp block hash message index: %w", err)
+	}
+
+	// Remove the signatures first, so that a failure leaves them to be pruned again.
+	if b.signatureStore != nil {
+		for _, messageID := range prunedIDs {
+			if err := b.signatureStore.DeleteSignatures(messageID); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := indexBatch.Write(); err != nil {
+		return false, fmt.Errorf("failed to prune warp block hash message index: %w", err)
+	}
+	return done, nil
+}
@@ -0,0 +1,235 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/warp/aggregator"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	signatureStore := aggregator.NewSignatureStore(memdb.New())
+	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, nil, memdb.New(), signatureStore, 500)
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	start := time.Unix(1_000_000, 0)
+	sender := ethcommon.HexToAddress("0x0100000000000000000000000000000000000001")
+	addMessage := func(blockNumber uint64, data string) *avalancheWarp.UnsignedMessage {
+		addressedCall, err := payload.NewAddressedCall(sender.Bytes(), []byte(data))
+		require.NoError(err)
+		unsignedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+		require.NoError(err)
+		blockTimestamp := uint64(start.Add(time.Duration(blockNumber) * time.Minute).Unix())
+		require.NoError(backend.AddMessage(unsignedMsg, blockNumber, blockTimestamp))
+		return unsignedMsg
+	}
+	requireMessages := func(expected ...*avalancheWarp.UnsignedMessage) {
+		messages, err := backend.GetMessagesInRange(0, 100)
+		require.NoError(err)
+		require.Len(messages, len(expected))
+		for i, message := range messages {
+			require.Equal(expected[i].ID(), message.Message.ID())
+		}
+		messages, err = backend.GetMessagesBySender(sender, 0, 100)
+		require.NoError(err)
+		require.Len(messages, len(expected))
+	}
+
+	msg1 := addMessage(1, "1")
+	msg2 := addMessage(2, "2")
+	msg3 := addMessage(3, "3")
+	msg4 := addMessage(4, "4")
+	// The message of block 1 is accepted again in block 5, so must outlive block 1
+	addMessage(5, "1")
+	msg6 := addMessage(6, "6")
+	require.Equal(int64(5), backend.stats.messages.Snapshot().Value())
+
+	// Store an aggregated signature of a message that is pruned
+	signature, err := warpSigner.Sign(msg2)
+	require.NoError(err)
+	blsSignature, err := bls.SignatureFromBytes(signature)
+	require.NoError(err)
+	require.NoError(signatureStore.PutSignature(msg2.ID(), bls.PublicFromSecretKey(sk), blsSignature))
+
+	// Nothing is pruned without retention limits or within them
+	pruned, err := backend.Prune(RetentionPolicy{}, 6)
+	require.NoError(err)
+	require.Zero(pruned)
+	pruned, err = backend.Prune(RetentionPolicy{MaxBlockDepth: 6}, 6)
+	require.NoError(err)
+	require.Zero(pruned)
+
+	// Keep the last 4 blocks
+	pruned, err = backend.Prune(RetentionPolicy{MaxBlockDepth: 4}, 6)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	requireMessages(msg3, msg4, msg1, msg6)
+	_, err = backend.GetMessage(msg2.ID())
+	require.Error(err)
+	_, err = backend.GetMessageSignature(msg2.ID())
+	require.Error(err)
+	_, ok = signatureStore.GetSignature(msg2.ID(), bls.PublicFromSecretKey(sk))
+	require.False(ok)
+	_, err = backend.GetMessage(msg1.ID())
+	require.NoError(err)
+
+	// Keep the messages of blocks produced in the last 90 seconds
+	backend.clock.Set(start.Add(6*time.Minute + 30*time.Second))
+	pruned, err = backend.Prune(RetentionPolicy{MaxAge: 90 * time.Second}, 6)
+	require.NoError(err)
+	require.Equal(2, pruned)
+	requireMessages(msg1, msg6)
+
+	// The message re-added in block 5 is pruned with block 5
+	pruned, err = backend.Prune(RetentionPolicy{MaxBlockDepth: 1}, 6)
+	require.NoError(err)
+	require.Equal(1, pruned)
+	requireMessages(msg6)
+	_, err = backend.GetMessage(msg1.ID())
+	require.Error(err)
+	require.Equal(int64(1), backend.stats.messages.Snapshot().Value())
+	require.Equal(int64(len(msg6.Bytes())), backend.stats.messageBytes.Snapshot().Value())
+}
+
+func TestPruneBlockHashSignatures(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000_000, 0)
+	acceptedBlkID := ids.GenerateTestID()
+	processingBlkID := ids.GenerateTestID()
+	testVM := &block.TestVM{
+		TestVM: common.TestVM{T: t},
+		GetBlockF: func(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
+			status := choices.Accepted
+			switch blkID {
+			case acceptedBlkID:
+			case processingBlkID:
+				status = choices.Processing
+			default:
+				return nil, errors.New("invalid blockID")
+			}
+			return &snowman.TestBlock{
+				TestDecidable: choices.TestDecidable{
+					IDV:     blkID,
+					StatusV: status,
+				},
+				HeightV:    3,
+				TimestampV: start.Add(3 * time.Minute),
+			}, nil
+		},
+	}
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	signatureStore := aggregator.NewSignatureStore(memdb.New())
+	backendIntf := NewBackend(networkID, sourceChainID, warpSigner, testVM, memdb.New(), signatureStore, 500)
+	backend, ok := backendIntf.(*backend)
+	require.True(ok)
+
+	_, err = backend.AddBlockHashMessage(context.Background(), processingBlkID)
+	require.Error(err)
+
+	unsignedMsg, err := backend.AddBlockHashMessage(context.Background(), acceptedBlkID)
+	require.NoError(err)
+	blockHashPayload, err := payload.ParseHash(unsignedMsg.Payload)
+	require.NoError(err)
+	require.Equal(acceptedBlkID, blockHashPayload.Hash)
+
+	// Store an aggregated signature over the block hash message
+	signature, err := warpSigner.Sign(unsignedMsg)
+	require.NoError(err)
+	blsSignature, err := bls.SignatureFromBytes(signature)
+	require.NoError(err)
+	require.NoError(signatureStore.PutSignature(unsignedMsg.ID(), bls.PublicFromSecretKey(sk), blsSignature))
+
+	// The signature is kept while its block is within the retention limits
+	pruned, err := backend.Prune(RetentionPolicy{MaxBlockDepth: 4}, 6)
+	require.NoError(err)
+	require.Zero(pruned)
+	_, ok = signatureStore.GetSignature(unsignedMsg.ID(), bls.PublicFromSecretKey(sk))
+	require.True(ok)
+
+	// The signature is pruned with its block once the block is older than the retention limit
+	backend.clock.Set(start.Add(5 * time.Minute))
+	_, err = backend.Prune(RetentionPolicy{MaxAge: time.Minute}, 6)
+	require.NoError(err)
+	_, ok = signatureStore.GetSignature(unsignedMsg.ID(), bls.PublicFromSecretKey(sk))
+	require.False(ok)
+}
+
+func TestPruneBackfill(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	warpSigner := avalancheWarp.NewSigner(sk, networkID, sourceChainID)
+	db := memdb.New()
+	signatureStore := aggregator.NewSignatureStore(prefixdb.New([]byte("signatures"), db))
+
+	// Store a message and a block hash signature as they were stored before the message index was added
+	sender := ethcommon.HexToAddress("0x0100000000000000000000000000000000000001")
+	addressedCall, err := payload.NewAddressedCall(sender.Bytes(), []byte("unindexed"))
+	require.NoError(err)
+	unindexedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(err)
+	unindexedID := unindexedMsg.ID()
+	require.NoError(db.Put(unindexedID[:], unindexedMsg.Bytes()))
+	blockHashPayload, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(err)
+	blockHashMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, blockHashPayload.Bytes())
+	require.NoError(err)
+	signature, err := warpSigner.Sign(blockHashMsg)
+	require.NoError(err)
+	blsSignature, err := bls.SignatureFromBytes(signature)
+	require.NoError(err)
+	require.NoError(signatureStore.PutSignature(blockHashMsg.ID(), bls.PublicFromSecretKey(sk), blsSignature))
+
+	backend := NewBackend(networkID, sourceChainID, warpSigner, nil, db, signatureStore, 500)
+	indexedMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, testPayload)
+	require.NoError(err)
+	require.NoError(backend.AddMessage(indexedMsg, 8, 0))
+
+	// The first pruning indexes the stored messages and signatures in the last accepted block
+	pruned, err := backend.Prune(RetentionPolicy{MaxBlockDepth: 5}, 10)
+	require.NoError(err)
+	require.Zero(pruned)
+	messages, err := backend.GetMessagesInRange(10, 10)
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(unindexedID, messages[0].Message.ID())
+	require.Equal(sender, messages[0].SourceAddress)
+	_, ok := signatureStore.GetSignature(blockHashMsg.ID(), bls.PublicFromSecretKey(sk))
+	require.True(ok)
+
+	// Once they are outside of the retention limits, they are pruned like indexed messages
+	pruned, err = backend.Prune(RetentionPolicy{MaxBlockDepth: 5}, 15)
+	require.NoError(err)
+	require.Equal(2, pruned)
+	_, err = backend.GetMessage(unindexedID)
+	require.Error(err)
+	_, ok = signatureStore.GetSignature(blockHashMsg.ID(), bls.PublicFromSecretKey(sk))
+	require.False(ok)
+}
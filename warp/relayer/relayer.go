@@ -0,0 +1,542 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// DefaultGasLimit is the gas limit of relay transactions if none is configured.
+	// It covers the predicate verification of a message signed by a large validator set
+	// and leaves room for the destination contract to process the message.
+	DefaultGasLimit uint64 = 1_000_000
+	// DefaultMaxAttempts is the number of times a message is attempted before it is dropped if none
+	// is configured.
+	DefaultMaxAttempts uint64 = 5
+
+	initialRetryDelay  = 500 * time.Millisecond
+	maxRetryDelay      = 30 * time.Second
+	retryBackoffFactor = 2
+
+	receiptPollInterval = time.Second
+	receiptTimeout      = time.Minute
+
+	// replacementFeeBump is the percentage by which the fees of a replacement relay transaction
+	// exceed those of the transaction it replaces, matching the default price bump of the mempool.
+	replacementFeeBump = 10
+)
+
+var (
+	_ precompileconfig.WarpMessageWriter = (*Relayer)(nil)
+
+	// ReceiveWarpMessageSelector is the selector of receiveWarpMessage(uint32) called on the destination
+	// contract by every relay transaction. The argument is the index of the message in the predicates
+	// of the transaction, which is always 0 for relay transactions.
+	ReceiveWarpMessageSelector = contract.CalculateFunctionSelector("receiveWarpMessage(uint32)")
+
+	errNoDestinationAddress = errors.New("missing warp relayer destination address")
+	errNoPrivateKey         = errors.New("missing warp relayer private key")
+	errTxReverted           = errors.New("relay transaction reverted")
+
+	// errMessageFailed marks failures to aggregate, send or execute the relay transaction of a
+	// message. Only these count towards [Config.MaxAttempts], so that failing to query fees and
+	// nonces from an unreachable destination chain does not drop messages.
+	errMessageFailed = errors.New("warp message failed to relay")
+
+	// Relay progress is tracked as two counters: every accepted message is stored under the next
+	// accepted index, and messages are relayed in order until the relayed index catches up.
+	// Messages below the relayed index are deleted the next time a message is queued.
+	acceptedIndexKey = []byte("acceptedIndex")
+	prunedIndexKey   = []byte("prunedIndex")
+	relayedIndexKey  = []byte("relayedIndex")
+	messagePrefix    = []byte("message")
+)
+
+// Aggregator aggregates validator signatures over an unsigned warp message until the
+// signature weight reaches [quorumNum].
+type Aggregator func(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64) (*avalancheWarp.Message, error)
+
+// DestinationClient is the subset of the ethclient API used to submit relay transactions
+// to the destination chain.
+type DestinationClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	AcceptedNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateBaseFee(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Config configures which messages are relayed and how relay transactions are issued.
+type Config struct {
+	// DestinationAddress is the contract called with receiveWarpMessage(0) by every relay transaction.
+	DestinationAddress common.Address
+	// PrivateKey signs relay transactions and must be funded on the destination chain.
+	PrivateKey *ecdsa.PrivateKey
+	// SourceAddresses restricts relaying to messages sent by these addresses. All messages
+	// are relayed if it is empty.
+	SourceAddresses []common.Address
+	// QuorumNumerator is the quorum the aggregate signature must reach.
+	QuorumNumerator uint64
+	// GasLimit is the gas limit of relay transactions. Defaults to [DefaultGasLimit].
+	GasLimit uint64
+	// MaxAttempts is the number of times a message is attempted before it is dropped so that it
+	// does not block the messages queued after it. Defaults to [DefaultMaxAttempts].
+	MaxAttempts uint64
+}
+
+// Relayer tracks warp messages accepted on this chain and delivers them, signed by the
+// validators of this chain, to a contract on a destination chain. Messages are persisted
+// in [db] when accepted and relayed in order, so relaying resumes where it left off after
+// a restart.
+//
+// [db] is only written while blocks are accepted, so that it can be committed atomically with
+// the accepted block. The relay loop records its progress in [progressDB] instead.
+type Relayer struct {
+	config          Config
+	sender          common.Address
+	sourceAddresses set.Set[common.Address]
+
+	writer    precompileconfig.WarpMessageWriter
+	aggregate Aggregator
+	client    DestinationClient
+
+	// lock guards [db] and [progressDB] updates against the relay loop
+	lock       sync.Mutex
+	db         database.Database
+	progressDB database.Database
+
+	// chainID, nonce, attempts, replaceTx and sentTxHashes are only accessed by the relay loop
+	chainID *big.Int
+	nonce   *uint64
+	// attempts is the number of failed attempts to relay the message at the relayed index
+	attempts uint64
+	// replaceTx is the last relay transaction that was sent but not found on the destination chain.
+	// The next relay transaction reuses its nonce with bumped fees, so that it replaces it in the
+	// mempool of the destination chain instead of being rejected.
+	replaceTx *types.Transaction
+	// sentTxHashes are the hashes of the relay transactions sent for the message at the relayed
+	// index. Any of them may still be included, so they are all checked before sending another one.
+	sentTxHashes []common.Hash
+
+	notify chan struct{}
+}
+
+// New returns a Relayer that adds accepted messages to [writer] before queueing them for relay
+// in [db], and records relay progress in [progressDB].
+func New(config Config, writer precompileconfig.WarpMessageWriter, aggregate Aggregator, client DestinationClient, db database.Database, progressDB database.Database) (*Relayer, error) {
+	if config.DestinationAddress == (common.Address{}) {
+		return nil, errNoDestinationAddress
+	}
+	if config.PrivateKey == nil {
+		return nil, errNoPrivateKey
+	}
+	if config.GasLimit == 0 {
+		config.GasLimit = DefaultGasLimit
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Relayer{
+		config:          config,
+		sender:          crypto.PubkeyToAddress(config.PrivateKey.PublicKey),
+		sourceAddresses: set.Of(config.SourceAddresses...),
+		writer:          writer,
+		aggregate:       aggregate,
+		client:          client,
+		db:              db,
+		progressDB:      progressDB,
+		notify:          make(chan struct{}, 1),
+	}, nil
+}
+
+// AddMessage adds [unsignedMessage] to the underlying writer and queues it for relay
+// if it was sent by one of the configured source addresses.
+func (r *Relayer) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage, blockNumber uint64, blockTimestamp uint64) error {
+	if err := r.writer.AddMessage(unsignedMessage, blockNumber, blockTimestamp); err != nil {
+		return err
+	}
+	if !r.shouldRelay(unsignedMessage) {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	acceptedIndex, err := getIndex(r.db, acceptedIndexKey)
+	if err != nil {
+		return err
+	}
+	prunedIndex, err := getIndex(r.db, prunedIndexKey)
+	if err != nil {
+		return err
+	}
+	relayedIndex, err := getIndex(r.progressDB, relayedIndexKey)
+	if err != nil {
+		return err
+	}
+	batch := r.db.NewBatch()
+	for ; prunedIndex < relayedIndex && prunedIndex < acceptedIndex; prunedIndex++ {
+		if err := batch.Delete(messageKey(prunedIndex)); err != nil {
+			return fmt.Errorf("failed to delete relayed warp message %d: %w", prunedIndex, err)
+		}
+	}
+	if err := database.PutUInt64(batch, prunedIndexKey, prunedIndex); err != nil {
+		return fmt.Errorf("failed to queue warp message %s for relay: %w", unsignedMessage.ID(), err)
+	}
+	if err := batch.Put(messageKey(acceptedIndex), unsignedMessage.Bytes()); err != nil {
+		return fmt.Errorf("failed to queue warp message %s for relay: %w", unsignedMessage.ID(), err)
+	}
+	if err := database.PutUInt64(batch, acceptedIndexKey, acceptedIndex+1); err != nil {
+		return fmt.Errorf("failed to queue warp message %s for relay: %w", unsignedMessage.ID(), err)
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to queue warp message %s for relay: %w", unsignedMessage.ID(), err)
+	}
+	log.Debug("Queued warp message for relay", "messageID", unsignedMessage.ID(), "index", acceptedIndex)
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Pending returns the number of queued messages that have not been relayed yet.
+func (r *Relayer) Pending() (uint64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	acceptedIndex, err := getIndex(r.db, acceptedIndexKey)
+	if err != nil {
+		return 0, err
+	}
+	relayedIndex, err := getIndex(r.progressDB, relayedIndexKey)
+	if err != nil {
+		return 0, err
+	}
+	if relayedIndex >= acceptedIndex {
+		return 0, nil
+	}
+	return acceptedIndex - relayedIndex, nil
+}
+
+// Start relays queued messages, including those queued before a restart, until [ctx] is cancelled.
+// A message that fails to relay is retried with exponential backoff, and later messages wait for it
+// so that messages are delivered in the order they were accepted. A message that fails
+// [Config.MaxAttempts] times is dropped so that it does not block the queue.
+func (r *Relayer) Start(ctx context.Context) {
+	delay := initialRetryDelay
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.notify:
+		case <-timer.C:
+		}
+
+		if err := r.relayPending(ctx); err != nil {
+			log.Warn("Failed to relay warp message", "err", err, "retryDelay", delay)
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(delay)
+			delay *= retryBackoffFactor
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+			continue
+		}
+		delay = initialRetryDelay
+	}
+}
+
+// relayPending relays all queued messages in order, returning on the first failure. A message is
+// only marked as relayed once its relay transaction succeeded on the destination chain.
+func (r *Relayer) relayPending(ctx context.Context) error {
+	for {
+		r.lock.Lock()
+		relayedIndex, err := getIndex(r.progressDB, relayedIndexKey)
+		if err != nil {
+			r.lock.Unlock()
+			return err
+		}
+		acceptedIndex, err := getIndex(r.db, acceptedIndexKey)
+		r.lock.Unlock()
+		if err != nil {
+			return err
+		}
+		if relayedIndex >= acceptedIndex {
+			return nil
+		}
+
+		messageBytes, err := r.db.Get(messageKey(relayedIndex))
+		if err != nil {
+			return fmt.Errorf("failed to get queued warp message %d: %w", relayedIndex, err)
+		}
+		unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(messageBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse queued warp message %d: %w", relayedIndex, err)
+		}
+		txHash, err := r.relay(ctx, unsignedMessage)
+		switch {
+		case err == nil:
+			log.Info("Relayed warp message", "messageID", unsignedMessage.ID(), "txHash", txHash)
+		case errors.Is(err, errMessageFailed) && r.attempts+1 >= r.config.MaxAttempts:
+			log.Error("Dropping warp message after repeated relay failures", "messageID", unsignedMessage.ID(), "attempts", r.attempts+1, "err", err)
+			// A relay transaction of the dropped message that is still pending is replaced by the
+			// relay transaction of the next message.
+			r.sentTxHashes = nil
+		case errors.Is(err, errMessageFailed):
+			r.attempts++
+			return fmt.Errorf("failed to relay warp message %s (attempt %d): %w", unsignedMessage.ID(), r.attempts, err)
+		default:
+			return fmt.Errorf("failed to relay warp message %s: %w", unsignedMessage.ID(), err)
+		}
+
+		r.attempts = 0
+		r.lock.Lock()
+		err = database.PutUInt64(r.progressDB, relayedIndexKey, relayedIndex+1)
+		r.lock.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to record relay of warp message %s: %w", unsignedMessage.ID(), err)
+		}
+	}
+}
+
+// relay aggregates the signatures over [unsignedMessage], submits the signed message to the
+// destination chain as the predicate of a transaction calling the destination contract and
+// waits for the transaction to succeed. If a relay transaction sent for [unsignedMessage] by a
+// previous attempt was included in the meantime, its result is returned instead of sending the
+// message again. Failures after the fees and nonce were fetched wrap [errMessageFailed].
+func (r *Relayer) relay(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage) (common.Hash, error) {
+	if r.replaceTx != nil {
+		receipt, err := r.checkReplaceTx(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if receipt != nil {
+			return r.handleReceipt(receipt)
+		}
+	}
+
+	signedMessage, err := r.aggregate(ctx, unsignedMessage, r.config.QuorumNumerator)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to aggregate signatures: %w", errMessageFailed, err)
+	}
+
+	if r.chainID == nil {
+		chainID, err := r.client.ChainID(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch destination chain ID: %w", err)
+		}
+		r.chainID = chainID
+	}
+	if r.replaceTx == nil && r.nonce == nil {
+		nonce, err := r.client.AcceptedNonceAt(ctx, r.sender)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch relayer nonce: %w", err)
+		}
+		r.nonce = &nonce
+	}
+	gasTipCap, err := r.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+	}
+	baseFee, err := r.client.EstimateBaseFee(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate base fee: %w", err)
+	}
+	var nonce uint64
+	if r.replaceTx != nil {
+		nonce = r.replaceTx.Nonce()
+		gasTipCap = maxBig(gasTipCap, bumpFee(r.replaceTx.GasTipCap()))
+	} else {
+		nonce = *r.nonce
+	}
+	// Allow the base fee to double before the transaction is included
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, common.Big2), gasTipCap)
+	if r.replaceTx != nil {
+		gasFeeCap = maxBig(gasFeeCap, bumpFee(r.replaceTx.GasFeeCap()))
+	}
+
+	data := make([]byte, 0, contract.SelectorLen+common.HashLength)
+	data = append(data, ReceiveWarpMessageSelector...)
+	data = append(data, common.Hash{}.Bytes()...)
+	tx := predicate.NewPredicateTx(
+		r.chainID,
+		nonce,
+		&r.config.DestinationAddress,
+		r.config.GasLimit,
+		gasFeeCap,
+		gasTipCap,
+		common.Big0,
+		data,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage.Bytes(),
+	)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(r.chainID), r.config.PrivateKey)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign relay transaction: %w", err)
+	}
+	if err := r.client.SendTransaction(ctx, signedTx); err != nil {
+		if r.replaceTx == nil {
+			// Refetch the nonce on the next attempt in case it is out of sync with the destination chain
+			r.nonce = nil
+		}
+		return common.Hash{}, fmt.Errorf("%w: failed to send relay transaction: %w", errMessageFailed, err)
+	}
+	nextNonce := nonce + 1
+	r.nonce = &nextNonce
+	r.replaceTx = signedTx
+	r.sentTxHashes = append(r.sentTxHashes, signedTx.Hash())
+
+	receipt, err := r.waitForReceipt(ctx, r.sentTxHashes)
+	if err != nil {
+		// The transaction may still be included, so it is checked and replaced on the next attempt
+		return common.Hash{}, fmt.Errorf("%w: relay transaction %s did not succeed: %w", errMessageFailed, signedTx.Hash(), err)
+	}
+	return r.handleReceipt(receipt)
+}
+
+// checkReplaceTx returns the receipt of the relay transaction sent for the message at the relayed
+// index that was included, if any. [replaceTx] is cleared if its nonce was used by a transaction that
+// was not sent for this message, so that the next relay transaction uses the next nonce instead.
+func (r *Relayer) checkReplaceTx(ctx context.Context) (*types.Receipt, error) {
+	// Fetch the nonce first, so that a transaction included after the receipts were checked is not
+	// mistaken for a transaction that was not sent for this message.
+	acceptedNonce, err := r.client.AcceptedNonceAt(ctx, r.sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch relayer nonce: %w", err)
+	}
+	receipt, err := r.findReceipt(ctx, r.sentTxHashes)
+	if err != nil || receipt != nil {
+		return receipt, err
+	}
+	if acceptedNonce > r.replaceTx.Nonce() {
+		r.replaceTx = nil
+		r.nonce = &acceptedNonce
+	}
+	return nil, nil
+}
+
+// handleReceipt clears the relay transactions of the message at the relayed index once one of
+// them was included, and returns its hash if it succeeded.
+func (r *Relayer) handleReceipt(receipt *types.Receipt) (common.Hash, error) {
+	r.replaceTx = nil
+	r.sentTxHashes = nil
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Hash{}, fmt.Errorf("%w: relay transaction %s did not succeed: %w", errMessageFailed, receipt.TxHash, errTxReverted)
+	}
+	return receipt.TxHash, nil
+}
+
+// findReceipt returns the receipt of the first of [txHashes] included on the destination chain,
+// or nil if none of them was found.
+func (r *Relayer) findReceipt(ctx context.Context, txHashes []common.Hash) (*types.Receipt, error) {
+	for _, txHash := range txHashes {
+		receipt, err := r.client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, interfaces.NotFound) {
+			return nil, fmt.Errorf("failed to fetch receipt of relay transaction %s: %w", txHash, err)
+		}
+	}
+	return nil, nil
+}
+
+// waitForReceipt polls the destination chain for the receipt of any of [txHashes] until one is
+// found or [receiptTimeout] elapses.
+func (r *Relayer) waitForReceipt(ctx context.Context, txHashes []common.Hash) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, receiptTimeout)
+	defer cancel()
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := r.findReceipt(ctx, txHashes)
+		if err != nil || receipt != nil {
+			return receipt, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// shouldRelay returns true if [unsignedMessage] is an addressed call sent by one of the
+// configured source addresses, or by any address if none are configured.
+func (r *Relayer) shouldRelay(unsignedMessage *avalancheWarp.UnsignedMessage) bool {
+	addressedCall, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return false
+	}
+	if r.sourceAddresses.Len() == 0 {
+		return true
+	}
+	return r.sourceAddresses.Contains(common.BytesToAddress(addressedCall.SourceAddress))
+}
+
+// bumpFee returns [fee] increased by [replacementFeeBump] percent, rounded up so that even the
+// smallest fees strictly increase.
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBump))
+	bumped.Div(bumped, big.NewInt(100))
+	return bumped.Add(bumped, common.Big1)
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func getIndex(db database.KeyValueReader, key []byte) (uint64, error) {
+	index, err := database.GetUInt64(db, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get warp relayer progress: %w", err)
+	}
+	return index, nil
+}
+
+func messageKey(index uint64) []byte {
+	key := make([]byte, len(messagePrefix)+8)
+	copy(key, messagePrefix)
+	binary.BigEndian.PutUint64(key[len(messagePrefix):], index)
+	return key
+}
@@ -0,0 +1,412 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	networkID          uint32 = 54321
+	sourceChainID             = ids.GenerateTestID()
+	sourceAddress             = common.HexToAddress("0x0100000000000000000000000000000000000001")
+	destinationAddress        = common.HexToAddress("0x0200000000000000000000000000000000000002")
+	errSendFailed             = errors.New("send failed")
+	errAggregateFailed        = errors.New("aggregate failed")
+)
+
+type testWriter struct {
+	messages []*avalancheWarp.UnsignedMessage
+}
+
+func (w *testWriter) AddMessage(unsignedMessage *avalancheWarp.UnsignedMessage, _ uint64, _ uint64) error {
+	w.messages = append(w.messages, unsignedMessage)
+	return nil
+}
+
+type testClient struct {
+	lock     sync.Mutex
+	nonce    uint64
+	sendErr  error
+	reverted bool
+	unmined  bool
+	txs      []*types.Transaction
+}
+
+func (c *testClient) ChainID(context.Context) (*big.Int, error) { return big.NewInt(1), nil }
+
+func (c *testClient) AcceptedNonceAt(context.Context, common.Address) (uint64, error) {
+	return c.nonce, nil
+}
+
+func (c *testClient) SuggestGasTipCap(context.Context) (*big.Int, error) { return big.NewInt(1), nil }
+
+func (c *testClient) EstimateBaseFee(context.Context) (*big.Int, error) { return big.NewInt(10), nil }
+
+func (c *testClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	c.txs = append(c.txs, tx)
+	return nil
+}
+
+func (c *testClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.unmined {
+		return nil, interfaces.NotFound
+	}
+	for _, tx := range c.txs {
+		if tx.Hash() != txHash {
+			continue
+		}
+		status := types.ReceiptStatusSuccessful
+		if c.reverted {
+			status = types.ReceiptStatusFailed
+		}
+		return &types.Receipt{Status: status, TxHash: txHash}, nil
+	}
+	return nil, interfaces.NotFound
+}
+
+func (c *testClient) setReverted(reverted bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.reverted = reverted
+}
+
+func (c *testClient) setUnmined(unmined bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.unmined = unmined
+}
+
+func (c *testClient) sent() []*types.Transaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]*types.Transaction(nil), c.txs...)
+}
+
+func testAggregate(_ context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, _ uint64) (*avalancheWarp.Message, error) {
+	return avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+}
+
+func newTestRelayer(t *testing.T, writer *testWriter, client *testClient, sourceAddresses ...common.Address) (*Relayer, *memdb.Database, *memdb.Database) {
+	db, progressDB := memdb.New(), memdb.New()
+	return newTestRelayerWithDB(t, writer, client, db, progressDB, sourceAddresses...), db, progressDB
+}
+
+func newTestRelayerWithDB(t *testing.T, writer *testWriter, client *testClient, db *memdb.Database, progressDB *memdb.Database, sourceAddresses ...common.Address) *Relayer {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer, err := New(Config{
+		DestinationAddress: destinationAddress,
+		PrivateKey:         key,
+		SourceAddresses:    sourceAddresses,
+		QuorumNumerator:    67,
+	}, writer, testAggregate, client, db, progressDB)
+	require.NoError(t, err)
+	return relayer
+}
+
+func newAddressedCallMessage(t *testing.T, source common.Address, data []byte) *avalancheWarp.UnsignedMessage {
+	addressedCall, err := payload.NewAddressedCall(source.Bytes(), data)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	return unsignedMessage
+}
+
+func TestNewRelayerRequiresConfig(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	_, err = New(Config{PrivateKey: key}, &testWriter{}, testAggregate, &testClient{}, memdb.New(), memdb.New())
+	require.ErrorIs(t, err, errNoDestinationAddress)
+
+	_, err = New(Config{DestinationAddress: destinationAddress}, &testWriter{}, testAggregate, &testClient{}, memdb.New(), memdb.New())
+	require.ErrorIs(t, err, errNoPrivateKey)
+}
+
+func TestRelayerRelaysAcceptedMessages(t *testing.T) {
+	require := require.New(t)
+
+	writer := &testWriter{}
+	client := &testClient{nonce: 5}
+	relayer, _, _ := newTestRelayer(t, writer, client)
+
+	messages := []*avalancheWarp.UnsignedMessage{
+		newAddressedCallMessage(t, sourceAddress, []byte("first")),
+		newAddressedCallMessage(t, sourceAddress, []byte("second")),
+	}
+	for _, message := range messages {
+		require.NoError(relayer.AddMessage(message, 0, 0))
+	}
+	require.Equal(messages, writer.messages)
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Equal(uint64(2), pending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relayer.Start(ctx)
+		close(done)
+	}()
+	require.Eventually(func() bool { return len(client.sent()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	pending, err = relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+
+	for i, tx := range client.sent() {
+		require.Equal(uint64(5+i), tx.Nonce())
+		require.Equal(destinationAddress, *tx.To())
+		require.Equal(DefaultGasLimit, tx.Gas())
+		require.Equal(big.NewInt(21), tx.GasFeeCap())
+		require.Equal(append(ReceiveWarpMessageSelector, common.Hash{}.Bytes()...), tx.Data())
+
+		accessList := tx.AccessList()
+		require.Len(accessList, 1)
+		require.Equal(warp.ContractAddress, accessList[0].Address)
+		predicateBytes, err := predicate.UnpackPredicate(utils.HashSliceToBytes(accessList[0].StorageKeys))
+		require.NoError(err)
+		signedMessage, err := avalancheWarp.ParseMessage(predicateBytes)
+		require.NoError(err)
+		require.Equal(messages[i].ID(), signedMessage.UnsignedMessage.ID())
+	}
+}
+
+func TestRelayerFiltersMessages(t *testing.T) {
+	require := require.New(t)
+
+	writer := &testWriter{}
+	relayer, _, _ := newTestRelayer(t, writer, &testClient{}, sourceAddress)
+
+	hashPayload, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(err)
+	blockHashMessage, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, hashPayload.Bytes())
+	require.NoError(err)
+
+	// Messages from other source addresses and block hash messages are added but not relayed
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, destinationAddress, []byte("other")), 0, 0))
+	require.NoError(relayer.AddMessage(blockHashMessage, 0, 0))
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("relayed")), 0, 0))
+	pending, err = relayer.Pending()
+	require.NoError(err)
+	require.Equal(uint64(1), pending)
+	require.Len(writer.messages, 3)
+}
+
+func TestRelayerResumesAfterRestart(t *testing.T) {
+	require := require.New(t)
+
+	failingClient := &testClient{sendErr: errSendFailed}
+	relayer, db, progressDB := newTestRelayer(t, &testWriter{}, failingClient)
+	first := newAddressedCallMessage(t, sourceAddress, []byte("first"))
+	second := newAddressedCallMessage(t, sourceAddress, []byte("second"))
+	require.NoError(relayer.AddMessage(first, 0, 0))
+	require.NoError(relayer.AddMessage(second, 0, 0))
+
+	err := relayer.relayPending(context.Background())
+	require.ErrorIs(err, errSendFailed)
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Equal(uint64(2), pending)
+
+	// A relayer restarted on the same database relays the queued messages in order
+	client := &testClient{}
+	restarted := newTestRelayerWithDB(t, &testWriter{}, client, db, progressDB)
+	require.NoError(restarted.relayPending(context.Background()))
+	pending, err = restarted.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+
+	txs := client.sent()
+	require.Len(txs, 2)
+	for i, message := range []*avalancheWarp.UnsignedMessage{first, second} {
+		predicateBytes, err := predicate.UnpackPredicate(utils.HashSliceToBytes(txs[i].AccessList()[0].StorageKeys))
+		require.NoError(err)
+		signedMessage, err := avalancheWarp.ParseMessage(predicateBytes)
+		require.NoError(err)
+		require.Equal(message.ID(), signedMessage.UnsignedMessage.ID())
+	}
+
+	// Relaying again is a no-op once all messages were relayed
+	require.NoError(restarted.relayPending(context.Background()))
+	require.Len(client.sent(), 2)
+
+	// Relayed messages are deleted when the next message is queued
+	require.NoError(restarted.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("third")), 0, 0))
+	for i := uint64(0); i < 2; i++ {
+		has, err := db.Has(messageKey(i))
+		require.NoError(err)
+		require.False(has)
+	}
+	has, err := db.Has(messageKey(2))
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestRelayerWaitsForSuccessfulReceipt(t *testing.T) {
+	require := require.New(t)
+
+	client := &testClient{reverted: true}
+	relayer, _, _ := newTestRelayer(t, &testWriter{}, client)
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("message")), 0, 0))
+
+	// A reverted relay transaction does not mark the message as relayed
+	err := relayer.relayPending(context.Background())
+	require.ErrorIs(err, errTxReverted)
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Equal(uint64(1), pending)
+
+	client.setReverted(false)
+	require.NoError(relayer.relayPending(context.Background()))
+	pending, err = relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+	require.Len(client.sent(), 2)
+}
+
+func TestRelayerDropsFailingMessages(t *testing.T) {
+	require := require.New(t)
+
+	failing := newAddressedCallMessage(t, sourceAddress, []byte("failing"))
+	relayed := newAddressedCallMessage(t, sourceAddress, []byte("relayed"))
+	aggregate := func(ctx context.Context, unsignedMessage *avalancheWarp.UnsignedMessage, quorumNum uint64) (*avalancheWarp.Message, error) {
+		if unsignedMessage.ID() == failing.ID() {
+			return nil, errAggregateFailed
+		}
+		return testAggregate(ctx, unsignedMessage, quorumNum)
+	}
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	client := &testClient{}
+	relayer, err := New(Config{
+		DestinationAddress: destinationAddress,
+		PrivateKey:         key,
+		MaxAttempts:        2,
+	}, &testWriter{}, aggregate, client, memdb.New(), memdb.New())
+	require.NoError(err)
+	require.NoError(relayer.AddMessage(failing, 0, 0))
+	require.NoError(relayer.AddMessage(relayed, 0, 0))
+
+	err = relayer.relayPending(context.Background())
+	require.ErrorIs(err, errAggregateFailed)
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Equal(uint64(2), pending)
+	require.Empty(client.sent())
+
+	// The failing message is dropped on its last attempt so the next message is relayed
+	require.NoError(relayer.relayPending(context.Background()))
+	pending, err = relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+
+	txs := client.sent()
+	require.Len(txs, 1)
+	predicateBytes, err := predicate.UnpackPredicate(utils.HashSliceToBytes(txs[0].AccessList()[0].StorageKeys))
+	require.NoError(err)
+	signedMessage, err := avalancheWarp.ParseMessage(predicateBytes)
+	require.NoError(err)
+	require.Equal(relayed.ID(), signedMessage.UnsignedMessage.ID())
+}
+
+func TestRelayerChecksTimedOutTransaction(t *testing.T) {
+	require := require.New(t)
+
+	client := &testClient{nonce: 3, unmined: true}
+	relayer, _, _ := newTestRelayer(t, &testWriter{}, client)
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("message")), 0, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := relayer.relayPending(ctx)
+	require.ErrorIs(err, errMessageFailed)
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Len(client.sent(), 1)
+
+	// The transaction included after the receipt timeout relays the message without sending it again
+	client.setUnmined(false)
+	require.NoError(relayer.relayPending(context.Background()))
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+	require.Len(client.sent(), 1)
+}
+
+func TestRelayerReplacesTimedOutTransaction(t *testing.T) {
+	require := require.New(t)
+
+	client := &testClient{nonce: 3, unmined: true}
+	relayer, _, _ := newTestRelayer(t, &testWriter{}, client)
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("message")), 0, 0))
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		err := relayer.relayPending(ctx)
+		cancel()
+		require.ErrorIs(err, errMessageFailed)
+	}
+
+	// The replacement reuses the nonce of the timed out transaction with bumped fees
+	txs := client.sent()
+	require.Len(txs, 2)
+	require.Equal(uint64(3), txs[0].Nonce())
+	require.Equal(uint64(3), txs[1].Nonce())
+	require.Equal(big.NewInt(1), txs[0].GasTipCap())
+	require.Equal(big.NewInt(21), txs[0].GasFeeCap())
+	require.Equal(big.NewInt(2), txs[1].GasTipCap())
+	require.Equal(big.NewInt(24), txs[1].GasFeeCap())
+
+	client.setUnmined(false)
+	require.NoError(relayer.relayPending(context.Background()))
+	pending, err := relayer.Pending()
+	require.NoError(err)
+	require.Zero(pending)
+	require.Len(client.sent(), 2)
+
+	// The next message uses the next nonce
+	require.NoError(relayer.AddMessage(newAddressedCallMessage(t, sourceAddress, []byte("next")), 0, 0))
+	require.NoError(relayer.relayPending(context.Background()))
+	txs = client.sent()
+	require.Len(txs, 3)
+	require.Equal(uint64(4), txs[2].Nonce())
+	require.Equal(big.NewInt(21), txs[2].GasFeeCap())
+}
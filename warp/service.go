@@ -14,12 +14,26 @@ import (
 	"github.com/ava-labs/subnet-evm/peer"
 	"github.com/ava-labs/subnet-evm/warp/aggregator"
 	"github.com/ava-labs/subnet-evm/warp/validators"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// maxMessageQueryBlockRange is the maximum number of blocks a single message index query may span
+const maxMessageQueryBlockRange = 2048
+
 var errNoValidators = errors.New("cannot aggregate signatures from subnet with no validators")
 
+// MessageResult is a warp message sent on this chain as returned by the message index queries.
+type MessageResult struct {
+	MessageID     ids.ID         `json:"messageID"`
+	BlockNumber   hexutil.Uint64 `json:"blockNumber"`
+	SourceAddress common.Address `json:"sourceAddress"`
+	// Payload of the addressed call, or of the message if it is not an addressed call
+	Payload         hexutil.Bytes `json:"payload"`
+	UnsignedMessage hexutil.Bytes `json:"unsignedMessage"`
+}
+
 // API introduces snowman specific functionality to the evm
 type API struct {
 	networkID                     uint32
@@ -27,9 +41,10 @@ type API struct {
 	backend                       Backend
 	state                         *validators.State
 	client                        peer.NetworkClient
+	signatureStore                aggregator.SignatureStore
 }
 
-func NewAPI(networkID uint32, sourceSubnetID ids.ID, sourceChainID ids.ID, state *validators.State, backend Backend, client peer.NetworkClient) *API {
+func NewAPI(networkID uint32, sourceSubnetID ids.ID, sourceChainID ids.ID, state *validators.State, backend Backend, client peer.NetworkClient, signatureStore aggregator.SignatureStore) *API {
 	return &API{
 		networkID:      networkID,
 		sourceSubnetID: sourceSubnetID,
@@ -37,6 +52,7 @@ func NewAPI(networkID uint32, sourceSubnetID ids.ID, sourceChainID ids.ID, state
 		backend:        backend,
 		state:          state,
 		client:         client,
+		signatureStore: signatureStore,
 	}
 }
 
@@ -58,6 +74,44 @@ func (a *API) GetBlockSignature(ctx context.Context, blockID ids.ID) (hexutil.By
 	return signature[:], nil
 }
 
+// GetMessagesBySender returns the messages sent by [sender] in blocks [fromBlock, toBlock].
+func (a *API) GetMessagesBySender(ctx context.Context, sender common.Address, fromBlock hexutil.Uint64, toBlock hexutil.Uint64) ([]MessageResult, error) {
+	if err := verifyMessageQueryRange(uint64(fromBlock), uint64(toBlock)); err != nil {
+		return nil, err
+	}
+	messages, err := a.backend.GetMessagesBySender(sender, uint64(fromBlock), uint64(toBlock))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages sent by %s with error %w", sender, err)
+	}
+	return newMessageResults(messages), nil
+}
+
+// GetMessagesByDestination returns the messages addressed to the chain [destinationChainID] in blocks
+// [fromBlock, toBlock]. Only messages whose addressed call payload is a typed payload of the warp
+// precompile carry a destination chain ID, so other messages are never returned.
+func (a *API) GetMessagesByDestination(ctx context.Context, destinationChainID common.Hash, fromBlock hexutil.Uint64, toBlock hexutil.Uint64) ([]MessageResult, error) {
+	if err := verifyMessageQueryRange(uint64(fromBlock), uint64(toBlock)); err != nil {
+		return nil, err
+	}
+	messages, err := a.backend.GetMessagesByDestination(destinationChainID, uint64(fromBlock), uint64(toBlock))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages addressed to %s with error %w", destinationChainID, err)
+	}
+	return newMessageResults(messages), nil
+}
+
+// GetMessagesInRange returns the messages sent in blocks [fromBlock, toBlock].
+func (a *API) GetMessagesInRange(ctx context.Context, fromBlock hexutil.Uint64, toBlock hexutil.Uint64) ([]MessageResult, error) {
+	if err := verifyMessageQueryRange(uint64(fromBlock), uint64(toBlock)); err != nil {
+		return nil, err
+	}
+	messages, err := a.backend.GetMessagesInRange(uint64(fromBlock), uint64(toBlock))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages in blocks [%d, %d] with error %w", fromBlock, toBlock, err)
+	}
+	return newMessageResults(messages), nil
+}
+
 // GetMessageAggregateSignature fetches the aggregate signature for the requested [messageID]
 func (a *API) GetMessageAggregateSignature(ctx context.Context, messageID ids.ID, quorumNum uint64) (signedMessageBytes hexutil.Bytes, err error) {
 	unsignedMessage, err := a.backend.GetMessage(messageID)
@@ -69,43 +123,73 @@ func (a *API) GetMessageAggregateSignature(ctx context.Context, messageID ids.ID
 
 // GetBlockAggregateSignature fetches the aggregate signature for the requested [blockID]
 func (a *API) GetBlockAggregateSignature(ctx context.Context, blockID ids.ID, quorumNum uint64) (signedMessageBytes hexutil.Bytes, err error) {
-	blockHashPayload, err := payload.NewHash(blockID)
+	unsignedMessage, err := a.backend.AddBlockHashMessage(ctx, blockID)
 	if err != nil {
 		return nil, err
 	}
-	unsignedMessage, err := warp.NewUnsignedMessage(a.networkID, a.sourceChainID, blockHashPayload.Bytes())
+	return a.aggregateSignatures(ctx, unsignedMessage, quorumNum)
+}
+
+func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64) (hexutil.Bytes, error) {
+	signatureResult, err := AggregateSignatures(ctx, a.state, a.client, a.signatureStore, a.sourceSubnetID, unsignedMessage, quorumNum)
 	if err != nil {
 		return nil, err
 	}
-
-	return a.aggregateSignatures(ctx, unsignedMessage, quorumNum)
+	// TODO: return the signature and total weight as well to the caller for more complete details
+	// Need to decide on the best UI for this and write up documentation with the potential
+	// gotchas that could impact signed messages becoming invalid.
+	return hexutil.Bytes(signatureResult.Message.Bytes()), nil
 }
 
-func (a *API) aggregateSignatures(ctx context.Context, unsignedMessage *warp.UnsignedMessage, quorumNum uint64) (hexutil.Bytes, error) {
-	pChainHeight, err := a.state.GetCurrentHeight(ctx)
+// AggregateSignatures fetches signatures over [unsignedMessage] from the current validator set of
+// [subnetID] using [client] and aggregates them until [quorumNum] is reached. Signatures already
+// in [signatureStore] are reused instead of being fetched again.
+func AggregateSignatures(ctx context.Context, state *validators.State, client peer.NetworkClient, signatureStore aggregator.SignatureStore, subnetID ids.ID, unsignedMessage *warp.UnsignedMessage, quorumNum uint64) (*aggregator.AggregateSignatureResult, error) {
+	pChainHeight, err := state.GetCurrentHeight(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Debug("Fetching signature",
-		"a.subnetID", a.sourceSubnetID,
+		"subnetID", subnetID,
 		"height", pChainHeight,
 	)
-	validators, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.state, pChainHeight, a.sourceSubnetID)
+	validators, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, state, pChainHeight, subnetID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get validator set: %w", err)
 	}
 	if len(validators) == 0 {
-		return nil, fmt.Errorf("%w (SubnetID: %s, Height: %d)", errNoValidators, a.sourceSubnetID, pChainHeight)
+		return nil, fmt.Errorf("%w (SubnetID: %s, Height: %d)", errNoValidators, subnetID, pChainHeight)
 	}
 
-	agg := aggregator.New(aggregator.NewSignatureGetter(a.client), validators, totalWeight)
-	signatureResult, err := agg.AggregateSignatures(ctx, unsignedMessage, quorumNum)
-	if err != nil {
-		return nil, err
+	agg := aggregator.New(aggregator.NewSignatureGetter(client), validators, totalWeight, signatureStore)
+	return agg.AggregateSignatures(ctx, unsignedMessage, quorumNum)
+}
+
+func verifyMessageQueryRange(fromBlock uint64, toBlock uint64) error {
+	if fromBlock > toBlock {
+		return fmt.Errorf("invalid block range: from block %d is after to block %d", fromBlock, toBlock)
 	}
-	// TODO: return the signature and total weight as well to the caller for more complete details
-	// Need to decide on the best UI for this and write up documentation with the potential
-	// gotchas that could impact signed messages becoming invalid.
-	return hexutil.Bytes(signatureResult.Message.Bytes()), nil
+	if toBlock-fromBlock >= maxMessageQueryBlockRange {
+		return fmt.Errorf("block range [%d, %d] exceeds the maximum of %d blocks", fromBlock, toBlock, maxMessageQueryBlockRange)
+	}
+	return nil
+}
+
+func newMessageResults(messages []*IndexedMessage) []MessageResult {
+	results := make([]MessageResult, 0, len(messages))
+	for _, message := range messages {
+		messagePayload := message.Message.Payload
+		if addressedCall, err := payload.ParseAddressedCall(messagePayload); err == nil {
+			messagePayload = addressedCall.Payload
+		}
+		results = append(results, MessageResult{
+			MessageID:       message.Message.ID(),
+			BlockNumber:     hexutil.Uint64(message.BlockNumber),
+			SourceAddress:   message.SourceAddress,
+			Payload:         messagePayload,
+			UnsignedMessage: message.Message.Bytes(),
+		})
+	}
+	return results
 }
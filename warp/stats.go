@@ -0,0 +1,62 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/metrics"
+)
+
+// backendStats tracks the size of the warp backend database.
+type backendStats struct {
+	messages       metrics.Gauge
+	messageBytes   metrics.Gauge
+	prunedMessages metrics.Counter
+}
+
+func newBackendStats() *backendStats {
+	return &backendStats{
+		messages:       metrics.GetOrRegisterGauge("warp_backend_messages", nil),
+		messageBytes:   metrics.GetOrRegisterGauge("warp_backend_message_bytes", nil),
+		prunedMessages: metrics.GetOrRegisterCounter("warp_backend_pruned_messages", nil),
+	}
+}
+
+func (s *backendStats) addMessage(size int) {
+	s.messages.Inc(1)
+	s.messageBytes.Inc(int64(size))
+}
+
+func (s *backendStats) removeMessage(size int) {
+	s.messages.Dec(1)
+	s.messageBytes.Dec(int64(size))
+}
+
+func (s *backendStats) clear() {
+	s.messages.Update(0)
+	s.messageBytes.Update(0)
+}
+
+// initStats sets the database size metrics from the messages stored in the database.
+// Messages are the only entries of the backend database outside of a prefixDB, whose
+// keys are longer than a message ID.
+func (b *backend) initStats() error {
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	var messages, messageBytes int64
+	for it.Next() {
+		if len(it.Key()) != len(ids.Empty) {
+			continue
+		}
+		messages++
+		messageBytes += int64(len(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	b.stats.messages.Update(messages)
+	b.stats.messageBytes.Update(messageBytes)
+	return nil
+}
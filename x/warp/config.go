@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
@@ -27,14 +29,15 @@ var (
 )
 
 var (
-	errOverflowSignersGasCost  = errors.New("overflow calculating warp signers gas cost")
-	errInvalidPredicateBytes   = errors.New("cannot unpack predicate bytes")
-	errInvalidWarpMsg          = errors.New("cannot unpack warp message")
-	errInvalidWarpMsgPayload   = errors.New("cannot unpack warp message payload")
-	errInvalidAddressedPayload = errors.New("cannot unpack addressed payload")
-	errInvalidBlockHashPayload = errors.New("cannot unpack block hash payload")
-	errCannotGetNumSigners     = errors.New("cannot fetch num signers from warp message")
-	errWarpCannotBeActivated   = errors.New("warp cannot be activated before DUpgrade")
+	errOverflowSignersGasCost      = errors.New("overflow calculating warp signers gas cost")
+	errInvalidPredicateBytes       = errors.New("cannot unpack predicate bytes")
+	errInvalidWarpMsg              = errors.New("cannot unpack warp message")
+	errInvalidWarpMsgPayload       = errors.New("cannot unpack warp message payload")
+	errInvalidAddressedPayload     = errors.New("cannot unpack addressed payload")
+	errInvalidBlockHashPayload     = errors.New("cannot unpack block hash payload")
+	errCannotGetNumSigners         = errors.New("cannot fetch num signers from warp message")
+	errWarpCannotBeActivated       = errors.New("warp cannot be activated before DUpgrade")
+	errSourceChainAllowedAndDenied = errors.New("source chain cannot be both allowed and denied")
 )
 
 // Config implements the precompileconfig.Config interface and
@@ -42,6 +45,13 @@ var (
 type Config struct {
 	precompileconfig.Upgrade
 	QuorumNumerator uint64 `json:"quorumNumerator"`
+	// SourceChainQuorumNumerators overrides QuorumNumerator for warp messages from the given source chains.
+	SourceChainQuorumNumerators map[ids.ID]uint64 `json:"sourceChainQuorumNumerators,omitempty"`
+	// AllowedSourceChainIDs restricts verified warp messages to those from the given source chains.
+	// If empty, messages from any source chain not in DeniedSourceChainIDs are allowed.
+	AllowedSourceChainIDs set.Set[ids.ID] `json:"allowedSourceChainIDs,omitempty"`
+	// DeniedSourceChainIDs rejects warp messages from the given source chains.
+	DeniedSourceChainIDs set.Set[ids.ID] `json:"deniedSourceChainIDs,omitempty"`
 }
 
 // NewConfig returns a config for a network upgrade at [blockTimestamp] that enables
@@ -91,6 +101,19 @@ func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
 	if c.QuorumNumerator != 0 && c.QuorumNumerator < params.WarpQuorumNumeratorMinimum {
 		return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d)", c.QuorumNumerator, params.WarpQuorumNumeratorMinimum)
 	}
+	for sourceChainID, quorumNumerator := range c.SourceChainQuorumNumerators {
+		if quorumNumerator > params.WarpQuorumDenominator {
+			return fmt.Errorf("cannot specify quorum numerator (%d) > quorum denominator (%d) for source chain %s", quorumNumerator, params.WarpQuorumDenominator, sourceChainID)
+		}
+		if quorumNumerator < params.WarpQuorumNumeratorMinimum {
+			return fmt.Errorf("cannot specify quorum numerator (%d) < min quorum numerator (%d) for source chain %s", quorumNumerator, params.WarpQuorumNumeratorMinimum, sourceChainID)
+		}
+	}
+	for sourceChainID := range c.DeniedSourceChainIDs {
+		if c.AllowedSourceChainIDs.Contains(sourceChainID) {
+			return fmt.Errorf("%w: %s", errSourceChainAllowedAndDenied, sourceChainID)
+		}
+	}
 	return nil
 }
 
@@ -102,10 +125,22 @@ func (c *Config) Equal(s precompileconfig.Config) bool {
 		return false
 	}
 	equals := c.Upgrade.Equal(&other.Upgrade)
-	return equals && c.QuorumNumerator == other.QuorumNumerator
+	if !equals || c.QuorumNumerator != other.QuorumNumerator {
+		return false
+	}
+	if len(c.SourceChainQuorumNumerators) != len(other.SourceChainQuorumNumerators) {
+		return false
+	}
+	for sourceChainID, quorumNumerator := range c.SourceChainQuorumNumerators {
+		otherQuorumNumerator, ok := other.SourceChainQuorumNumerators[sourceChainID]
+		if !ok || quorumNumerator != otherQuorumNumerator {
+			return false
+		}
+	}
+	return c.AllowedSourceChainIDs.Equals(other.AllowedSourceChainIDs) && c.DeniedSourceChainIDs.Equals(other.DeniedSourceChainIDs)
 }
 
-func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash common.Hash, blockNumber uint64, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
+func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash common.Hash, blockNumber uint64, blockTimestamp uint64, txHash common.Hash, logIndex int, topics []common.Hash, logData []byte) error {
 	unsignedMessage, err := UnpackSendWarpEventDataToMessage(logData)
 	if err != nil {
 		return fmt.Errorf("failed to parse warp log data into unsigned message (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
@@ -119,30 +154,49 @@ func (c *Config) Accept(acceptCtx *precompileconfig.AcceptContext, blockHash com
 		"logData", common.Bytes2Hex(logData),
 		"warpMessageID", unsignedMessage.ID(),
 	)
-	if err := acceptCtx.Warp.AddMessage(unsignedMessage); err != nil {
+	if err := acceptCtx.Warp.AddMessage(unsignedMessage, blockNumber, blockTimestamp); err != nil {
 		return fmt.Errorf("failed to add warp message during accept (TxHash: %s, LogIndex: %d): %w", txHash, logIndex, err)
 	}
 	return nil
 }
 
-// verifyWarpMessage checks that [warpMsg] can be parsed as an addressed payload and verifies the Warp Message Signature
-// within [predicateContext].
-func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateContext, warpMsg *warp.Message) bool {
+// isSourceChainAllowed returns true if warp messages from [sourceChainID] may be verified.
+func (c *Config) isSourceChainAllowed(sourceChainID ids.ID) bool {
+	if c.DeniedSourceChainIDs.Contains(sourceChainID) {
+		return false
+	}
+	return c.AllowedSourceChainIDs.Len() == 0 || c.AllowedSourceChainIDs.Contains(sourceChainID)
+}
+
+// quorumNumerator returns the quorum numerator required for warp messages from [sourceChainID].
+func (c *Config) quorumNumerator(sourceChainID ids.ID) uint64 {
+	if quorumNumerator, ok := c.SourceChainQuorumNumerators[sourceChainID]; ok {
+		return quorumNumerator
+	}
 	// Use default quorum numerator unless config specifies a non-default option
-	quorumNumerator := params.WarpDefaultQuorumNumerator
 	if c.QuorumNumerator != 0 {
-		quorumNumerator = c.QuorumNumerator
+		return c.QuorumNumerator
+	}
+	return params.WarpDefaultQuorumNumerator
+}
+
+// verifyWarpMessage checks that [warpMsg] is from an allowed source chain and verifies the Warp Message Signature
+// within [predicateContext] against the quorum required for its source chain.
+func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateContext, warpMsg *warp.Message) bool {
+	if !c.isSourceChainAllowed(warpMsg.SourceChainID) {
+		log.Debug("rejecting warp message from disallowed source chain", "msgID", warpMsg.ID(), "sourceChainID", warpMsg.SourceChainID)
+		return false
 	}
+	quorumNumerator := c.quorumNumerator(warpMsg.SourceChainID)
 
 	log.Debug("verifying warp message", "warpMsg", warpMsg, "quorumNum", quorumNumerator, "quorumDenom", params.WarpQuorumDenominator)
-	if err := warpMsg.Signature.Verify(
+	if err := VerifyMessage(
 		context.Background(),
-		&warpMsg.UnsignedMessage,
+		warpMsg,
 		predicateContext.SnowCtx.NetworkID,
 		warpValidators.NewState(predicateContext.SnowCtx), // Wrap validators.State on the chain snow context to special case the Primary Network
 		predicateContext.ProposerVMBlockCtx.PChainHeight,
 		quorumNumerator,
-		params.WarpQuorumDenominator,
 	); err != nil {
 		log.Debug("failed to verify warp signature", "msgID", warpMsg.ID(), "err", err)
 		return false
@@ -151,6 +205,21 @@ func (c *Config) verifyWarpMessage(predicateContext *precompileconfig.PredicateC
 	return true
 }
 
+// VerifyMessage verifies that the signature of [warpMsg] is valid for [networkID] and signed by at least
+// [quorumNumerator] / [params.WarpQuorumDenominator] of the weight of the validators of its source subnet,
+// as given by [validatorState] at [pChainHeight].
+func VerifyMessage(ctx context.Context, warpMsg *warp.Message, networkID uint32, validatorState validators.State, pChainHeight uint64, quorumNumerator uint64) error {
+	return warpMsg.Signature.Verify(
+		ctx,
+		&warpMsg.UnsignedMessage,
+		networkID,
+		validatorState,
+		pChainHeight,
+		quorumNumerator,
+		params.WarpQuorumDenominator,
+	)
+}
+
 // PredicateGas returns the amount of gas necessary to verify the predicate
 // PredicateGas charges for:
 // 1. Base cost of the message
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
@@ -14,6 +16,8 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+var testSourceChainID = ids.GenerateTestID()
+
 func TestVerify(t *testing.T) {
 	tests := map[string]testutils.ConfigVerifyTest{
 		"quorum numerator less than minimum": {
@@ -42,6 +46,46 @@ func TestVerify(t *testing.T) {
 			}(),
 			ExpectedError: errWarpCannotBeActivated.Error(),
 		},
+		"source chain quorum numerator less than minimum": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumNumeratorMinimum - 1}
+				return config
+			}(),
+			ExpectedError: fmt.Sprintf("cannot specify quorum numerator (%d) < min quorum numerator (%d) for source chain %s", params.WarpQuorumNumeratorMinimum-1, params.WarpQuorumNumeratorMinimum, testSourceChainID),
+		},
+		"source chain quorum numerator greater than quorum denominator": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator + 1}
+				return config
+			}(),
+			ExpectedError: fmt.Sprintf("cannot specify quorum numerator (%d) > quorum denominator (%d) for source chain %s", params.WarpQuorumDenominator+1, params.WarpQuorumDenominator, testSourceChainID),
+		},
+		"valid source chain quorum numerator": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator}
+				return config
+			}(),
+		},
+		"source chain both allowed and denied": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.AllowedSourceChainIDs = set.Of(testSourceChainID)
+				config.DeniedSourceChainIDs = set.Of(testSourceChainID)
+				return config
+			}(),
+			ExpectedError: errSourceChainAllowedAndDenied.Error(),
+		},
+		"valid allowed and denied source chains": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.AllowedSourceChainIDs = set.Of(testSourceChainID)
+				config.DeniedSourceChainIDs = set.Of(ids.GenerateTestID())
+				return config
+			}(),
+		},
 	}
 	testutils.RunVerifyTests(t, tests)
 }
@@ -83,6 +127,46 @@ func TestEqualWarpConfig(t *testing.T) {
 			Other:    NewConfig(utils.NewUint64(3), params.WarpQuorumNumeratorMinimum+5),
 			Expected: true,
 		},
+
+		"different source chain quorum numerators": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator}
+				return config
+			}(),
+			Other: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator - 1}
+				return config
+			}(),
+			Expected: false,
+		},
+
+		"different allowed source chains": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.AllowedSourceChainIDs = set.Of(testSourceChainID)
+				return config
+			}(),
+			Other:    NewDefaultConfig(utils.NewUint64(3)),
+			Expected: false,
+		},
+
+		"same source chain config": {
+			Config: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator}
+				config.DeniedSourceChainIDs = set.Of(testSourceChainID)
+				return config
+			}(),
+			Other: func() *Config {
+				config := NewDefaultConfig(utils.NewUint64(3))
+				config.SourceChainQuorumNumerators = map[ids.ID]uint64{testSourceChainID: params.WarpQuorumDenominator}
+				config.DeniedSourceChainIDs = set.Of(testSourceChainID)
+				return config
+			}(),
+			Expected: true,
+		},
 	}
 	testutils.RunEqualTests(t, tests)
 }
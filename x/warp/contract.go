@@ -18,6 +18,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -32,6 +33,17 @@ const (
 	// SendWarpMessageGasCostPerByte cost accounts for producing a signed message of a given size
 	SendWarpMessageGasCostPerByte uint64 = params.LogDataGas
 
+	// ConsumeWarpMessageGasCost is charged on top of the cost of getVerifiedWarpMessage to read and
+	// set the consumed flag of the message.
+	ConsumeWarpMessageGasCost uint64 = contract.ReadGasCostPerSlot + contract.WriteGasCostPerSlot
+	IsMessageConsumedGasCost  uint64 = contract.ReadGasCostPerSlot
+
+	// GasCostPerStorageProofNode is charged on top of the cost of getVerifiedWarpBlockHash for hashing and
+	// decoding the block header and each node of the account and storage proofs of getVerifiedWarpStorageValue.
+	GasCostPerStorageProofNode uint64 = 1_000
+	// GasCostPerStorageProofBytes is charged for each byte of the block header and the proof nodes.
+	GasCostPerStorageProofBytes uint64 = 10
+
 	GasCostPerWarpSigner            uint64 = 500
 	GasCostPerWarpMessageBytes      uint64 = 100
 	GasCostPerSignatureVerification uint64 = 200_000
@@ -40,6 +52,10 @@ const (
 var (
 	errInvalidSendInput  = errors.New("invalid sendWarpMessage input")
 	errInvalidIndexInput = errors.New("invalid index to specify warp message")
+
+	errInvalidIsMessageConsumedInput = errors.New("invalid isMessageConsumed input")
+	errInvalidStorageValueInput      = errors.New("invalid getVerifiedWarpStorageValue input")
+	errNotMessageDestination         = errors.New("caller is not the destination of the warp message")
 )
 
 // Singleton StatefulPrecompiledContract and signatures.
@@ -76,6 +92,29 @@ type GetVerifiedWarpMessageOutput struct {
 	Valid   bool
 }
 
+type GetVerifiedWarpStorageValueInput struct {
+	Index        uint32
+	BlockHeader  []byte
+	Account      common.Address
+	Slot         common.Hash
+	AccountProof [][]byte
+	StorageProof [][]byte
+}
+
+// WarpStorageValue is an auto generated low-level Go binding around an user-defined struct.
+type WarpStorageValue struct {
+	SourceChainID common.Hash
+	BlockHash     common.Hash
+	Account       common.Address
+	Slot          common.Hash
+	Value         common.Hash
+}
+
+type GetVerifiedWarpStorageValueOutput struct {
+	StorageValue WarpStorageValue
+	Valid        bool
+}
+
 type SendWarpMessageEventData struct {
 	Message []byte
 }
@@ -188,6 +227,163 @@ func getVerifiedWarpMessage(accessibleState contract.AccessibleState, caller com
 	return handleWarpMessage(accessibleState, input, suppliedGas, addressedPayloadHandler{})
 }
 
+// UnpackGetVerifiedWarpStorageValueInput attempts to unpack [input] as GetVerifiedWarpStorageValueInput
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpStorageValueInput(input []byte) (GetVerifiedWarpStorageValueInput, error) {
+	inputStruct := GetVerifiedWarpStorageValueInput{}
+	err := WarpABI.UnpackInputIntoInterface(&inputStruct, "getVerifiedWarpStorageValue", input)
+
+	return inputStruct, err
+}
+
+// PackGetVerifiedWarpStorageValue packs [inputStruct] of type GetVerifiedWarpStorageValueInput into the appropriate arguments
+// for getVerifiedWarpStorageValue.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedWarpStorageValue(inputStruct GetVerifiedWarpStorageValueInput) ([]byte, error) {
+	return WarpABI.Pack("getVerifiedWarpStorageValue",
+		inputStruct.Index,
+		inputStruct.BlockHeader,
+		inputStruct.Account,
+		inputStruct.Slot,
+		inputStruct.AccountProof,
+		inputStruct.StorageProof,
+	)
+}
+
+// PackGetVerifiedWarpStorageValueOutput attempts to pack given [outputStruct] of type GetVerifiedWarpStorageValueOutput
+// to conform the ABI outputs.
+func PackGetVerifiedWarpStorageValueOutput(outputStruct GetVerifiedWarpStorageValueOutput) ([]byte, error) {
+	return WarpABI.PackOutput("getVerifiedWarpStorageValue",
+		outputStruct.StorageValue,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedWarpStorageValueOutput attempts to unpack [output] as GetVerifiedWarpStorageValueOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedWarpStorageValueOutput(output []byte) (GetVerifiedWarpStorageValueOutput, error) {
+	outputStruct := GetVerifiedWarpStorageValueOutput{}
+	err := WarpABI.UnpackIntoInterface(&outputStruct, "getVerifiedWarpStorageValue", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedWarpStorageValue retrieves the pre-verified warp block hash from the predicate storage slots like
+// getVerifiedWarpBlockHash and returns the value of the storage slot proven against the state root of that block.
+// Reverts if [blockHeader] does not hash to the verified block hash or if the proofs are invalid.
+func getVerifiedWarpStorageValue(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost); err != nil {
+		return nil, 0, err
+	}
+	inputStruct, err := UnpackGetVerifiedWarpStorageValueInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidStorageValueInput, err)
+	}
+	proofGas, err := storageProofGas(inputStruct)
+	if err != nil {
+		return nil, 0, err
+	}
+	if remainingGas, err = contract.DeductGas(remainingGas, proofGas); err != nil {
+		return nil, 0, err
+	}
+	return handleWarpMessageAtIndex(accessibleState, inputStruct.Index, remainingGas, storageValueHandler{input: inputStruct})
+}
+
+// PackConsumeVerifiedWarpMessage packs [index] of type uint32 into the appropriate arguments for consumeVerifiedWarpMessage.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackConsumeVerifiedWarpMessage(index uint32) ([]byte, error) {
+	return WarpABI.Pack("consumeVerifiedWarpMessage", index)
+}
+
+// consumeVerifiedWarpMessage retrieves the pre-verified warp message from the predicate storage slots like
+// getVerifiedWarpMessage and marks it as consumed by [caller]. A message that was already consumed by [caller]
+// is returned as invalid. The output has the same encoding as the output of getVerifiedWarpMessage.
+func consumeVerifiedWarpMessage(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, ConsumeWarpMessageGasCost); err != nil {
+		return nil, 0, err
+	}
+	if readOnly {
+		return nil, remainingGas, vmerrs.ErrWriteProtection
+	}
+	return handleWarpMessage(accessibleState, input, remainingGas, consumingAddressedPayloadHandler{stateDB: accessibleState.GetStateDB(), caller: caller})
+}
+
+// IsMessageConsumedInput is the input of isMessageConsumed.
+type IsMessageConsumedInput struct {
+	SourceChainID common.Hash
+	MessageID     common.Hash
+	Consumer      common.Address
+}
+
+// UnpackIsMessageConsumedInput attempts to unpack [input] into the arguments to isMessageConsumed
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackIsMessageConsumedInput(input []byte) (IsMessageConsumedInput, error) {
+	inputStruct := IsMessageConsumedInput{}
+	err := WarpABI.UnpackInputIntoInterface(&inputStruct, "isMessageConsumed", input)
+	return inputStruct, err
+}
+
+// PackIsMessageConsumed packs [inputStruct] into the appropriate arguments for isMessageConsumed.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackIsMessageConsumed(inputStruct IsMessageConsumedInput) ([]byte, error) {
+	return WarpABI.Pack("isMessageConsumed", inputStruct.SourceChainID, inputStruct.MessageID, inputStruct.Consumer)
+}
+
+// PackIsMessageConsumedOutput attempts to pack given [consumed] of type bool
+// to conform the ABI outputs.
+func PackIsMessageConsumedOutput(consumed bool) ([]byte, error) {
+	return WarpABI.PackOutput("isMessageConsumed", consumed)
+}
+
+// UnpackIsMessageConsumedOutput attempts to unpack given [output] into the bool type output
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackIsMessageConsumedOutput(output []byte) (bool, error) {
+	res, err := WarpABI.Unpack("isMessageConsumed", output)
+	if err != nil {
+		return false, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(bool)).(*bool)
+	return unpacked, nil
+}
+
+// isMessageConsumed returns whether the message with the given source chain ID and message ID was consumed
+// by the given consumer through consumeVerifiedWarpMessage.
+func isMessageConsumed(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, IsMessageConsumedGasCost); err != nil {
+		return nil, 0, err
+	}
+	inputStruct, err := UnpackIsMessageConsumedInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIsMessageConsumedInput, err)
+	}
+	consumed := IsMessageConsumed(accessibleState.GetStateDB(), inputStruct.Consumer, inputStruct.SourceChainID, inputStruct.MessageID)
+	packedOutput, err := PackIsMessageConsumedOutput(consumed)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// IsMessageConsumed returns whether the message with [messageID] from [sourceChainID] was consumed by [consumer].
+func IsMessageConsumed(stateDB contract.StateDB, consumer common.Address, sourceChainID common.Hash, messageID common.Hash) bool {
+	return stateDB.GetState(ContractAddress, consumedMessageKey(consumer, sourceChainID, messageID)) != (common.Hash{})
+}
+
+// SetMessageConsumed marks the message with [messageID] from [sourceChainID] as consumed by [consumer].
+func SetMessageConsumed(stateDB contract.StateDB, consumer common.Address, sourceChainID common.Hash, messageID common.Hash) {
+	stateDB.SetState(ContractAddress, consumedMessageKey(consumer, sourceChainID, messageID), common.BigToHash(common.Big1))
+}
+
+// consumedMessageKey returns the storage key of the consumed flag of the message with [messageID] from
+// [sourceChainID] consumed by [consumer]. The flag is kept per consumer so that a contract consuming a
+// message first cannot prevent the contract the message is intended for from consuming it.
+func consumedMessageKey(consumer common.Address, sourceChainID common.Hash, messageID common.Hash) common.Hash {
+	return crypto.Keccak256Hash(consumer[:], sourceChainID[:], messageID[:])
+}
+
 // UnpackSendWarpMessageInput attempts to unpack [input] as []byte
 // assumes that [input] does not include selector (omits first 4 func signature bytes)
 func UnpackSendWarpMessageInput(input []byte) ([]byte, error) {
@@ -316,6 +512,12 @@ func createWarpPrecompile() contract.StatefulPrecompiledContract {
 		"getVerifiedWarpMessage":   getVerifiedWarpMessage,
 		"sendWarpMessage":          sendWarpMessage,
 	}
+	// Functions that can only be called once the DUpgrade is activated
+	dUpgradeFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"consumeVerifiedWarpMessage":  consumeVerifiedWarpMessage,
+		"getVerifiedWarpStorageValue": getVerifiedWarpStorageValue,
+		"isMessageConsumed":           isMessageConsumed,
+	}
 
 	for name, function := range abiFunctionMap {
 		method, ok := WarpABI.Methods[name]
@@ -324,6 +526,13 @@ func createWarpPrecompile() contract.StatefulPrecompiledContract {
 		}
 		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
 	}
+	for name, function := range dUpgradeFunctionMap {
+		method, ok := WarpABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunctionWithActivator(method.ID, function, isDUpgradeActivated))
+	}
 	// Construct the contract with no fallback function.
 	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
 	if err != nil {
@@ -331,3 +540,7 @@ func createWarpPrecompile() contract.StatefulPrecompiledContract {
 	}
 	return statefulContract
 }
+
+func isDUpgradeActivated(evm contract.AccessibleState) bool {
+	return evm.GetChainConfig().IsDUpgrade(evm.GetBlockContext().Timestamp())
+}
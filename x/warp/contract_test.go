@@ -17,11 +17,13 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/subnet-evm/core/state"
 	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
 	"github.com/ava-labs/subnet-evm/precompile/testutils"
 	"github.com/ava-labs/subnet-evm/predicate"
 	"github.com/ava-labs/subnet-evm/vmerrs"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestGetBlockchainID(t *testing.T) {
@@ -729,6 +731,280 @@ func TestGetVerifiedWarpBlockHash(t *testing.T) {
 	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
 }
 
+func TestConsumeVerifiedWarpMessage(t *testing.T) {
+	networkID := uint32(54321)
+	callerAddr := common.HexToAddress("0x0123")
+	otherAddr := common.HexToAddress("0x4567")
+	sourceAddress := common.HexToAddress("0x456789")
+	sourceChainID := ids.GenerateTestID()
+	newMessage := func(payloadBytes []byte) ([]byte, common.Hash) {
+		addressedPayload, err := payload.NewAddressedCall(
+			sourceAddress.Bytes(),
+			payloadBytes,
+		)
+		require.NoError(t, err)
+		unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, addressedPayload.Bytes())
+		require.NoError(t, err)
+		warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+		require.NoError(t, err)
+		return predicate.PackPredicate(warpMessage.Bytes()), common.Hash(unsignedWarpMsg.ID())
+	}
+	packValidOutput := func(payloadBytes []byte) []byte {
+		res, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{
+			Message: WarpMessage{
+				SourceChainID:       common.Hash(sourceChainID),
+				OriginSenderAddress: sourceAddress,
+				Payload:             payloadBytes,
+			},
+			Valid: true,
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	packagedPayloadBytes := []byte("mcsorley")
+	warpMessagePredicateBytes, messageID := newMessage(packagedPayloadBytes)
+	contractCallPayloadBytes, err := PackContractCall(ContractCall{
+		DestinationChainID: common.Hash(ids.GenerateTestID()),
+		DestinationAddress: callerAddr,
+		GasLimit:           100_000,
+		Data:               packagedPayloadBytes,
+	})
+	require.NoError(t, err)
+	contractCallPredicateBytes, contractCallMessageID := newMessage(contractCallPayloadBytes)
+	consumeVerifiedWarpMsg, err := PackConsumeVerifiedWarpMessage(0)
+	require.NoError(t, err)
+	noFailures := set.NewBits().Bytes()
+	invalidRes, err := PackGetVerifiedWarpMessageOutput(GetVerifiedWarpMessageOutput{Valid: false})
+	require.NoError(t, err)
+	setupBlockContext := func(predicateResults []byte) func(*contract.MockBlockContext) {
+		return func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults)
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"consume message success": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:          false,
+			ExpectedRes:       packValidOutput(packagedPayloadBytes),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsMessageConsumed(state, callerAddr, common.Hash(sourceChainID), messageID))
+				require.False(t, IsMessageConsumed(state, otherAddr, common.Hash(sourceChainID), messageID))
+			},
+		},
+		"consume consumed message": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+				SetMessageConsumed(state, callerAddr, common.Hash(sourceChainID), messageID)
+			},
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:          false,
+			ExpectedRes:       invalidRes,
+		},
+		"consume message consumed by third party": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+				SetMessageConsumed(state, otherAddr, common.Hash(sourceChainID), messageID)
+			},
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(warpMessagePredicateBytes)),
+			ReadOnly:          false,
+			ExpectedRes:       packValidOutput(packagedPayloadBytes),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsMessageConsumed(state, callerAddr, common.Hash(sourceChainID), messageID))
+			},
+		},
+		"consume contract call message from destination": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{contractCallPredicateBytes})
+			},
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(contractCallPredicateBytes)),
+			ReadOnly:          false,
+			ExpectedRes:       packValidOutput(contractCallPayloadBytes),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.True(t, IsMessageConsumed(state, callerAddr, common.Hash(sourceChainID), contractCallMessageID))
+			},
+		},
+		"consume contract call message from third party": {
+			Caller:  otherAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{contractCallPredicateBytes})
+			},
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost + GasCostPerWarpMessageBytes*uint64(len(contractCallPredicateBytes)),
+			ReadOnly:          false,
+			ExpectedErr:       errNotMessageDestination.Error(),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsMessageConsumed(state, otherAddr, common.Hash(sourceChainID), contractCallMessageID))
+				require.False(t, IsMessageConsumed(state, callerAddr, common.Hash(sourceChainID), contractCallMessageID))
+			},
+		},
+		"consume message failed predicate": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+			},
+			SetupBlockContext: setupBlockContext(set.NewBits(0).Bytes()),
+			SuppliedGas:       ConsumeWarpMessageGasCost + GetVerifiedWarpMessageBaseCost,
+			ReadOnly:          false,
+			ExpectedRes:       invalidRes,
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				require.False(t, IsMessageConsumed(state, callerAddr, common.Hash(sourceChainID), messageID))
+			},
+		},
+		"consume message readOnly": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			SuppliedGas: ConsumeWarpMessageGasCost,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrWriteProtection.Error(),
+		},
+		"consume message insufficient gas": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			SuppliedGas: ConsumeWarpMessageGasCost - 1,
+			ReadOnly:    false,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"consume message before activation": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return consumeVerifiedWarpMsg },
+			ChainConfig: newDUpgradeChainConfig(t, false),
+			SuppliedGas: 0,
+			ReadOnly:    false,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestIsMessageConsumed(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	consumerAddr := common.HexToAddress("0x4567")
+	sourceChainID := common.Hash(ids.GenerateTestID())
+	messageID := common.Hash(ids.GenerateTestID())
+	isMessageConsumedInput, err := PackIsMessageConsumed(IsMessageConsumedInput{
+		SourceChainID: sourceChainID,
+		MessageID:     messageID,
+		Consumer:      consumerAddr,
+	})
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"message not consumed": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isMessageConsumedInput },
+			SuppliedGas: IsMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsMessageConsumedOutput(false)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"message consumed": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return isMessageConsumedInput },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetMessageConsumed(state, consumerAddr, sourceChainID, messageID)
+			},
+			SuppliedGas: IsMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsMessageConsumedOutput(true)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"message consumed by other consumer": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return isMessageConsumedInput },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetMessageConsumed(state, callerAddr, sourceChainID, messageID)
+			},
+			SuppliedGas: IsMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsMessageConsumedOutput(false)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"message consumed from other source chain": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackIsMessageConsumed(IsMessageConsumedInput{
+					SourceChainID: common.Hash(ids.GenerateTestID()),
+					MessageID:     messageID,
+					Consumer:      consumerAddr,
+				})
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				SetMessageConsumed(state, consumerAddr, sourceChainID, messageID)
+			},
+			SuppliedGas: IsMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackIsMessageConsumedOutput(false)
+				require.NoError(t, err)
+				return res
+			}(),
+		},
+		"invalid input": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isMessageConsumedInput[:len(isMessageConsumedInput)-1] },
+			SuppliedGas: IsMessageConsumedGasCost,
+			ReadOnly:    true,
+			ExpectedErr: errInvalidIsMessageConsumedInput.Error(),
+		},
+		"insufficient gas": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isMessageConsumedInput },
+			SuppliedGas: IsMessageConsumedGasCost - 1,
+			ReadOnly:    true,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+		"before activation": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return isMessageConsumedInput },
+			ChainConfig: newDUpgradeChainConfig(t, false),
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+// newDUpgradeChainConfig returns a chain config where the DUpgrade is activated if [activated].
+func newDUpgradeChainConfig(t testing.TB, activated bool) precompileconfig.ChainConfig {
+	config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+	config.EXPECT().IsDUpgrade(gomock.Any()).Return(activated).AnyTimes()
+	return config
+}
+
 func TestPackEvents(t *testing.T) {
 	sourceChainID := ids.GenerateTestID()
 	sourceAddress := common.HexToAddress("0x0123")
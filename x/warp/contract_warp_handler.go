@@ -18,12 +18,15 @@ import (
 
 var (
 	_ messageHandler = addressedPayloadHandler{}
+	_ messageHandler = consumingAddressedPayloadHandler{}
 	_ messageHandler = blockHashHandler{}
+	_ messageHandler = storageValueHandler{}
 )
 
 var (
-	getVerifiedWarpMessageInvalidOutput   []byte
-	getVerifiedWarpBlockHashInvalidOutput []byte
+	getVerifiedWarpMessageInvalidOutput      []byte
+	getVerifiedWarpBlockHashInvalidOutput    []byte
+	getVerifiedWarpStorageValueInvalidOutput []byte
 )
 
 func init() {
@@ -38,6 +41,12 @@ func init() {
 		panic(err)
 	}
 	getVerifiedWarpBlockHashInvalidOutput = res
+
+	res, err = PackGetVerifiedWarpStorageValueOutput(GetVerifiedWarpStorageValueOutput{Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedWarpStorageValueInvalidOutput = res
 }
 
 type messageHandler interface {
@@ -55,6 +64,12 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	if err != nil {
 		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIndexInput, err)
 	}
+	return handleWarpMessageAtIndex(accessibleState, warpIndexInput, remainingGas, handler)
+}
+
+// handleWarpMessageAtIndex passes the warp message in the predicate at [warpIndexInput] to [handler] if it was verified,
+// charging for its size.
+func handleWarpMessageAtIndex(accessibleState contract.AccessibleState, warpIndexInput uint32, remainingGas uint64, handler messageHandler) ([]byte, uint64, error) {
 	if warpIndexInput > math.MaxInt32 {
 		return nil, remainingGas, fmt.Errorf("%w: larger than MaxInt32", errInvalidIndexInput)
 	}
@@ -73,7 +88,8 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	if overflow {
 		return nil, 0, vmerrs.ErrOutOfGas
 	}
-	if remainingGas, err = contract.DeductGas(remainingGas, msgBytesGas); err != nil {
+	remainingGas, err := contract.DeductGas(remainingGas, msgBytesGas)
+	if err != nil {
 		return nil, 0, err
 	}
 	// Note: since the predicate is verified in advance of execution, the precompile should not
@@ -114,6 +130,39 @@ func (addressedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte,
 	})
 }
 
+// consumingAddressedPayloadHandler handles addressed payloads like [addressedPayloadHandler] and
+// marks each message as consumed by [caller], treating messages that [caller] already consumed as
+// invalid. Messages with a ContractCall typed payload can only be consumed by their destination.
+type consumingAddressedPayloadHandler struct {
+	stateDB contract.StateDB
+	caller  common.Address
+}
+
+func (consumingAddressedPayloadHandler) packFailed() []byte {
+	return getVerifiedWarpMessageInvalidOutput
+}
+
+func (h consumingAddressedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	sourceChainID := common.Hash(warpMessage.SourceChainID)
+	messageID := common.Hash(warpMessage.UnsignedMessage.ID())
+	if IsMessageConsumed(h.stateDB, h.caller, sourceChainID, messageID) {
+		return h.packFailed(), nil
+	}
+	addressedPayload, err := payload.ParseAddressedCall(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidAddressedPayload, err)
+	}
+	if call, err := UnpackContractCall(addressedPayload.Payload); err == nil && call.DestinationAddress != h.caller {
+		return nil, fmt.Errorf("%w: expected %s, got %s", errNotMessageDestination, call.DestinationAddress, h.caller)
+	}
+	res, err := addressedPayloadHandler{}.handleMessage(warpMessage)
+	if err != nil {
+		return nil, err
+	}
+	SetMessageConsumed(h.stateDB, h.caller, sourceChainID, messageID)
+	return res, nil
+}
+
 type blockHashHandler struct{}
 
 func (blockHashHandler) packFailed() []byte {
@@ -133,3 +182,35 @@ func (blockHashHandler) handleMessage(warpMessage *warp.Message) ([]byte, error)
 		Valid: true,
 	})
 }
+
+// storageValueHandler handles block hash payloads by proving the storage slot in [input] against the
+// state root of the block.
+type storageValueHandler struct {
+	input GetVerifiedWarpStorageValueInput
+}
+
+func (storageValueHandler) packFailed() []byte {
+	return getVerifiedWarpStorageValueInvalidOutput
+}
+
+func (h storageValueHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
+	blockHashPayload, err := payload.ParseHash(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidBlockHashPayload, err)
+	}
+	blockHash := common.BytesToHash(blockHashPayload.Hash[:])
+	value, err := verifyStorageProof(blockHash, h.input)
+	if err != nil {
+		return nil, err
+	}
+	return PackGetVerifiedWarpStorageValueOutput(GetVerifiedWarpStorageValueOutput{
+		StorageValue: WarpStorageValue{
+			SourceChainID: common.Hash(warpMessage.SourceChainID),
+			BlockHash:     blockHash,
+			Account:       h.input.Account,
+			Slot:          h.input.Slot,
+			Value:         value,
+		},
+		Valid: true,
+	})
+}
@@ -0,0 +1,193 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Typed payloads are standard formats for the payload passed to sendWarpMessage, and therefore for the
+// payload of the addressed call received through getVerifiedWarpMessage. A typed payload is the ABI
+// encoding of (uint8 payloadType, bytes body), where body is the ABI encoding of the fields of the
+// payload type in order. The same encoding is implemented in Solidity by the WarpPayloads library in
+// contracts/contracts/interfaces/IWarpPayloads.sol.
+
+// PayloadType identifies the format of a typed payload.
+type PayloadType uint8
+
+const (
+	// NativeTokenTransferPayloadType is the type of a [NativeTokenTransfer] payload
+	NativeTokenTransferPayloadType PayloadType = iota + 1
+	// ERC20TransferPayloadType is the type of an [ERC20Transfer] payload
+	ERC20TransferPayloadType
+	// ContractCallPayloadType is the type of a [ContractCall] payload
+	ContractCallPayloadType
+)
+
+var (
+	ErrInvalidTypedPayload   = errors.New("invalid typed warp payload")
+	ErrUnexpectedPayloadType = errors.New("unexpected typed warp payload type")
+
+	uint8Type   = mustNewType("uint8")
+	uint256Type = mustNewType("uint256")
+	bytes32Type = mustNewType("bytes32")
+	addressType = mustNewType("address")
+	bytesType   = mustNewType("bytes")
+
+	typedPayloadArgs = abi.Arguments{
+		{Name: "payloadType", Type: uint8Type},
+		{Name: "body", Type: bytesType},
+	}
+	nativeTokenTransferArgs = abi.Arguments{
+		{Name: "destinationChainID", Type: bytes32Type},
+		{Name: "recipient", Type: addressType},
+		{Name: "amount", Type: uint256Type},
+	}
+	erc20TransferArgs = abi.Arguments{
+		{Name: "destinationChainID", Type: bytes32Type},
+		{Name: "token", Type: addressType},
+		{Name: "recipient", Type: addressType},
+		{Name: "amount", Type: uint256Type},
+	}
+	contractCallArgs = abi.Arguments{
+		{Name: "destinationChainID", Type: bytes32Type},
+		{Name: "destinationAddress", Type: addressType},
+		{Name: "gasLimit", Type: uint256Type},
+		{Name: "data", Type: bytesType},
+	}
+)
+
+// NativeTokenTransfer requests [Amount] of the native token to be released to [Recipient]
+// on the chain with [DestinationChainID].
+type NativeTokenTransfer struct {
+	DestinationChainID common.Hash
+	Recipient          common.Address
+	Amount             *big.Int
+}
+
+// ERC20Transfer requests [Amount] of the ERC20 [Token] of the source chain to be released
+// to [Recipient] on the chain with [DestinationChainID].
+type ERC20Transfer struct {
+	DestinationChainID common.Hash
+	Token              common.Address
+	Recipient          common.Address
+	Amount             *big.Int
+}
+
+// ContractCall requests [DestinationAddress] on the chain with [DestinationChainID] to be
+// called with [Data] and at most [GasLimit] gas.
+type ContractCall struct {
+	DestinationChainID common.Hash
+	DestinationAddress common.Address
+	GasLimit           uint64
+	Data               []byte
+}
+
+// PackNativeTokenTransfer packs [transfer] into a typed payload.
+func PackNativeTokenTransfer(transfer NativeTokenTransfer) ([]byte, error) {
+	return packTypedPayload(NativeTokenTransferPayloadType, nativeTokenTransferArgs, transfer.DestinationChainID, transfer.Recipient, transfer.Amount)
+}
+
+// UnpackNativeTokenTransfer unpacks [payload] as a typed NativeTokenTransfer payload.
+func UnpackNativeTokenTransfer(payload []byte) (NativeTokenTransfer, error) {
+	values, err := unpackTypedPayload(payload, NativeTokenTransferPayloadType, nativeTokenTransferArgs)
+	if err != nil {
+		return NativeTokenTransfer{}, err
+	}
+	return NativeTokenTransfer{
+		DestinationChainID: *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Recipient:          *abi.ConvertType(values[1], new(common.Address)).(*common.Address),
+		Amount:             *abi.ConvertType(values[2], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// PackERC20Transfer packs [transfer] into a typed payload.
+func PackERC20Transfer(transfer ERC20Transfer) ([]byte, error) {
+	return packTypedPayload(ERC20TransferPayloadType, erc20TransferArgs, transfer.DestinationChainID, transfer.Token, transfer.Recipient, transfer.Amount)
+}
+
+// UnpackERC20Transfer unpacks [payload] as a typed ERC20Transfer payload.
+func UnpackERC20Transfer(payload []byte) (ERC20Transfer, error) {
+	values, err := unpackTypedPayload(payload, ERC20TransferPayloadType, erc20TransferArgs)
+	if err != nil {
+		return ERC20Transfer{}, err
+	}
+	return ERC20Transfer{
+		DestinationChainID: *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Token:              *abi.ConvertType(values[1], new(common.Address)).(*common.Address),
+		Recipient:          *abi.ConvertType(values[2], new(common.Address)).(*common.Address),
+		Amount:             *abi.ConvertType(values[3], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// PackContractCall packs [call] into a typed payload.
+func PackContractCall(call ContractCall) ([]byte, error) {
+	return packTypedPayload(ContractCallPayloadType, contractCallArgs, call.DestinationChainID, call.DestinationAddress, new(big.Int).SetUint64(call.GasLimit), call.Data)
+}
+
+// UnpackContractCall unpacks [payload] as a typed ContractCall payload.
+func UnpackContractCall(payload []byte) (ContractCall, error) {
+	values, err := unpackTypedPayload(payload, ContractCallPayloadType, contractCallArgs)
+	if err != nil {
+		return ContractCall{}, err
+	}
+	gasLimit := *abi.ConvertType(values[2], new(*big.Int)).(**big.Int)
+	if !gasLimit.IsUint64() {
+		return ContractCall{}, fmt.Errorf("%w: gas limit %s exceeds uint64", ErrInvalidTypedPayload, gasLimit)
+	}
+	return ContractCall{
+		DestinationChainID: *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		DestinationAddress: *abi.ConvertType(values[1], new(common.Address)).(*common.Address),
+		GasLimit:           gasLimit.Uint64(),
+		Data:               *abi.ConvertType(values[3], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// UnpackPayloadType returns the type of the typed payload [payload] and its ABI encoded body.
+func UnpackPayloadType(payload []byte) (PayloadType, []byte, error) {
+	values, err := typedPayloadArgs.Unpack(payload)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %s", ErrInvalidTypedPayload, err)
+	}
+	payloadType := *abi.ConvertType(values[0], new(uint8)).(*uint8)
+	body := *abi.ConvertType(values[1], new([]byte)).(*[]byte)
+	return PayloadType(payloadType), body, nil
+}
+
+func packTypedPayload(payloadType PayloadType, args abi.Arguments, values ...interface{}) ([]byte, error) {
+	body, err := args.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return typedPayloadArgs.Pack(uint8(payloadType), body)
+}
+
+// unpackTypedPayload unpacks the body of [payload] with [args], failing if [payload] is not of [expectedType].
+func unpackTypedPayload(payload []byte, expectedType PayloadType, args abi.Arguments) ([]interface{}, error) {
+	payloadType, body, err := UnpackPayloadType(payload)
+	if err != nil {
+		return nil, err
+	}
+	if payloadType != expectedType {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrUnexpectedPayloadType, expectedType, payloadType)
+	}
+	values, err := args.Unpack(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTypedPayload, err)
+	}
+	return values, nil
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,83 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeTokenTransferPayload(t *testing.T) {
+	require := require.New(t)
+
+	transfer := NativeTokenTransfer{
+		DestinationChainID: common.HexToHash("0x01"),
+		Recipient:          common.HexToAddress("0x0100000000000000000000000000000000000002"),
+		Amount:             big.NewInt(1_000_000),
+	}
+	payload, err := PackNativeTokenTransfer(transfer)
+	require.NoError(err)
+
+	payloadType, _, err := UnpackPayloadType(payload)
+	require.NoError(err)
+	require.Equal(NativeTokenTransferPayloadType, payloadType)
+
+	unpacked, err := UnpackNativeTokenTransfer(payload)
+	require.NoError(err)
+	require.Equal(transfer, unpacked)
+
+	_, err = UnpackERC20Transfer(payload)
+	require.ErrorIs(err, ErrUnexpectedPayloadType)
+}
+
+func TestERC20TransferPayload(t *testing.T) {
+	require := require.New(t)
+
+	transfer := ERC20Transfer{
+		DestinationChainID: common.HexToHash("0x02"),
+		Token:              common.HexToAddress("0x0100000000000000000000000000000000000003"),
+		Recipient:          common.HexToAddress("0x0100000000000000000000000000000000000004"),
+		Amount:             new(big.Int).Lsh(big.NewInt(1), 200),
+	}
+	payload, err := PackERC20Transfer(transfer)
+	require.NoError(err)
+
+	unpacked, err := UnpackERC20Transfer(payload)
+	require.NoError(err)
+	require.Equal(transfer, unpacked)
+
+	_, err = UnpackContractCall(payload)
+	require.ErrorIs(err, ErrUnexpectedPayloadType)
+}
+
+func TestContractCallPayload(t *testing.T) {
+	require := require.New(t)
+
+	call := ContractCall{
+		DestinationChainID: common.HexToHash("0x03"),
+		DestinationAddress: common.HexToAddress("0x0100000000000000000000000000000000000005"),
+		GasLimit:           250_000,
+		Data:               []byte("call data"),
+	}
+	payload, err := PackContractCall(call)
+	require.NoError(err)
+
+	unpacked, err := UnpackContractCall(payload)
+	require.NoError(err)
+	require.Equal(call, unpacked)
+
+	_, err = UnpackNativeTokenTransfer(payload)
+	require.ErrorIs(err, ErrUnexpectedPayloadType)
+}
+
+func TestUnpackInvalidTypedPayload(t *testing.T) {
+	_, _, err := UnpackPayloadType([]byte("not a typed payload"))
+	require.ErrorIs(t, err, ErrInvalidTypedPayload)
+
+	_, err = UnpackContractCall(nil)
+	require.ErrorIs(t, err, ErrInvalidTypedPayload)
+}
@@ -687,3 +687,67 @@ func TestWarpPredicate(t *testing.T) {
 func BenchmarkWarpPredicate(b *testing.B) {
 	testutils.RunPredicateBenchmarks(b, predicateTests)
 }
+
+func TestWarpSourceChainConfig(t *testing.T) {
+	snowCtx := createSnowCtx([]validatorRange{
+		{
+			start:     0,
+			end:       100,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+	numSigners := int(params.WarpDefaultQuorumNumerator)
+	predicateBytes := createPredicate(numSigners)
+
+	newTest := func(config *Config, valid bool) testutils.PredicateTest {
+		expectedPredicateResults := set.NewBits()
+		if !valid {
+			expectedPredicateResults.Add(0)
+		}
+		return testutils.PredicateTest{
+			Config: config,
+			PredicateContext: &precompileconfig.PredicateContext{
+				SnowCtx: snowCtx,
+				ProposerVMBlockCtx: &block.Context{
+					PChainHeight: 1,
+				},
+			},
+			StorageSlots: [][]byte{predicateBytes},
+			Gas:          GasCostPerSignatureVerification + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + uint64(numSigners)*GasCostPerWarpSigner,
+			GasErr:       nil,
+			PredicateRes: expectedPredicateResults.Bytes(),
+		}
+	}
+	newConfig := func(modify func(*Config)) *Config {
+		config := NewDefaultConfig(subnetEVMUtils.NewUint64(0))
+		modify(config)
+		return config
+	}
+
+	tests := map[string]testutils.PredicateTest{
+		"higher quorum for source chain": newTest(newConfig(func(c *Config) {
+			c.SourceChainQuorumNumerators = map[ids.ID]uint64{sourceChainID: params.WarpDefaultQuorumNumerator + 1}
+		}), false),
+		"higher quorum for other source chain": newTest(newConfig(func(c *Config) {
+			c.SourceChainQuorumNumerators = map[ids.ID]uint64{ids.GenerateTestID(): params.WarpDefaultQuorumNumerator + 1}
+		}), true),
+		"lower quorum for source chain overrides quorum numerator": newTest(newConfig(func(c *Config) {
+			c.QuorumNumerator = params.WarpQuorumDenominator
+			c.SourceChainQuorumNumerators = map[ids.ID]uint64{sourceChainID: params.WarpDefaultQuorumNumerator}
+		}), true),
+		"source chain allowed": newTest(newConfig(func(c *Config) {
+			c.AllowedSourceChainIDs = set.Of(sourceChainID)
+		}), true),
+		"source chain not allowed": newTest(newConfig(func(c *Config) {
+			c.AllowedSourceChainIDs = set.Of(ids.GenerateTestID())
+		}), false),
+		"source chain denied": newTest(newConfig(func(c *Config) {
+			c.DeniedSourceChainIDs = set.Of(sourceChainID)
+		}), false),
+		"other source chain denied": newTest(newConfig(func(c *Config) {
+			c.DeniedSourceChainIDs = set.Of(ids.GenerateTestID())
+		}), true),
+	}
+	testutils.RunPredicateTests(t, tests)
+}
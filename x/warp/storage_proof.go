@@ -0,0 +1,128 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethdb/memorydb"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ava-labs/subnet-evm/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// headerRootIndex is the index of the state root in the RLP list of an Ethereum block header,
+// after the parent hash, uncle hash and coinbase.
+const headerRootIndex = 3
+
+var (
+	errBlockHeaderMismatch = errors.New("block header does not match verified block hash")
+	errInvalidBlockHeader  = errors.New("cannot decode state root from block header")
+	errInvalidAccountProof = errors.New("invalid account proof")
+	errInvalidStorageProof = errors.New("invalid storage proof")
+	errInvalidAccountRLP   = errors.New("cannot decode proven account")
+	errInvalidStorageRLP   = errors.New("cannot decode proven storage value")
+)
+
+// storageProofGas returns the gas charged for verifying the block header and proofs in [input].
+func storageProofGas(input GetVerifiedWarpStorageValueInput) (uint64, error) {
+	numNodes := uint64(1 + len(input.AccountProof) + len(input.StorageProof))
+	numBytes := uint64(len(input.BlockHeader))
+	for _, node := range input.AccountProof {
+		numBytes += uint64(len(node))
+	}
+	for _, node := range input.StorageProof {
+		numBytes += uint64(len(node))
+	}
+
+	nodesGas, overflow := math.SafeMul(numNodes, GasCostPerStorageProofNode)
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	bytesGas, overflow := math.SafeMul(numBytes, GasCostPerStorageProofBytes)
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	totalGas, overflow := math.SafeAdd(nodesGas, bytesGas)
+	if overflow {
+		return 0, vmerrs.ErrOutOfGas
+	}
+	return totalGas, nil
+}
+
+// verifyStorageProof returns the value of the storage slot in [input], proven against the state root of
+// the block header in [input], which must hash to [blockHash]. The proofs are in the format returned by
+// eth_getProof. A proof of absence of the account or of the storage slot proves a zero value.
+func verifyStorageProof(blockHash common.Hash, input GetVerifiedWarpStorageValueInput) (common.Hash, error) {
+	if crypto.Keccak256Hash(input.BlockHeader) != blockHash {
+		return common.Hash{}, errBlockHeaderMismatch
+	}
+	stateRoot, err := headerStateRoot(input.BlockHeader)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %s", errInvalidBlockHeader, err)
+	}
+
+	accountRLP, err := trie.VerifyProof(stateRoot, crypto.Keccak256(input.Account.Bytes()), newProofDB(input.AccountProof))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %s", errInvalidAccountProof, err)
+	}
+	if len(accountRLP) == 0 {
+		return common.Hash{}, nil
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(accountRLP, &account); err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %s", errInvalidAccountRLP, err)
+	}
+
+	valueRLP, err := trie.VerifyProof(account.Root, crypto.Keccak256(input.Slot.Bytes()), newProofDB(input.StorageProof))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %s", errInvalidStorageProof, err)
+	}
+	if len(valueRLP) == 0 {
+		return common.Hash{}, nil
+	}
+	_, value, _, err := rlp.Split(valueRLP)
+	if err != nil || len(value) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("%w: %x", errInvalidStorageRLP, valueRLP)
+	}
+	return common.BytesToHash(value), nil
+}
+
+// headerStateRoot returns the state root of the RLP encoded block header [header]. Only the leading
+// fields shared by all Ethereum block headers are decoded, so that headers of chains with different
+// header extensions can be used.
+func headerStateRoot(header []byte) (common.Hash, error) {
+	fields, _, err := rlp.SplitList(header)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	for i := 0; i < headerRootIndex; i++ {
+		if _, fields, err = rlp.SplitString(fields); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	root, _, err := rlp.SplitString(fields)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(root) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("unexpected state root length %d", len(root))
+	}
+	return common.BytesToHash(root), nil
+}
+
+// newProofDB returns a proof database containing the trie nodes in [proof], keyed by their hash.
+func newProofDB(proof [][]byte) *memorydb.Database {
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		// memorydb never returns an error on Put
+		_ = proofDB.Put(crypto.Keccak256(node), node)
+	}
+	return proofDB
+}
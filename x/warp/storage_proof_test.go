@@ -0,0 +1,207 @@
+// (c) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contract"
+	"github.com/ava-labs/subnet-evm/precompile/testutils"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// proofList collects the nodes of a Merkle proof in the format returned by eth_getProof.
+type proofList [][]byte
+
+func (p *proofList) Put(_ []byte, value []byte) error {
+	*p = append(*p, value)
+	return nil
+}
+
+func (*proofList) Delete([]byte) error { panic("not supported") }
+
+func TestGetVerifiedWarpStorageValue(t *testing.T) {
+	require := require.New(t)
+
+	account := common.HexToAddress("0x0100000000000000000000000000000000000001")
+	slot := common.HexToHash("0x01")
+	value := common.HexToHash("0x1234")
+	missingSlot := common.HexToHash("0x02")
+
+	// Build a state with [account] holding [value] at [slot]
+	db := trie.NewDatabase(rawdb.NewMemoryDatabase())
+	storageTrie := trie.NewEmpty(db)
+	valueRLP, err := rlp.EncodeToBytes(common.TrimLeftZeroes(value.Bytes()))
+	require.NoError(err)
+	require.NoError(storageTrie.Update(crypto.Keccak256(slot.Bytes()), valueRLP))
+	accountRLP, err := rlp.EncodeToBytes(&types.StateAccount{
+		Nonce:    1,
+		Balance:  big.NewInt(1),
+		Root:     storageTrie.Hash(),
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	})
+	require.NoError(err)
+	accountTrie := trie.NewEmpty(db)
+	require.NoError(accountTrie.Update(crypto.Keccak256(account.Bytes()), accountRLP))
+
+	var accountProof, storageProof, missingStorageProof proofList
+	require.NoError(accountTrie.Prove(crypto.Keccak256(account.Bytes()), 0, &accountProof))
+	require.NoError(storageTrie.Prove(crypto.Keccak256(slot.Bytes()), 0, &storageProof))
+	require.NoError(storageTrie.Prove(crypto.Keccak256(missingSlot.Bytes()), 0, &missingStorageProof))
+
+	header := &types.Header{
+		Root:       accountTrie.Hash(),
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		BaseFee:    big.NewInt(1),
+	}
+	headerBytes, err := rlp.EncodeToBytes(header)
+	require.NoError(err)
+
+	// Create a verified warp message attesting to the block hash of [header]
+	networkID := uint32(54321)
+	sourceChainID := ids.GenerateTestID()
+	blockHashPayload, err := payload.NewHash(ids.ID(header.Hash()))
+	require.NoError(err)
+	unsignedWarpMsg, err := avalancheWarp.NewUnsignedMessage(networkID, sourceChainID, blockHashPayload.Bytes())
+	require.NoError(err)
+	warpMessage, err := avalancheWarp.NewMessage(unsignedWarpMsg, &avalancheWarp.BitSetSignature{}) // Create message with empty signature for testing
+	require.NoError(err)
+	warpMessagePredicateBytes := predicate.PackPredicate(warpMessage.Bytes())
+	noFailures := set.NewBits().Bytes()
+
+	validInput := GetVerifiedWarpStorageValueInput{
+		BlockHeader:  headerBytes,
+		Account:      account,
+		Slot:         slot,
+		AccountProof: accountProof,
+		StorageProof: storageProof,
+	}
+	packInput := func(input GetVerifiedWarpStorageValueInput) []byte {
+		packed, err := PackGetVerifiedWarpStorageValue(input)
+		require.NoError(err)
+		return packed
+	}
+	inputGas := func(input GetVerifiedWarpStorageValueInput) uint64 {
+		gas, err := storageProofGas(input)
+		require.NoError(err)
+		return GetVerifiedWarpMessageBaseCost + gas
+	}
+	packOutput := func(slot common.Hash, value common.Hash) []byte {
+		res, err := PackGetVerifiedWarpStorageValueOutput(GetVerifiedWarpStorageValueOutput{
+			StorageValue: WarpStorageValue{
+				SourceChainID: common.Hash(sourceChainID),
+				BlockHash:     header.Hash(),
+				Account:       account,
+				Slot:          slot,
+				Value:         value,
+			},
+			Valid: true,
+		})
+		require.NoError(err)
+		return res
+	}
+	setPredicate := func(t testing.TB, state contract.StateDB) {
+		state.SetPredicateStorageSlots(ContractAddress, [][]byte{warpMessagePredicateBytes})
+	}
+	predicateGas := GasCostPerWarpMessageBytes * uint64(len(warpMessagePredicateBytes))
+
+	missingSlotInput := validInput
+	missingSlotInput.Slot = missingSlot
+	missingSlotInput.StorageProof = missingStorageProof
+
+	otherHeaderInput := validInput
+	otherHeader := types.CopyHeader(header)
+	otherHeader.Number = big.NewInt(2)
+	otherHeaderInput.BlockHeader, err = rlp.EncodeToBytes(otherHeader)
+	require.NoError(err)
+
+	invalidProofInput := validInput
+	invalidProofInput.AccountProof = accountProof[1:]
+
+	setupBlockContext := func(predicateResults []byte) func(*contract.MockBlockContext) {
+		return func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Timestamp().Return(uint64(0)).AnyTimes()
+			mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(predicateResults)
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"prove storage value": {
+			Caller:            account,
+			InputFn:           func(t testing.TB) []byte { return packInput(validInput) },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       inputGas(validInput) + predicateGas,
+			ReadOnly:          true,
+			ExpectedRes:       packOutput(slot, value),
+		},
+		"prove missing storage value": {
+			Caller:            account,
+			InputFn:           func(t testing.TB) []byte { return packInput(missingSlotInput) },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       inputGas(missingSlotInput) + predicateGas,
+			ReadOnly:          true,
+			ExpectedRes:       packOutput(missingSlot, common.Hash{}),
+		},
+		"invalid predicate": {
+			Caller:            account,
+			InputFn:           func(t testing.TB) []byte { return packInput(validInput) },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext(set.NewBits(0).Bytes()),
+			SuppliedGas:       inputGas(validInput),
+			ReadOnly:          true,
+			ExpectedRes:       getVerifiedWarpStorageValueInvalidOutput,
+		},
+		"block header does not match block hash": {
+			Caller:            account,
+			InputFn:           func(t testing.TB) []byte { return packInput(otherHeaderInput) },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       inputGas(otherHeaderInput) + predicateGas,
+			ReadOnly:          true,
+			ExpectedErr:       errBlockHeaderMismatch.Error(),
+		},
+		"invalid account proof": {
+			Caller:            account,
+			InputFn:           func(t testing.TB) []byte { return packInput(invalidProofInput) },
+			BeforeHook:        setPredicate,
+			SetupBlockContext: setupBlockContext(noFailures),
+			SuppliedGas:       inputGas(invalidProofInput) + predicateGas,
+			ReadOnly:          true,
+			ExpectedErr:       errInvalidAccountProof.Error(),
+		},
+		"insufficient gas for proof": {
+			Caller:      account,
+			InputFn:     func(t testing.TB) []byte { return packInput(validInput) },
+			SuppliedGas: inputGas(validInput) - 1,
+			ReadOnly:    true,
+			ExpectedErr: "out of gas",
+		},
+		"before activation": {
+			Caller:      account,
+			InputFn:     func(t testing.TB) []byte { return packInput(validInput) },
+			ChainConfig: newDUpgradeChainConfig(t, false),
+			SuppliedGas: 0,
+			ReadOnly:    true,
+			ExpectedErr: "invalid non-activated function selector",
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}